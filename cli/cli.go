@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 )
 
 var (
@@ -24,13 +25,16 @@ type CommandFunc = func(flags *flag.FlagSet, printer *Printer) error
 // It should be linked to a [CommandSet] to establish a tree of commands available to the user.
 type Command struct {
 	CommandSet
-	flags      *flag.FlagSet
-	exec       CommandFunc
-	key        string
-	parent     string
-	shortUsage string
-	printer    *Printer
-	aliases    []string
+	flags       *flag.FlagSet
+	exec        CommandFunc
+	key         string
+	parent      string
+	shortUsage  string
+	printer     *Printer
+	aliases     []string
+	propagated  []*flag.Flag
+	inherited   []*flag.Flag
+	constraints []flagConstraint
 }
 
 func cleanseKey(key string) string {
@@ -43,6 +47,8 @@ func newCommand(key, parent, shortUsage string, printer *Printer) *Command {
 	fs.BoolP("help", "h", false, "Prints this usage information")
 	fs.SetInterspersed(false)
 	cmd := &Command{flags: fs, key: key, parent: parent, shortUsage: shortUsage, printer: printer}
+	// Sub-commands share the same Printer, so output settings apply to the whole command tree.
+	cmd.CommandSet.printer = printer
 	if len(parent) > 0 {
 		cmd.CommandSet.parent = strings.Join([]string{parent, key}, " ")
 	} else {
@@ -82,6 +88,40 @@ func (c *Command) Flags() *flag.FlagSet {
 	return c.flags
 }
 
+// Propagate marks flags already defined in this [Command]'s [flag.FlagSet] as inherited by all sub-commands, recursively.
+// This is useful for flags like --verbose or --config that apply to a whole tree of commands, without resorting to global state.
+//
+// Sub-commands share the same flag value, so it may be read from either the sub-command's flags or this command's flags.
+// If a sub-command defines a flag with the same name, then the sub-command's flag takes precedence and the propagated flag is not added.
+// If only the shorthand conflicts, then the propagated flag is added without its shorthand.
+//
+// Propagating a flag that isn't defined will panic, since that's a programming error.
+func (c *Command) Propagate(names ...string) *Command {
+	for _, name := range names {
+		f := c.flags.Lookup(name)
+		if f == nil {
+			panic(fmt.Sprintf("cannot propagate undefined flag '%s'", name))
+		}
+		c.propagated = append(c.propagated, f)
+	}
+	return c
+}
+
+func (c *Command) inherit(flags []*flag.Flag) {
+	c.inherited = flags
+	for _, f := range flags {
+		if c.flags.Lookup(f.Name) != nil {
+			continue
+		}
+		if len(f.Shorthand) > 0 && c.flags.ShorthandLookup(f.Shorthand) != nil {
+			noShort := *f
+			noShort.Shorthand = ""
+			f = &noShort
+		}
+		c.flags.AddFlag(f)
+	}
+}
+
 // Usage allows specifying a longer description of the [Command] that will be output when a [HelpPatterns] flag is passed.
 //
 // The short description, flag usages, and sub-command usages will be appended to this description.
@@ -111,6 +151,12 @@ func (c *Command) Usage(format string, args ...any) *Command {
 		}
 		buf.WriteString("\nFLAGS\n")
 		buf.WriteString(c.flags.FlagUsages())
+		if len(c.constraints) > 0 {
+			buf.WriteString("\nCONSTRAINTS\n")
+			for _, constraint := range c.constraints {
+				buf.WriteString("  " + constraint.description + "\n")
+			}
+		}
 		if len(c.CommandSet.commands) > 0 {
 			buf.WriteString("\nCOMMANDS\n")
 			buf.WriteString(c.CommandUsages())
@@ -122,6 +168,7 @@ func (c *Command) Usage(format string, args ...any) *Command {
 
 // Exec executes the command with given arguments, parsing flags.
 func (c *Command) Exec(args []string) error {
+	c.CommandSet.propagate = append(slices.Clip(c.inherited), c.propagated...)
 	if err := c.CommandSet.Exec(args); err != nil {
 		if !errors.Is(err, ErrUnknownCommand) {
 			return err
@@ -139,11 +186,22 @@ func (c *Command) Exec(args []string) error {
 		c.flags.Usage()
 		return nil
 	}
-	if err := runGlobalPreExec(); err != nil {
-		return err
-	}
+	start := time.Now()
+	err := c.run()
+	reportUsage(strings.TrimSpace(c.CommandPath()), start, err)
+	return err
+}
+
+// run executes the command after flags have been parsed.
+func (c *Command) run() error {
 	out := c.Printer()
-	err := c.exec(c.flags, out)
+	err := c.checkConstraints()
+	if err == nil {
+		if err := runGlobalPreExec(); err != nil {
+			return err
+		}
+		err = c.exec(c.flags, out)
+	}
 	if err != nil {
 		if errors.Is(err, &UsageError{}) {
 			out.Println(err.Error())
@@ -164,6 +222,8 @@ type CommandSet struct {
 	aliases  map[string]*Command
 	printer  *Printer
 	parent   string
+	// propagate holds flags that sub-commands should inherit, set right before sub-command execution.
+	propagate []*flag.Flag
 }
 
 // NewCommandSet is used to set up a top level [CommandSet] as the root of a CLI's command structure.
@@ -234,13 +294,18 @@ func (s *CommandSet) Exec(args []string) error {
 			return fmt.Errorf("%w: %s", ErrUnknownCommand, args[0])
 		}
 	}
+	cmd.inherit(s.propagate)
 	return cmd.Exec(args[1:])
 }
 
 // RespondUsage will print usage information with the given [Printer] if one of [HelpPatterns] is given as the first argument.
 // If usage information was printed, then true will be returned.
 func (s *CommandSet) RespondUsage(format string, vals ...any) bool {
-	args := os.Args[1:]
+	return s.RespondUsageArgs(os.Args[1:], format, vals...)
+}
+
+// RespondUsageArgs is the same as [CommandSet.RespondUsage], but checks the given arguments instead of [os.Args].
+func (s *CommandSet) RespondUsageArgs(args []string, format string, vals ...any) bool {
 	if len(args) == 0 {
 		return false
 	}
@@ -253,7 +318,7 @@ func (s *CommandSet) RespondUsage(format string, vals ...any) bool {
 
 COMMANDS:
 %s`, s.parent, text, s.CommandUsages())
-		s.printer.Print(usage)
+		s.Printer().Print(usage)
 		return true
 	}
 	return false
@@ -3,7 +3,6 @@ package cli
 import (
 	flag "github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
-	"os"
 	"testing"
 )
 
@@ -74,13 +73,9 @@ func TestPrinter_RespondUsage(t *testing.T) {
 	cmdExecuted := 0
 	subExecuted := 0
 	cmd := testCommandSet(t, &cmdExecuted, &subExecuted)
-	tmp := os.Args
-	t.Cleanup(func() {
-		os.Args = tmp
-	})
-	os.Args = []string{"command", HelpPatterns[0], "something", "else"}
-	responded := cmd.RespondUsage("Printed usage")
+	responded := cmd.RespondUsageArgs([]string{HelpPatterns[0], "something", "else"}, "Printed usage")
 	assert.True(t, responded, "Should have responded with cmd usage")
+	assert.False(t, cmd.RespondUsageArgs([]string{"test"}, "Printed usage"))
 }
 
 func testCommandSet(t *testing.T, cmdExecuted, subExecuted *int) *CommandSet {
@@ -116,3 +111,39 @@ func testCommandWithSubcommand(t *testing.T, cmdExecuted, subExecuted *int) *Com
 	})
 	return cmd
 }
+
+func TestCommand_Propagate(t *testing.T) {
+	set := NewCommandSet("commands")
+	parent := set.AddCommand("parent", "parent command")
+	verbose := parent.Flags().BoolP("verbose", "v", false, "Enables verbose output")
+	parent.Flags().StringP("config", "c", "", "Config file")
+	parent.Flags().String("local", "", "Not propagated")
+	parent.Propagate("verbose", "config")
+
+	mid := parent.AddCommand("mid", "middle command")
+	var (
+		leafVerbose bool
+		leafConfig  string
+		hasLocal    bool
+	)
+	leaf := mid.AddCommand("leaf", "leaf command").Does(func(flags *flag.FlagSet, _ *Printer) error {
+		leafVerbose = MustGet(flags.GetBool("verbose"))
+		leafConfig = MustGet(flags.GetString("config"))
+		hasLocal = flags.Lookup("local") != nil
+		return nil
+	})
+	leaf.Flags().StringP("color", "c", "", "Conflicting shorthand")
+
+	assert.NoError(t, set.Exec([]string{"parent", "mid", "leaf", "-v", "--config", "app.yaml"}))
+	assert.True(t, leafVerbose)
+	assert.True(t, *verbose, "Value should be shared with the parent flag")
+	assert.Equal(t, "app.yaml", leafConfig)
+	assert.False(t, hasLocal, "Only marked flags should be propagated")
+	assert.Equal(t, "color", leaf.Flags().ShorthandLookup("c").Name, "Leaf shorthand should take precedence")
+
+	assert.NoError(t, set.Exec([]string{"parent", "mid", "leaf", "-v"}), "Repeated execution should not redefine flags")
+
+	assert.Panics(t, func() {
+		parent.Propagate("undefined")
+	})
+}
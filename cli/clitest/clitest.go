@@ -0,0 +1,81 @@
+// Package clitest provides helpers for testing a [cli.CommandSet] without touching [os.Args], [os.Stdin], or [os.Stderr].
+package clitest
+
+import (
+	"bytes"
+	"github.com/saylorsolutions/x/cli"
+	"io"
+	"strings"
+)
+
+// Result is the outcome of executing a [cli.CommandSet].
+type Result struct {
+	// Output is everything written to the [cli.Printer] during execution.
+	Output string
+	// Err is the error returned from [cli.CommandSet.Exec].
+	Err error
+}
+
+// Lines returns the non-empty lines of the [Result] Output.
+func (r Result) Lines() []string {
+	var lines []string
+	for _, line := range strings.Split(r.Output, "\n") {
+		if len(strings.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Exec executes the [cli.CommandSet] with the given arguments, capturing all [cli.Printer] output.
+// Arguments should not include the program name, the same as os.Args[1:].
+//
+// The Printer's output and input are restored after execution, so the same set may be executed many times.
+func Exec(set *cli.CommandSet, args ...string) Result {
+	return ExecInput(set, strings.NewReader(""), args...)
+}
+
+// ExecInput is the same as [Exec], but commands will read from stdin through [cli.Printer.Input].
+func ExecInput(set *cli.CommandSet, stdin io.Reader, args ...string) Result {
+	p := set.Printer()
+	var (
+		buf       bytes.Buffer
+		origOut   = p.Writer()
+		origIn    = p.Input()
+		origColor = p.Color()
+		origWidth = p.Width()
+	)
+	p.Redirect(&buf)
+	p.SetInput(stdin)
+	defer func() {
+		p.Redirect(origOut)
+		p.SetInput(origIn)
+		p.SetColor(origColor)
+		p.SetWidth(origWidth)
+	}()
+	err := set.Exec(args)
+	return Result{
+		Output: buf.String(),
+		Err:    err,
+	}
+}
+
+// Usage captures the output of [cli.CommandSet.RespondUsageArgs] with the given arguments.
+// The returned bool is true if usage was printed.
+func Usage(set *cli.CommandSet, args []string, format string, vals ...any) (string, bool) {
+	p := set.Printer()
+	var (
+		buf       bytes.Buffer
+		origOut   = p.Writer()
+		origColor = p.Color()
+		origWidth = p.Width()
+	)
+	p.Redirect(&buf)
+	defer func() {
+		p.Redirect(origOut)
+		p.SetColor(origColor)
+		p.SetWidth(origWidth)
+	}()
+	responded := set.RespondUsageArgs(args, format, vals...)
+	return buf.String(), responded
+}
@@ -0,0 +1,70 @@
+package clitest
+
+import (
+	"bufio"
+	"errors"
+	"github.com/saylorsolutions/x/cli"
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testSet() *cli.CommandSet {
+	set := cli.NewCommandSet("app")
+	greet := set.AddCommand("greet", "Greets someone")
+	greet.Flags().String("name", "world", "Who to greet")
+	greet.Does(func(flags *flag.FlagSet, p *cli.Printer) error {
+		p.Printf("Hello, %s!\n", cli.MustGet(flags.GetString("name")))
+		return nil
+	})
+	set.AddCommand("echo", "Echoes stdin").Does(func(_ *flag.FlagSet, p *cli.Printer) error {
+		scanner := bufio.NewScanner(p.Input())
+		for scanner.Scan() {
+			p.Println(scanner.Text())
+		}
+		return scanner.Err()
+	})
+	set.AddCommand("fail", "Always fails").Does(func(_ *flag.FlagSet, p *cli.Printer) error {
+		p.Error("something went wrong")
+		return errors.New("failed")
+	})
+	return set
+}
+
+func TestExec(t *testing.T) {
+	set := testSet()
+	result := Exec(set, "greet", "--name", "tester")
+	assert.NoError(t, result.Err)
+	assert.Equal(t, "Hello, tester!\n", result.Output)
+
+	result = Exec(set, "fail")
+	assert.EqualError(t, result.Err, "failed")
+	assert.Equal(t, []string{"something went wrong"}, result.Lines())
+
+	result = Exec(set, "unknown")
+	assert.ErrorIs(t, result.Err, cli.ErrUnknownCommand)
+
+	result = Exec(set, "greet", "-h")
+	assert.NoError(t, result.Err)
+	assert.Contains(t, result.Output, "Who to greet")
+
+	assert.Equal(t, os.Stderr, set.Printer().Writer(), "Output should be restored")
+}
+
+func TestExecInput(t *testing.T) {
+	result := ExecInput(testSet(), strings.NewReader("line 1\nline 2\n"), "echo")
+	assert.NoError(t, result.Err)
+	assert.Equal(t, []string{"line 1", "line 2"}, result.Lines())
+}
+
+func TestUsage(t *testing.T) {
+	out, ok := Usage(testSet(), []string{"--help"}, "A test app")
+	assert.True(t, ok)
+	assert.Contains(t, out, "A test app")
+	assert.Contains(t, out, "greet")
+
+	_, ok = Usage(testSet(), []string{"greet"}, "A test app")
+	assert.False(t, ok)
+}
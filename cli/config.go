@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/configx"
+	flag "github.com/spf13/pflag"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AddConfigCommands adds a "config" sub-command to the [CommandSet] with these sub-commands, based on the [configx.Loader].
+//
+//   - init: Writes a config file with all default values, to the first file configured in the loader or the given --path.
+//   - show: Loads the config and prints each resolved value along with its source.
+//   - validate: Loads the config and reports whether it's valid.
+//
+// Only JSON config files can be written by init, since that's the only format supported by [configx] out of the box.
+func AddConfigCommands[T any](set *CommandSet, loader *configx.Loader[T]) *Command {
+	if loader == nil {
+		panic("nil config loader")
+	}
+	cmd := set.AddCommand("config", "Manages configuration", "cfg")
+	cmd.Usage("Commands for creating, inspecting, and validating configuration.")
+
+	var (
+		path  string
+		force bool
+	)
+	initCmd := cmd.AddCommand("init", "Writes a config file with default values")
+	var defaultPath string
+	if files := loader.Files(); len(files) > 0 {
+		defaultPath = files[0]
+	}
+	initCmd.Flags().StringVar(&path, "path", defaultPath, "Path of the config file to write")
+	initCmd.Flags().BoolVar(&force, "force", false, "Overwrites an existing config file")
+	initCmd.Does(func(_ *flag.FlagSet, p *Printer) error {
+		if len(path) == 0 {
+			return NewUsageError("no config file path configured, use --path to specify one")
+		}
+		if ext := strings.ToLower(filepath.Ext(path)); ext != ".json" {
+			return fmt.Errorf("%w: cannot write '%s' files", configx.ErrUnsupportedFormat, ext)
+		}
+		data, err := json.MarshalIndent(loader.Defaults(), "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if !force {
+			flags |= os.O_EXCL
+		}
+		f, err := os.OpenFile(path, flags, 0644)
+		if err != nil {
+			if errors.Is(err, os.ErrExist) {
+				return fmt.Errorf("config file '%s' already exists, use --force to overwrite it", path)
+			}
+			return err
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		p.Success("Wrote default config to %s", path)
+		return nil
+	})
+
+	cmd.AddCommand("show", "Prints resolved config values and their sources").Does(func(_ *flag.FlagSet, p *Printer) error {
+		if _, err := loader.Load(); err != nil {
+			return err
+		}
+		values := loader.Describe()
+		rows := make([][]string, len(values))
+		for i, val := range values {
+			rows[i] = []string{val.Path, fmt.Sprint(val.Value), val.Source.String()}
+		}
+		p.Table([]string{"FIELD", "VALUE", "SOURCE"}, rows)
+		return nil
+	})
+
+	cmd.AddCommand("validate", "Checks that the config can be loaded and is valid").Does(func(_ *flag.FlagSet, p *Printer) error {
+		if _, err := loader.Load(); err != nil {
+			return err
+		}
+		p.Success("Configuration is valid")
+		return nil
+	})
+	return cmd
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/saylorsolutions/x/configx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testCLIConfig struct {
+	Name string `json:"name" default:"app"`
+	Port int    `json:"port" default:"8080"`
+}
+
+func (c *testCLIConfig) Validate() error {
+	if c.Port <= 0 {
+		return errors.New("port must be positive")
+	}
+	return nil
+}
+
+func testConfigSet(t *testing.T, path string) (*CommandSet, *bytes.Buffer) {
+	t.Helper()
+	loader, err := configx.NewLoader[testCLIConfig](configx.OptOptionalFile(path))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	set := NewCommandSet("app")
+	set.Printer().Redirect(&buf)
+	AddConfigCommands(set, loader)
+	return set, &buf
+}
+
+func TestAddConfigCommands_Init(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conf", "config.json")
+	set, _ := testConfigSet(t, path)
+	require.NoError(t, set.Exec([]string{"config", "init"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var conf testCLIConfig
+	require.NoError(t, json.Unmarshal(data, &conf))
+	assert.Equal(t, testCLIConfig{Name: "app", Port: 8080}, conf)
+
+	assert.Error(t, set.Exec([]string{"config", "init"}), "Should not overwrite without --force")
+	assert.NoError(t, set.Exec([]string{"config", "init", "--force"}))
+
+	other := filepath.Join(t.TempDir(), "config.yaml")
+	err = set.Exec([]string{"config", "init", "--path", other})
+	assert.ErrorIs(t, err, configx.ErrUnsupportedFormat)
+}
+
+func TestAddConfigCommands_ShowValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"port": 9000}`), 0600))
+	set, buf := testConfigSet(t, path)
+	set.Printer().SetMachineReadable(true)
+
+	require.NoError(t, set.Exec([]string{"config", "show"}))
+	assert.Equal(t, "FIELD\tVALUE\tSOURCE\nName\tapp\tdefault\nPort\t9000\tfile\n", buf.String())
+
+	require.NoError(t, set.Exec([]string{"config", "validate"}))
+	require.NoError(t, os.WriteFile(path, []byte(`{"port": -1}`), 0600))
+	assert.Error(t, set.Exec([]string{"config", "validate"}))
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	flag "github.com/spf13/pflag"
+	"strings"
+)
+
+// flagConstraint is a rule about how flags may be used together, checked after parsing.
+type flagConstraint struct {
+	description string
+	check       func(flags *flag.FlagSet) error
+}
+
+func (c *Command) lookupFlags(names []string) {
+	for _, name := range names {
+		if c.flags.Lookup(name) == nil {
+			panic(fmt.Sprintf("cannot constrain undefined flag '%s'", name))
+		}
+	}
+}
+
+func (c *Command) addConstraint(description string, check func(flags *flag.FlagSet) error) *Command {
+	c.constraints = append(c.constraints, flagConstraint{description: description, check: check})
+	return c
+}
+
+func (c *Command) checkConstraints() error {
+	for _, constraint := range c.constraints {
+		if err := constraint.check(c.flags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flagList(names []string, conj string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "--" + name
+	}
+	if len(quoted) <= 2 {
+		return strings.Join(quoted, " "+conj+" ")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + ", " + conj + " " + quoted[len(quoted)-1]
+}
+
+func changed(flags *flag.FlagSet, names []string) (set, unset []string) {
+	for _, name := range names {
+		if flags.Changed(name) {
+			set = append(set, name)
+		} else {
+			unset = append(unset, name)
+		}
+	}
+	return set, unset
+}
+
+// RequireTogether requires that if any of the named flags are given, then all of them must be given.
+// This is useful for flags that don't make sense alone, like a username and password.
+//
+// Constraints are checked after parsing and before the [CommandFunc] is called, and a violation returns a [UsageError].
+// Constraining a flag that isn't defined will panic, since that's a programming error.
+func (c *Command) RequireTogether(names ...string) *Command {
+	c.lookupFlags(names)
+	return c.addConstraint(flagList(names, "and")+" must be used together", func(flags *flag.FlagSet) error {
+		set, unset := changed(flags, names)
+		if len(set) > 0 && len(unset) > 0 {
+			return NewUsageError("%s must be used with %s", flagList(unset, "and"), flagList(set, "and"))
+		}
+		return nil
+	})
+}
+
+// MutuallyExclusive allows at most one of the named flags to be given.
+// This is useful for flags that select between alternatives, like output formats.
+//
+// See [Command.RequireTogether] for how constraints are checked.
+func (c *Command) MutuallyExclusive(names ...string) *Command {
+	c.lookupFlags(names)
+	return c.addConstraint("only one of "+flagList(names, "or")+" may be used", func(flags *flag.FlagSet) error {
+		if set, _ := changed(flags, names); len(set) > 1 {
+			return NewUsageError("%s cannot be used together", flagList(set, "and"))
+		}
+		return nil
+	})
+}
+
+// RequireOneOf requires that at least one of the named flags is given.
+// Combine with [Command.MutuallyExclusive] to require exactly one.
+//
+// See [Command.RequireTogether] for how constraints are checked.
+func (c *Command) RequireOneOf(names ...string) *Command {
+	c.lookupFlags(names)
+	description := "one of " + flagList(names, "or") + " is required"
+	if len(names) == 1 {
+		description = flagList(names, "") + " is required"
+	}
+	return c.addConstraint(description, func(flags *flag.FlagSet) error {
+		if set, _ := changed(flags, names); len(set) == 0 {
+			return NewUsageError("%s", description)
+		}
+		return nil
+	})
+}
+
+// RequiredIf requires the named flag to be given if the condition flag is given.
+// For example, RequiredIf("cert", "tls") requires --cert when --tls is used.
+//
+// See [Command.RequireTogether] for how constraints are checked.
+func (c *Command) RequiredIf(name, condition string) *Command {
+	c.lookupFlags([]string{name, condition})
+	description := fmt.Sprintf("--%s is required when --%s is used", name, condition)
+	return c.addConstraint(description, func(flags *flag.FlagSet) error {
+		if flags.Changed(condition) && !flags.Changed(name) {
+			return NewUsageError("%s", description)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testConstraintSet() (*CommandSet, *bytes.Buffer, *bool) {
+	var (
+		buf    bytes.Buffer
+		called bool
+	)
+	set := NewCommandSet("app")
+	set.Printer().Redirect(&buf)
+	cmd := set.AddCommand("login", "Logs in")
+	fs := cmd.Flags()
+	fs.String("user", "", "Username")
+	fs.String("pass", "", "Password")
+	fs.Bool("json", false, "JSON output")
+	fs.Bool("table", false, "Table output")
+	fs.Bool("tls", false, "Use TLS")
+	fs.String("cert", "", "Certificate file")
+	fs.String("token", "", "Token")
+	cmd.RequireTogether("user", "pass").
+		MutuallyExclusive("json", "table").
+		RequiredIf("cert", "tls").
+		RequireOneOf("user", "token").
+		Does(func(flags *flag.FlagSet, printer *Printer) error {
+			called = true
+			return nil
+		})
+	return set, &buf, &called
+}
+
+func TestCommand_Constraints(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"Valid":             {args: []string{"--user=a", "--pass=b", "--json"}},
+		"Token only":        {args: []string{"--token=t"}},
+		"Missing together":  {args: []string{"--user=a"}, err: "--pass must be used with --user"},
+		"Exclusive":         {args: []string{"--token=t", "--json", "--table"}, err: "--json and --table cannot be used together"},
+		"Required if":       {args: []string{"--token=t", "--tls"}, err: "--cert is required when --tls is used"},
+		"Required if valid": {args: []string{"--token=t", "--tls", "--cert=c"}},
+		"None of":           {args: []string{"--json"}, err: "one of --user or --token is required"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			set, buf, called := testConstraintSet()
+			err := set.Exec(append([]string{"login"}, tc.args...))
+			if len(tc.err) == 0 {
+				assert.NoError(t, err)
+				assert.True(t, *called)
+				return
+			}
+			assert.ErrorIs(t, err, &UsageError{})
+			assert.ErrorContains(t, err, tc.err)
+			assert.False(t, *called, "Command should not run if constraints are violated")
+			assert.Contains(t, buf.String(), "CONSTRAINTS", "Usage should be printed")
+		})
+	}
+}
+
+func TestCommand_ConstraintsUsage(t *testing.T) {
+	set, buf, _ := testConstraintSet()
+	assert.NoError(t, set.Exec([]string{"login", "--help"}))
+	assert.Contains(t, buf.String(), `CONSTRAINTS
+  --user and --pass must be used together
+  only one of --json or --table may be used
+  --cert is required when --tls is used
+  one of --user or --token is required
+`)
+}
+
+func TestCommand_ConstraintsUndefined(t *testing.T) {
+	set := NewCommandSet("app")
+	assert.Panics(t, func() {
+		set.AddCommand("cmd", "").MutuallyExclusive("missing", "other")
+	})
+}
+
+func TestFlagList(t *testing.T) {
+	assert.Equal(t, "--a", flagList([]string{"a"}, "or"))
+	assert.Equal(t, "--a or --b", flagList([]string{"a", "b"}, "or"))
+	assert.Equal(t, "--a, --b, or --c", flagList([]string{"a", "b", "c"}, "or"))
+}
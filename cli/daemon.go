@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	flag "github.com/spf13/pflag"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DaemonChildEnv is set in the environment of a daemon started in the background, so it knows not to start another.
+	DaemonChildEnv  = "CLI_DAEMON_CHILD"
+	envNotifySocket = "NOTIFY_SOCKET"
+	envWatchdogUsec = "WATCHDOG_USEC"
+	envWatchdogPID  = "WATCHDOG_PID"
+)
+
+var (
+	ErrDaemonRunning = errors.New("daemon is already running")
+)
+
+// DaemonFunc runs a long-running [Command] until the context is cancelled by SIGINT or SIGTERM.
+// The [Daemon] can be used to notify the service manager of progress.
+type DaemonFunc func(ctx context.Context, d *Daemon, flags *flag.FlagSet, p *Printer) error
+
+type daemonConf struct {
+	pidFile string
+	logFile string
+}
+
+type DaemonOption func(conf *daemonConf) error
+
+// OptPIDFile sets the default for the --pid-file flag.
+func OptPIDFile(path string) DaemonOption {
+	return func(conf *daemonConf) error {
+		if len(path) == 0 {
+			return errors.New("empty PID file path")
+		}
+		conf.pidFile = path
+		return nil
+	}
+}
+
+// OptLogFile sets the default for the --log-file flag.
+func OptLogFile(path string) DaemonOption {
+	return func(conf *daemonConf) error {
+		if len(path) == 0 {
+			return errors.New("empty log file path")
+		}
+		conf.logFile = path
+		return nil
+	}
+}
+
+// Daemon is passed to a [DaemonFunc] to interact with the service manager.
+// Notifications are only sent when the --systemd flag is given and systemd provided a notification socket, otherwise they do nothing.
+type Daemon struct {
+	notifySocket string
+	watchdog     time.Duration
+}
+
+// Notify sends a raw state string to systemd with the sd_notify protocol, like "READY=1".
+// This does nothing if notifications aren't enabled.
+//
+// Source: https://www.freedesktop.org/software/systemd/man/latest/sd_notify.html
+func (d *Daemon) Notify(state string) error {
+	if len(d.notifySocket) == 0 {
+		return nil
+	}
+	addr := d.notifySocket
+	if strings.HasPrefix(addr, "@") {
+		// Abstract namespace socket
+		addr = "\x00" + addr[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: addr, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("failed to connect to notify socket: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// Ready tells the service manager that startup is complete.
+// Services with Type=notify should call this once they're ready to accept work.
+func (d *Daemon) Ready() error {
+	return d.Notify("READY=1")
+}
+
+// Status sends a single line status message that is shown by "systemctl status".
+func (d *Daemon) Status(format string, args ...any) error {
+	return d.Notify("STATUS=" + strings.ReplaceAll(fmt.Sprintf(format, args...), "\n", " "))
+}
+
+// WatchdogInterval returns the interval that systemd expects watchdog pings within, or 0 if the watchdog isn't enabled.
+// Pings are sent automatically at half this interval while the [DaemonFunc] runs.
+func (d *Daemon) WatchdogInterval() time.Duration {
+	return d.watchdog
+}
+
+func (d *Daemon) runWatchdog(ctx context.Context) {
+	if d.watchdog <= 0 {
+		return
+	}
+	ticker := time.NewTicker(d.watchdog / 2)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = d.Notify("WATCHDOG=1")
+			}
+		}
+	}()
+}
+
+func newDaemon(systemd bool) *Daemon {
+	d := new(Daemon)
+	if !systemd {
+		return d
+	}
+	d.notifySocket = os.Getenv(envNotifySocket)
+	if usec, err := strconv.ParseInt(os.Getenv(envWatchdogUsec), 10, 64); err == nil && usec > 0 {
+		// WATCHDOG_PID is only set if the watchdog is meant for a specific process.
+		if pid := os.Getenv(envWatchdogPID); len(pid) == 0 || pid == strconv.Itoa(os.Getpid()) {
+			d.watchdog = time.Duration(usec) * time.Microsecond
+		}
+	}
+	return d
+}
+
+// Daemon sets up this [Command] to run a long-running process that behaves well under service managers.
+// These flags are added to the [Command]:
+//
+//   - --pid-file: Writes the process ID to this file while running, and refuses to start if another live process holds it.
+//   - --log-file: Appends output to this file instead of the [Printer]'s writer.
+//   - --background: Starts the command as a detached background process and returns immediately.
+//   - --systemd: Enables sd_notify integration, sending STOPPING=1 on exit and watchdog pings if systemd requests them.
+//
+// The [DaemonFunc] should call [Daemon.Ready] once startup is complete.
+// The context passed to the [DaemonFunc] is cancelled when SIGINT or SIGTERM is received.
+//
+// Options that fail to apply will panic, since that's a programming error.
+func (c *Command) Daemon(run DaemonFunc, opts ...DaemonOption) *Command {
+	if run == nil {
+		panic("nil daemon func")
+	}
+	var conf daemonConf
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			panic(err)
+		}
+	}
+	var (
+		pidFile    string
+		logFile    string
+		background bool
+		systemd    bool
+	)
+	c.flags.StringVar(&pidFile, "pid-file", conf.pidFile, "Writes the process ID to this file while running")
+	c.flags.StringVar(&logFile, "log-file", conf.logFile, "Appends output to this file")
+	c.flags.BoolVar(&background, "background", false, "Starts as a detached background process")
+	c.flags.BoolVar(&systemd, "systemd", false, "Enables systemd notifications")
+	return c.Does(func(flags *flag.FlagSet, p *Printer) error {
+		if background && len(os.Getenv(DaemonChildEnv)) == 0 {
+			pid, err := startBackground(logFile)
+			if err != nil {
+				return err
+			}
+			p.Success("Started in the background with PID %d", pid)
+			return nil
+		}
+		if len(logFile) > 0 {
+			f, err := os.OpenFile(logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+			if err != nil {
+				return fmt.Errorf("failed to open log file: %w", err)
+			}
+			defer func() {
+				_ = f.Close()
+			}()
+			prev := p.Writer()
+			p.Redirect(f)
+			defer p.Redirect(prev)
+		}
+		if len(pidFile) > 0 {
+			if err := writePIDFile(pidFile); err != nil {
+				return err
+			}
+			defer func() {
+				_ = os.Remove(pidFile)
+			}()
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		d := newDaemon(systemd)
+		d.runWatchdog(ctx)
+		defer func() {
+			_ = d.Notify("STOPPING=1")
+		}()
+		return run(ctx, d, flags, p)
+	})
+}
+
+// writePIDFile writes the current process ID to the file, unless it's held by another live process.
+func writePIDFile(path string) error {
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err == nil && pid != os.Getpid() && processAlive(pid) {
+			return fmt.Errorf("%w: PID %d in '%s'", ErrDaemonRunning, pid, path)
+		}
+		// Stale PID file, overwrite it.
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644)
+}
+
+// startBackground starts this executable again with the same arguments as a detached process.
+func startBackground(logFile string) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+	if len(logFile) == 0 {
+		logFile = os.DevNull
+	}
+	out, err := os.OpenFile(logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), DaemonChildEnv+"=1")
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.SysProcAttr = detachedProcAttr()
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	pid := cmd.Process.Pid
+	return pid, cmd.Process.Release()
+}
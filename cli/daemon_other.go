@@ -0,0 +1,22 @@
+//go:build !unix
+
+package cli
+
+import (
+	"os"
+	"syscall"
+)
+
+func processAlive(pid int) bool {
+	// FindProcess opens a handle to the process on Windows, so it fails if the process doesn't exist.
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = proc.Release()
+	return true
+}
+
+func detachedProcAttr() *syscall.SysProcAttr {
+	return nil
+}
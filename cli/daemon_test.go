@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommand_Daemon(t *testing.T) {
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "app.pid")
+	logFile := filepath.Join(dir, "app.log")
+	sock := filepath.Join(dir, "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: sock, Net: "unixgram"})
+	require.NoError(t, err)
+	defer func() {
+		_ = conn.Close()
+	}()
+	t.Setenv(envNotifySocket, sock)
+	t.Setenv(envWatchdogUsec, strconv.Itoa(int((20 * time.Millisecond).Microseconds())))
+
+	var buf bytes.Buffer
+	set := NewCommandSet("app")
+	set.Printer().Redirect(&buf)
+	set.AddCommand("serve", "Runs the server").Daemon(func(ctx context.Context, d *Daemon, flags *flag.FlagSet, p *Printer) error {
+		data, err := os.ReadFile(pidFile)
+		require.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(os.Getpid()), strings.TrimSpace(string(data)))
+		assert.Equal(t, 20*time.Millisecond, d.WatchdogInterval())
+		p.Println("running")
+		require.NoError(t, d.Ready())
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	}, OptPIDFile(pidFile))
+
+	require.NoError(t, set.Exec([]string{"serve", "--systemd", "--log-file", logFile}))
+	assert.NoFileExists(t, pidFile, "PID file should be removed on exit")
+	assert.Empty(t, buf.String(), "Output should be redirected to the log file")
+	data, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, "running\n", string(data))
+
+	var states []string
+	msg := make([]byte, 256)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	for {
+		n, err := conn.Read(msg)
+		if err != nil {
+			break
+		}
+		states = append(states, string(msg[:n]))
+		if states[len(states)-1] == "STOPPING=1" {
+			break
+		}
+	}
+	assert.Equal(t, "READY=1", states[0])
+	assert.Contains(t, states, "WATCHDOG=1")
+	assert.Equal(t, "STOPPING=1", states[len(states)-1])
+}
+
+func TestCommand_Daemon_Running(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "app.pid")
+	// The parent process is alive and isn't this process, so the PID file is considered held.
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getppid())), 0644))
+
+	var called bool
+	set := NewCommandSet("app")
+	set.Printer().Redirect(&bytes.Buffer{})
+	set.AddCommand("serve", "Runs the server").Daemon(func(ctx context.Context, d *Daemon, flags *flag.FlagSet, p *Printer) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, set.Exec([]string{"serve", "--pid-file", pidFile}), ErrDaemonRunning)
+	assert.False(t, called)
+
+	require.NoError(t, os.WriteFile(pidFile, []byte("not a pid"), 0644))
+	assert.NoError(t, set.Exec([]string{"serve", "--pid-file", pidFile}), "Invalid PID files should be overwritten")
+	assert.True(t, called)
+}
+
+func TestDaemon_NotifyDisabled(t *testing.T) {
+	t.Setenv(envNotifySocket, filepath.Join(t.TempDir(), "missing.sock"))
+	d := newDaemon(false)
+	assert.NoError(t, d.Ready(), "Notifications should do nothing without --systemd")
+	assert.Zero(t, d.WatchdogInterval())
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package cli
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+func detachedProcAttr() *syscall.SysProcAttr {
+	// A new session detaches the process from the controlling terminal, so it isn't sent SIGHUP when the terminal closes.
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -7,6 +7,7 @@ There are a few reasonable (IMHO) policies for how this operates.
   - This package uses [pflag] for posix style flags.
   - Flags should NOT be interspersed by default. This makes flag and argument parsing much more consistent and predictable, but can be overridden.
   - Global flags are often confusing and not necessary. Flags apply to the command at hand, while global state may be configured through other means.
+    When a flag really does apply to a whole tree of commands, a parent [Command] may opt in to sharing it with [Command.Propagate].
   - Sub-command aliases are often very convenient, so they're supported as additional, optional parameters to [CommandSet.AddCommand].
 
 # Invocation
@@ -47,6 +48,26 @@ For more robust interactivity, I can recommend [tview] as a great tool for full
 It's easy to use, and quick to get productive.
 I haven't tried many alternatives because this works well for me. YMMV.
 
+# Long-running Commands
+
+A [Command] that runs a server or worker can use [Command.Daemon] to get PID file management, log redirection, background execution, and systemd notifications.
+The [DaemonFunc] is given a context that is cancelled on SIGINT or SIGTERM, and should call [Daemon.Ready] once it's ready to accept work.
+
+# Usage Reporting
+
+Tool owners can see which sub-commands are actually used by registering a [UsageSink] with [AddUsageSink].
+Each [Invocation] only includes the command path, duration, and status, and nothing is reported if the user sets one of the [UsageOptOutVars], like DO_NOT_TRACK.
+
+# Self Update
+
+[CommandSet.AddUpdateCommand] adds an "update" sub-command that downloads the latest [Release] for a channel from a [ReleaseSource], like [JSONReleaseSource].
+The download is verified with its SHA256 checksum before it replaces the running binary, and an [UpdateVerifier] can be added to check a signature.
+
+# Testing
+
+The cli/clitest package can execute a [CommandSet] with given arguments and input, and capture the output written to its [Printer].
+To support this, commands should read input from [Printer.Input] rather than [os.Stdin].
+
 [pflag]: https://github.com/spf13/pflag
 [tview]: https://github.com/rivo/tview
 */
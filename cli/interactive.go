@@ -26,7 +26,13 @@ var (
 //
 // This loop may be interrupted with one of the [InteractiveQuitCommands].
 func (s *CommandSet) RespondInteractive() bool {
-	args := os.Args[1:]
+	return s.RespondInteractiveArgs(os.Args[0], os.Args[1:])
+}
+
+// RespondInteractiveArgs is the same as [CommandSet.RespondInteractive], but checks the given arguments instead of [os.Args].
+// The command is the executable that will be invoked for each line of input, which is usually os.Args[0].
+// Input is read from [Printer.Input].
+func (s *CommandSet) RespondInteractiveArgs(command string, args []string) bool {
 	if len(args) == 0 {
 		return false
 	}
@@ -34,8 +40,8 @@ func (s *CommandSet) RespondInteractive() bool {
 		return false
 	}
 
-	if err := s.interactiveLoop(os.Args[0]); err != nil {
-		s.printer.Println("Error running command interactively:", err)
+	if err := s.interactiveLoop(command); err != nil {
+		s.Printer().Println("Error running command interactively:", err)
 	}
 	return true
 }
@@ -50,8 +56,8 @@ func (s *CommandSet) interactiveLoop(command string) error {
 		}
 		return commandStack[len(commandStack)-1]
 	}
-	scanner := bufio.NewScanner(os.Stdin)
-	p := s.printer
+	p := s.Printer()
+	scanner := bufio.NewScanner(p.Input())
 	p.Printf(`Running '%s' interactively. Enter %s to exit.
 Use the %s command with one or more sub-commands to push them to the execution stack, and %s to pop and return.
 `, command, strings.Join(InteractiveQuitCommands, " or "),
@@ -1,25 +1,160 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	flag "github.com/spf13/pflag"
 	"io"
 	"os"
+	"strconv"
+)
+
+// Verbosity controls which messages a [Printer] will output.
+type Verbosity int
+
+const (
+	VerbosityQuiet   Verbosity = -1 // VerbosityQuiet only outputs errors and direct Print calls.
+	VerbosityNormal  Verbosity = 0  // VerbosityNormal is the default.
+	VerbosityVerbose Verbosity = 1  // VerbosityVerbose also outputs [Printer.Verbose] messages.
+	VerbosityDebug   Verbosity = 2  // VerbosityDebug also outputs [Printer.Debug] messages.
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiDim    = "\x1b[2m"
 )
 
 // Printer is provided to easily establish policies for user messages.
-// It exposes Print, Println, and Printf methods.
+// It exposes Print, Println, and Printf methods, which always output regardless of verbosity.
+//
+// Leveled methods like [Printer.Success], [Printer.Warn], [Printer.Error], and [Printer.Verbose] respect the [Verbosity] and are colored when color is enabled.
+// Color is enabled by default when writing to a terminal, unless the NO_COLOR environment variable is set or TERM is "dumb".
+// [Printer.BindFlags] can be used to let the user control these settings with standard flags.
 //
 // Printer writes to [os.Stderr] by default, but this can be overridden with [Printer.Redirect].
+// Commands should read user input from [Printer.Input] rather than [os.Stdin], so it can be replaced in tests.
 type Printer struct {
-	out io.Writer
+	out       io.Writer
+	in        io.Reader
+	color     bool
+	verbosity Verbosity
+	machine   bool
+	width     int
 }
 
 func NewPrinter() *Printer {
-	return &Printer{out: os.Stderr}
+	return &Printer{out: os.Stderr, in: os.Stdin, color: detectColor(os.Stderr), width: detectWidth(isTerminal(os.Stderr))}
+}
+
+// detectColor returns true if the writer is a terminal and the environment doesn't disable color.
+// See https://no-color.org for NO_COLOR.
+func detectColor(w io.Writer) bool {
+	if len(os.Getenv("NO_COLOR")) > 0 || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isTerminal(w)
 }
 
+// isTerminal returns true if the writer is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Redirect changes where output is written.
+// Color and width detection are re-evaluated for the new writer.
 func (p *Printer) Redirect(writer io.Writer) {
 	p.out = writer
+	p.color = detectColor(writer) && !p.machine
+	p.width = detectWidth(isTerminal(writer))
+}
+
+// Writer returns the [io.Writer] that output is written to.
+func (p *Printer) Writer() io.Writer {
+	return p.out
+}
+
+// SetInput changes where user input is read from, which is [os.Stdin] by default.
+// This is mostly useful for testing commands that read input.
+func (p *Printer) SetInput(reader io.Reader) {
+	p.in = reader
+}
+
+// Input returns the [io.Reader] that commands should read user input from.
+func (p *Printer) Input() io.Reader {
+	if p.in == nil {
+		return os.Stdin
+	}
+	return p.in
+}
+
+// SetColor overrides color detection.
+func (p *Printer) SetColor(enabled bool) {
+	p.color = enabled
+}
+
+// Color returns whether color output is enabled.
+func (p *Printer) Color() bool {
+	return p.color
+}
+
+// SetVerbosity sets the [Verbosity] of leveled output.
+func (p *Printer) SetVerbosity(verbosity Verbosity) {
+	p.verbosity = verbosity
+}
+
+// Verbosity returns the current [Verbosity].
+func (p *Printer) Verbosity() Verbosity {
+	return p.verbosity
+}
+
+// SetMachineReadable enables machine-readable mode, which disables color.
+// Commands should check [Printer.MachineReadable] to decide whether to output structured data, like with [Printer.JSON].
+func (p *Printer) SetMachineReadable(enabled bool) {
+	p.machine = enabled
+	if enabled {
+		p.color = false
+	}
+}
+
+// MachineReadable returns whether the user has requested machine-readable output.
+func (p *Printer) MachineReadable() bool {
+	return p.machine
+}
+
+// BindFlags adds flags to the [flag.FlagSet] that configure this [Printer] when parsed.
+//
+//   - -v, --verbose: Increases verbosity, and may be repeated (-vv) for debug output.
+//   - -q, --quiet: Only outputs errors.
+//   - --no-color: Disables color output.
+//   - --machine: Enables machine-readable output, see [Printer.SetMachineReadable].
+//
+// Use [Command.Propagate] to make these flags available to all sub-commands.
+func (p *Printer) BindFlags(flags *flag.FlagSet) {
+	flags.VarPF(&verbosityValue{p: p}, "verbose", "v", "Increases output verbosity, may be repeated").NoOptDefVal = "+1"
+	flags.VarPF(&boolValue{set: func(val bool) {
+		if val {
+			p.verbosity = VerbosityQuiet
+		} else if p.verbosity == VerbosityQuiet {
+			p.verbosity = VerbosityNormal
+		}
+	}}, "quiet", "q", "Only outputs errors").NoOptDefVal = "true"
+	flags.VarPF(&boolValue{set: func(val bool) {
+		if val {
+			p.color = false
+		}
+	}}, "no-color", "", "Disables color output").NoOptDefVal = "true"
+	flags.VarPF(&boolValue{set: p.SetMachineReadable}, "machine", "", "Enables machine-readable output").NoOptDefVal = "true"
 }
 
 func (p *Printer) Print(msg ...any) {
@@ -33,3 +168,112 @@ func (p *Printer) Printf(format string, args ...any) {
 func (p *Printer) Println(msg ...any) {
 	_, _ = fmt.Fprintln(p.out, msg...)
 }
+
+func (p *Printer) styled(style, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if p.color && len(style) > 0 {
+		msg = style + msg + ansiReset
+	}
+	_, _ = fmt.Fprintln(p.out, msg)
+}
+
+// Info outputs a line unless the [Printer] is quiet.
+func (p *Printer) Info(format string, args ...any) {
+	if p.verbosity > VerbosityQuiet {
+		p.styled("", format, args...)
+	}
+}
+
+// Success outputs a line in green unless the [Printer] is quiet.
+func (p *Printer) Success(format string, args ...any) {
+	if p.verbosity > VerbosityQuiet {
+		p.styled(ansiGreen, format, args...)
+	}
+}
+
+// Warn outputs a line in yellow unless the [Printer] is quiet.
+func (p *Printer) Warn(format string, args ...any) {
+	if p.verbosity > VerbosityQuiet {
+		p.styled(ansiYellow, format, args...)
+	}
+}
+
+// Error outputs a line in red, regardless of [Verbosity].
+func (p *Printer) Error(format string, args ...any) {
+	p.styled(ansiRed, format, args...)
+}
+
+// Verbose outputs a line if the [Verbosity] is at least [VerbosityVerbose].
+func (p *Printer) Verbose(format string, args ...any) {
+	if p.verbosity >= VerbosityVerbose {
+		p.styled("", format, args...)
+	}
+}
+
+// Debug outputs a dimmed line if the [Verbosity] is at least [VerbosityDebug].
+func (p *Printer) Debug(format string, args ...any) {
+	if p.verbosity >= VerbosityDebug {
+		p.styled(ansiDim, format, args...)
+	}
+}
+
+// JSON outputs the value as JSON, regardless of [Verbosity].
+// Output is indented unless machine-readable mode is enabled.
+func (p *Printer) JSON(val any) error {
+	enc := json.NewEncoder(p.out)
+	if !p.machine {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(val)
+}
+
+type verbosityValue struct {
+	p *Printer
+}
+
+func (v *verbosityValue) String() string {
+	return strconv.Itoa(int(v.p.verbosity))
+}
+
+func (v *verbosityValue) Set(s string) error {
+	if s == "+1" {
+		if v.p.verbosity < VerbosityNormal {
+			v.p.verbosity = VerbosityNormal
+		}
+		v.p.verbosity++
+		return nil
+	}
+	level, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	v.p.verbosity = Verbosity(level)
+	return nil
+}
+
+func (v *verbosityValue) Type() string {
+	return "count"
+}
+
+type boolValue struct {
+	val bool
+	set func(bool)
+}
+
+func (b *boolValue) String() string {
+	return strconv.FormatBool(b.val)
+}
+
+func (b *boolValue) Set(s string) error {
+	val, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	b.val = val
+	b.set(val)
+	return nil
+}
+
+func (b *boolValue) Type() string {
+	return "bool"
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPrinter_Levels(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter()
+	p.Redirect(&buf)
+	assert.False(t, p.Color(), "Color should be disabled for non-terminal writers")
+
+	p.Info("info")
+	p.Success("success")
+	p.Warn("warn")
+	p.Error("error")
+	p.Verbose("verbose")
+	p.Debug("debug")
+	assert.Equal(t, "info\nsuccess\nwarn\nerror\n", buf.String())
+
+	buf.Reset()
+	p.SetVerbosity(VerbosityQuiet)
+	p.Info("info")
+	p.Warn("warn")
+	p.Error("error")
+	p.Print("print\n")
+	assert.Equal(t, "error\nprint\n", buf.String())
+
+	buf.Reset()
+	p.SetVerbosity(VerbosityDebug)
+	p.Verbose("verbose")
+	p.Debug("debug")
+	assert.Equal(t, "verbose\ndebug\n", buf.String())
+}
+
+func TestPrinter_Color(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter()
+	p.Redirect(&buf)
+	p.SetColor(true)
+	p.Success("done")
+	p.Error("failed %d", 1)
+	p.Info("plain")
+	assert.Equal(t, ansiGreen+"done"+ansiReset+"\n"+ansiRed+"failed 1"+ansiReset+"\nplain\n", buf.String())
+
+	p.SetMachineReadable(true)
+	assert.False(t, p.Color(), "Machine-readable mode should disable color")
+}
+
+func TestDetectColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, detectColor(nil))
+}
+
+func TestPrinter_BindFlags(t *testing.T) {
+	tests := map[string]struct {
+		args      []string
+		verbosity Verbosity
+		machine   bool
+	}{
+		"Default":       {nil, VerbosityNormal, false},
+		"Verbose":       {[]string{"-v"}, VerbosityVerbose, false},
+		"Debug":         {[]string{"-vv"}, VerbosityDebug, false},
+		"Explicit":      {[]string{"--verbose=2"}, VerbosityDebug, false},
+		"Quiet":         {[]string{"-q"}, VerbosityQuiet, false},
+		"Quiet verbose": {[]string{"-q", "-v"}, VerbosityVerbose, false},
+		"Machine":       {[]string{"--machine", "--no-color"}, VerbosityNormal, true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPrinter()
+			p.SetColor(true)
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			p.BindFlags(fs)
+			require.NoError(t, fs.Parse(tc.args))
+			assert.Equal(t, tc.verbosity, p.Verbosity())
+			assert.Equal(t, tc.machine, p.MachineReadable())
+			if tc.machine {
+				assert.False(t, p.Color())
+			}
+		})
+	}
+}
+
+func TestPrinter_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter()
+	p.Redirect(&buf)
+	require.NoError(t, p.JSON(map[string]int{"a": 1}))
+	assert.Equal(t, "{\n  \"a\": 1\n}\n", buf.String())
+
+	buf.Reset()
+	p.SetMachineReadable(true)
+	require.NoError(t, p.JSON(map[string]int{"a": 1}))
+	assert.Equal(t, "{\"a\":1}\n", buf.String())
+}
+
+func TestPrinter_Shared(t *testing.T) {
+	var buf bytes.Buffer
+	set := NewCommandSet("app")
+	set.Printer().Redirect(&buf)
+	cmd := set.AddCommand("cmd", "command")
+	set.Printer().BindFlags(cmd.Flags())
+	cmd.Propagate("verbose")
+	cmd.AddCommand("sub", "sub-command").Does(func(_ *flag.FlagSet, p *Printer) error {
+		p.Verbose("from sub")
+		return nil
+	})
+	require.NoError(t, set.Exec([]string{"cmd", "sub", "-v"}))
+	assert.Equal(t, "from sub\n", buf.String(), "Sub-commands should share the root Printer")
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	tableColumnGap   = "  "
+	tableMinColWidth = 8
+)
+
+// SetWidth sets the maximum line width used by [Printer.Table] and [Printer.KV].
+// A width <= 0 disables wrapping.
+//
+// By default, the width is read from the COLUMNS environment variable when writing to a terminal.
+func (p *Printer) SetWidth(width int) {
+	p.width = width
+}
+
+// Width returns the maximum line width used for wrapping, or 0 if wrapping is disabled.
+func (p *Printer) Width() int {
+	return p.width
+}
+
+// detectWidth returns the width from the COLUMNS environment variable if writing to a terminal, regardless of whether color is enabled.
+func detectWidth(terminal bool) int {
+	if !terminal {
+		return 0
+	}
+	width, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || width <= 0 {
+		return 0
+	}
+	return width
+}
+
+// Table outputs rows aligned into columns with a header row.
+// If the table is wider than [Printer.Width], then the widest columns are narrowed and their cells are wrapped onto multiple lines.
+//
+// In machine-readable mode, the table is output as tab separated values without alignment or wrapping.
+func (p *Printer) Table(headers []string, rows [][]string) {
+	if p.machine {
+		if len(headers) > 0 {
+			p.Println(strings.Join(headers, "\t"))
+		}
+		for _, row := range rows {
+			p.Println(strings.Join(row, "\t"))
+		}
+		return
+	}
+	numCols := len(headers)
+	for _, row := range rows {
+		numCols = max(numCols, len(row))
+	}
+	if numCols == 0 {
+		return
+	}
+	widths := make([]int, numCols)
+	measure := func(row []string) {
+		for i, cell := range row {
+			for _, line := range strings.Split(cell, "\n") {
+				widths[i] = max(widths[i], utf8.RuneCountInString(line))
+			}
+		}
+	}
+	measure(headers)
+	for _, row := range rows {
+		measure(row)
+	}
+	fitWidths(widths, p.width)
+
+	var buf strings.Builder
+	if len(headers) > 0 {
+		writeTableRow(&buf, widths, headers)
+		underline := make([]string, numCols)
+		for i, w := range widths {
+			underline[i] = strings.Repeat("-", w)
+		}
+		writeTableRow(&buf, widths, underline)
+	}
+	for _, row := range rows {
+		writeTableRow(&buf, widths, row)
+	}
+	p.Print(buf.String())
+}
+
+// KV outputs key/value pairs with aligned values, given as alternating keys and values like [log/slog].
+// A missing final value is output as an empty string.
+//
+//	p.KV("Name", name, "Version", version)
+//
+// In machine-readable mode, each pair is output as key=value.
+func (p *Printer) KV(keysAndValues ...any) {
+	var rows [][]string
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		var val string
+		if i+1 < len(keysAndValues) {
+			val = fmt.Sprint(keysAndValues[i+1])
+		}
+		rows = append(rows, []string{key, val})
+	}
+	if p.machine {
+		for _, row := range rows {
+			p.Printf("%s=%s\n", row[0], row[1])
+		}
+		return
+	}
+	for _, row := range rows {
+		row[0] += ":"
+	}
+	p.Table(nil, rows)
+}
+
+// fitWidths narrows the widest columns until the table fits in maxWidth, without narrowing any column below tableMinColWidth.
+func fitWidths(widths []int, maxWidth int) {
+	if maxWidth <= 0 {
+		return
+	}
+	total := len(tableColumnGap) * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > maxWidth {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= tableMinColWidth {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+func writeTableRow(buf *strings.Builder, widths []int, row []string) {
+	cells := make([][]string, len(widths))
+	height := 1
+	for i := range widths {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+		cells[i] = wrapText(cell, widths[i])
+		height = max(height, len(cells[i]))
+	}
+	for line := 0; line < height; line++ {
+		var sb strings.Builder
+		for i, w := range widths {
+			var text string
+			if line < len(cells[i]) {
+				text = cells[i][line]
+			}
+			if i > 0 {
+				sb.WriteString(tableColumnGap)
+			}
+			sb.WriteString(text)
+			if i < len(widths)-1 {
+				sb.WriteString(strings.Repeat(" ", w-utf8.RuneCountInString(text)))
+			}
+		}
+		buf.WriteString(strings.TrimRight(sb.String(), " "))
+		buf.WriteByte('\n')
+	}
+}
+
+// wrapText splits text into lines no longer than width, breaking on spaces where possible.
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		var line []rune
+		for _, word := range strings.Fields(para) {
+			runes := []rune(word)
+			if len(line) > 0 && len(line)+1+len(runes) > width {
+				lines = append(lines, string(line))
+				line = line[:0]
+			}
+			if len(line) > 0 {
+				line = append(line, ' ')
+			}
+			for len(line)+len(runes) > width {
+				// Word is too long for a line by itself.
+				n := width - len(line)
+				lines = append(lines, string(append(line, runes[:n]...)))
+				line = line[:0]
+				runes = runes[n:]
+			}
+			line = append(line, runes...)
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
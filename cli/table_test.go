@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPrinter_Table(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter()
+	p.Redirect(&buf)
+	p.Table([]string{"NAME", "STATUS"}, [][]string{
+		{"api", "running"},
+		{"worker-long-name", "stopped"},
+		{"short"},
+	})
+	expected := `NAME              STATUS
+----------------  -------
+api               running
+worker-long-name  stopped
+short
+`
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	p.SetMachineReadable(true)
+	p.Table([]string{"NAME", "STATUS"}, [][]string{{"api", "running"}})
+	assert.Equal(t, "NAME\tSTATUS\napi\trunning\n", buf.String())
+}
+
+func TestPrinter_Table_Wrap(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter()
+	p.Redirect(&buf)
+	p.SetWidth(30)
+	p.Table([]string{"ID", "DESCRIPTION"}, [][]string{
+		{"1", "a fairly long description that needs wrapping"},
+	})
+	expected := `ID  DESCRIPTION
+--  --------------------------
+1   a fairly long description
+    that needs wrapping
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestPrinter_KV(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewPrinter()
+	p.Redirect(&buf)
+	p.KV("Name", "app", "Version", 2, "Missing")
+	expected := `Name:     app
+Version:  2
+Missing:
+`
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	p.SetMachineReadable(true)
+	p.KV("Name", "app")
+	assert.Equal(t, "Name=app\n", buf.String())
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{"abc de", "fghijk", "lm"}, wrapText("abc de fghijklm", 6))
+	assert.Equal(t, []string{"abcdef", "gh"}, wrapText("abcdefgh", 6))
+	assert.Equal(t, []string{"a", "b"}, wrapText("a\nb", 6))
+	assert.Equal(t, []string{""}, wrapText("", 6))
+}
+
+func TestDetectWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "80")
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("TERM", "dumb")
+	assert.Equal(t, 80, detectWidth(true), "Disabling color should not disable wrapping in a terminal")
+	assert.Zero(t, detectWidth(false))
+	assert.False(t, isTerminal(nil))
+}
@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/httpx"
+	flag "github.com/spf13/pflag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+
+	defaultUpdateTimeout = 5 * time.Minute
+)
+
+var (
+	ErrNoRelease        = errors.New("no release available")
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Release describes a downloadable version of the binary for the current platform.
+type Release struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+	SHA256  string `json:"sha256"` // SHA256 is the hex encoded checksum of the binary at URL.
+}
+
+// ReleaseSource finds the latest [Release] for the release channel, like [ChannelStable] or [ChannelBeta].
+// An error wrapping [ErrNoRelease] should be returned if there is no release for the channel or platform.
+type ReleaseSource func(ctx context.Context, channel string, current BuildInfo) (*Release, error)
+
+// JSONReleaseSource returns a [ReleaseSource] that reads a JSON manifest from the URL.
+// The manifest maps each channel to a map of "GOOS/GOARCH" platforms to a [Release], like this.
+//
+//	{
+//	  "stable": {
+//	    "linux/amd64": {"version": "v1.2.0", "url": "https://example.com/app-linux-amd64", "sha256": "..."}
+//	  }
+//	}
+func JSONReleaseSource(manifestURL string, client ...*http.Client) ReleaseSource {
+	return func(ctx context.Context, channel string, current BuildInfo) (*Release, error) {
+		req := httpx.GetRequest(manifestURL).WithContext(ctx)
+		if len(client) > 0 {
+			req.WithClient(client[0])
+		}
+		resp, status, err := req.MaxResponseBytes(1 << 20).Send()
+		if err != nil {
+			return nil, err
+		}
+		if status != http.StatusOK {
+			_ = resp.Close()
+			return nil, fmt.Errorf("unexpected status %d reading release manifest", status)
+		}
+		manifest, err := httpx.ReadJSON[map[string]map[string]Release](resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read release manifest: %w", err)
+		}
+		platform := current.OS + "/" + current.Arch
+		rel, ok := (*manifest)[channel][platform]
+		if !ok {
+			return nil, fmt.Errorf("%w: channel '%s' has no release for %s", ErrNoRelease, channel, platform)
+		}
+		return &rel, nil
+	}
+}
+
+// UpdateVerifier is called with the path to a downloaded binary after its checksum is verified, and before it replaces the running binary.
+// This can be used to verify a signature.
+type UpdateVerifier func(ctx context.Context, path string, rel *Release) error
+
+type updateConf struct {
+	channels []string
+	version  string
+	verifier UpdateVerifier
+	target   string
+	client   *http.Client
+}
+
+type UpdateOption func(conf *updateConf) error
+
+// OptUpdateChannels sets the release channels that may be selected with --channel.
+// The first channel is the default. Defaults to [ChannelStable] and [ChannelBeta].
+func OptUpdateChannels(channels ...string) UpdateOption {
+	return func(conf *updateConf) error {
+		if len(channels) == 0 {
+			return errors.New("no update channels specified")
+		}
+		conf.channels = channels
+		return nil
+	}
+}
+
+// OptUpdateVersion overrides the current version read from the build info, like [OptVersion].
+func OptUpdateVersion(version string) UpdateOption {
+	return func(conf *updateConf) error {
+		conf.version = version
+		return nil
+	}
+}
+
+// OptUpdateVerifier sets an [UpdateVerifier] to check a downloaded binary, like by verifying its signature.
+func OptUpdateVerifier(verifier UpdateVerifier) UpdateOption {
+	return func(conf *updateConf) error {
+		if verifier == nil {
+			return errors.New("nil update verifier")
+		}
+		conf.verifier = verifier
+		return nil
+	}
+}
+
+// OptUpdateTarget sets the path of the binary to replace, which defaults to the running executable.
+func OptUpdateTarget(path string) UpdateOption {
+	return func(conf *updateConf) error {
+		if len(path) == 0 {
+			return errors.New("empty update target")
+		}
+		conf.target = path
+		return nil
+	}
+}
+
+// OptUpdateHTTPClient sets the [http.Client] used to download releases.
+func OptUpdateHTTPClient(client *http.Client) UpdateOption {
+	return func(conf *updateConf) error {
+		if client == nil {
+			return errors.New("nil client")
+		}
+		conf.client = client
+		return nil
+	}
+}
+
+// AddUpdateCommand adds an "update" sub-command that replaces the running binary with the latest [Release] from the [ReleaseSource].
+//
+// The downloaded binary is verified with its SHA256 checksum, and optionally an [UpdateVerifier], before it's swapped into place with a rename, so a failed update never leaves a partial binary behind.
+// The --channel flag selects the release channel, --check only reports whether an update is available, and --force reinstalls the current version.
+func (s *CommandSet) AddUpdateCommand(source ReleaseSource, opts ...UpdateOption) *Command {
+	if source == nil {
+		panic("nil release source")
+	}
+	conf := updateConf{
+		channels: []string{ChannelStable, ChannelBeta},
+		client:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			panic(err)
+		}
+	}
+	cmd := s.AddCommand("update", "Updates this binary to the latest release")
+	var (
+		channel string
+		check   bool
+		force   bool
+		timeout time.Duration
+	)
+	flags := cmd.Flags()
+	flags.StringVar(&channel, "channel", conf.channels[0], fmt.Sprintf("Selects the release channel, one of: %s", strings.Join(conf.channels, ", ")))
+	flags.BoolVar(&check, "check", false, "Only checks if an update is available")
+	flags.BoolVar(&force, "force", false, "Installs the latest release even if it's the current version")
+	flags.DurationVar(&timeout, "timeout", defaultUpdateTimeout, "Limits the time spent checking for and downloading an update")
+	return cmd.Does(func(_ *flag.FlagSet, p *Printer) error {
+		if !slices.Contains(conf.channels, channel) {
+			return NewUsageError("unknown channel '%s', must be one of: %s", channel, strings.Join(conf.channels, ", "))
+		}
+		info := ReadBuildInfo()
+		if len(conf.version) > 0 {
+			info.Version = conf.version
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		rel, err := source(ctx, channel, info)
+		if err != nil {
+			return err
+		}
+		if check {
+			if p.MachineReadable() {
+				return p.JSON(rel)
+			}
+			if rel.Version == info.Version {
+				p.Success("Already up to date: %s", info.Version)
+			} else {
+				p.Warn("A newer version is available: %s", rel.Version)
+			}
+			return nil
+		}
+		if rel.Version == info.Version && !force {
+			p.Success("Already up to date: %s", info.Version)
+			return nil
+		}
+		target := conf.target
+		if len(target) == 0 {
+			target, err = os.Executable()
+			if err != nil {
+				return err
+			}
+			if target, err = filepath.EvalSymlinks(target); err != nil {
+				return err
+			}
+		}
+		p.Info("Updating %s to %s", info.Version, rel.Version)
+		if err := installRelease(ctx, conf, rel, target); err != nil {
+			return err
+		}
+		p.Success("Updated to %s", rel.Version)
+		return nil
+	})
+}
+
+// installRelease downloads the release next to the target, verifies it, and renames it over the target.
+func installRelease(ctx context.Context, conf updateConf, rel *Release, target string) (err error) {
+	expected, err := hex.DecodeString(strings.TrimSpace(rel.SHA256))
+	if err != nil || len(expected) != sha256.Size {
+		return fmt.Errorf("release %s has an invalid SHA256 checksum", rel.Version)
+	}
+	resp, status, err := httpx.GetRequest(rel.URL).WithContext(ctx).WithClient(conf.client).Send()
+	if err != nil {
+		return fmt.Errorf("failed to download release: %w", err)
+	}
+	body, err := resp.Body()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to download release: unexpected status %d", status)
+	}
+
+	// The temp file must be in the same directory as the target, so the rename is atomic.
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".update-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tmp.Close()
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), body); err != nil {
+		return fmt.Errorf("failed to download release: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if actual := hash.Sum(nil); !slices.Equal(actual, expected) {
+		return fmt.Errorf("%w: expected %x, got %x", ErrChecksumMismatch, expected, actual)
+	}
+	if conf.verifier != nil {
+		if err := conf.verifier(ctx, tmp.Name(), rel); err != nil {
+			return fmt.Errorf("failed to verify release: %w", err)
+		}
+	}
+	mode := os.FileMode(0755)
+	if info, err := os.Stat(target); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	if runtime.GOOS == "windows" {
+		// A running executable can't be replaced on Windows, but it can be renamed out of the way.
+		old := target + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(target, old); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), target)
+}
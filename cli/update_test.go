@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testUpdateServer(t *testing.T, binary []byte, checksum string) *httptest.Server {
+	info := ReadBuildInfo()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest.json":
+			_ = json.NewEncoder(w).Encode(map[string]map[string]Release{
+				ChannelStable: {
+					info.OS + "/" + info.Arch: {Version: "v1.1.0", URL: srv.URL + "/app", SHA256: checksum},
+				},
+				ChannelBeta: {
+					info.OS + "/" + info.Arch: {Version: "v1.2.0-beta", URL: srv.URL + "/app", SHA256: checksum},
+				},
+			})
+		case "/app":
+			_, _ = w.Write(binary)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testUpdateSet(source ReleaseSource, opts ...UpdateOption) (*CommandSet, *bytes.Buffer) {
+	var buf bytes.Buffer
+	set := NewCommandSet("app")
+	set.Printer().Redirect(&buf)
+	set.AddUpdateCommand(source, opts...)
+	return set, &buf
+}
+
+func testTarget(t *testing.T) string {
+	target := filepath.Join(t.TempDir(), "app")
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0750))
+	return target
+}
+
+func TestAddUpdateCommand(t *testing.T) {
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+	srv := testUpdateServer(t, binary, hex.EncodeToString(sum[:]))
+	target := testTarget(t)
+
+	var verified *Release
+	opts := []UpdateOption{
+		OptUpdateVersion("v1.0.0"),
+		OptUpdateTarget(target),
+		OptUpdateVerifier(func(ctx context.Context, path string, rel *Release) error {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, binary, data)
+			verified = rel
+			return nil
+		}),
+	}
+	set, buf := testUpdateSet(JSONReleaseSource(srv.URL+"/manifest.json"), opts...)
+	require.NoError(t, set.Exec([]string{"update", "--check"}))
+	assert.Contains(t, buf.String(), "A newer version is available: v1.1.0")
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data), "Check should not update")
+
+	set, _ = testUpdateSet(JSONReleaseSource(srv.URL+"/manifest.json"), opts...)
+	require.NoError(t, set.Exec([]string{"update", "--channel", "beta"}))
+	require.NotNil(t, verified)
+	assert.Equal(t, "v1.2.0-beta", verified.Version)
+	data, err = os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, binary, data)
+	info, err := os.Stat(target)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0750), info.Mode().Perm(), "File mode should be preserved")
+	entries, err := os.ReadDir(filepath.Dir(target))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "Temp files should not be left behind")
+
+	err = set.Exec([]string{"update", "--channel", "nightly"})
+	assert.ErrorIs(t, err, new(UsageError), "Unknown channels should be a usage error")
+}
+
+func TestAddUpdateCommand_UpToDate(t *testing.T) {
+	target := testTarget(t)
+	set, buf := testUpdateSet(func(ctx context.Context, channel string, current BuildInfo) (*Release, error) {
+		return &Release{Version: current.Version}, nil
+	}, OptUpdateVersion("v1.0.0"), OptUpdateTarget(target))
+	require.NoError(t, set.Exec([]string{"update"}))
+	assert.Contains(t, buf.String(), "Already up to date: v1.0.0")
+}
+
+func TestAddUpdateCommand_ChecksumMismatch(t *testing.T) {
+	sum := sha256.Sum256([]byte("something else"))
+	srv := testUpdateServer(t, []byte("new binary"), hex.EncodeToString(sum[:]))
+	target := testTarget(t)
+	set, _ := testUpdateSet(JSONReleaseSource(srv.URL+"/manifest.json"), OptUpdateVersion("v1.0.0"), OptUpdateTarget(target))
+	err := set.Exec([]string{"update"})
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+	entries, err := os.ReadDir(filepath.Dir(target))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "Temp files should be removed on failure")
+}
+
+func TestAddUpdateCommand_VerifierFails(t *testing.T) {
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+	srv := testUpdateServer(t, binary, hex.EncodeToString(sum[:]))
+	target := testTarget(t)
+	errBadSig := errors.New("bad signature")
+	set, _ := testUpdateSet(JSONReleaseSource(srv.URL+"/manifest.json"), OptUpdateVersion("v1.0.0"), OptUpdateTarget(target),
+		OptUpdateVerifier(func(ctx context.Context, path string, rel *Release) error {
+			return errBadSig
+		}),
+	)
+	assert.ErrorIs(t, set.Exec([]string{"update"}), errBadSig)
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+}
+
+func TestJSONReleaseSource_NoRelease(t *testing.T) {
+	srv := testUpdateServer(t, nil, "")
+	_, err := JSONReleaseSource(srv.URL+"/manifest.json")(context.Background(), "nightly", ReadBuildInfo())
+	assert.ErrorIs(t, err, ErrNoRelease)
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UsageOptOutVars is a slice of environment variables that disable usage reporting when set to anything other than "", "0", or "false".
+// DO_NOT_TRACK is included by default, and tools may add their own variable.
+var UsageOptOutVars = []string{"DO_NOT_TRACK"}
+
+// InvocationStatus is the outcome of a [Command] invocation.
+type InvocationStatus int
+
+const (
+	InvocationSuccess    InvocationStatus = iota // InvocationSuccess means the command returned no error.
+	InvocationError                              // InvocationError means the command returned an error.
+	InvocationUsageError                         // InvocationUsageError means the command was invoked incorrectly, see [UsageError].
+)
+
+func (s InvocationStatus) String() string {
+	switch s {
+	case InvocationSuccess:
+		return "success"
+	case InvocationError:
+		return "error"
+	case InvocationUsageError:
+		return "usage_error"
+	default:
+		return "unknown"
+	}
+}
+
+// Invocation is an anonymous record of a [Command] being executed, reported to a [UsageSink].
+// It intentionally doesn't include arguments, flag values, or error messages, since they may contain sensitive information.
+type Invocation struct {
+	CommandPath string           // CommandPath is the full path of the command, like "app config show".
+	Started     time.Time        // Started is when the command started executing.
+	Duration    time.Duration    // Duration is how long the command took to execute.
+	Status      InvocationStatus // Status is the outcome of the command.
+}
+
+// UsageSink receives an [Invocation] each time a [Command] is executed.
+// A sink is called synchronously after the command returns, so it should return quickly, like by buffering or sending in the background.
+type UsageSink func(inv Invocation)
+
+var (
+	usageMux   sync.Mutex
+	usageSinks []UsageSink
+)
+
+// AddUsageSink registers a sink that is told about each [Command] invocation, so tool owners can see which sub-commands are actually used.
+// Usage is not reported if the user opts out with one of the [UsageOptOutVars].
+// Invocations that only print usage information with --help are not reported.
+//
+// Passing a nil [UsageSink] to this function will panic.
+func AddUsageSink(sink UsageSink) {
+	if sink == nil {
+		panic("nil usage sink")
+	}
+	usageMux.Lock()
+	defer usageMux.Unlock()
+	usageSinks = append(usageSinks, sink)
+}
+
+// UsageOptedOut returns true if the user has opted out of usage reporting with one of the [UsageOptOutVars].
+func UsageOptedOut() bool {
+	for _, name := range UsageOptOutVars {
+		switch strings.ToLower(strings.TrimSpace(os.Getenv(name))) {
+		case "", "0", "false":
+			continue
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func reportUsage(path string, start time.Time, err error) {
+	usageMux.Lock()
+	sinks := usageSinks
+	usageMux.Unlock()
+	if len(sinks) == 0 || UsageOptedOut() {
+		return
+	}
+	inv := Invocation{
+		CommandPath: path,
+		Started:     start,
+		Duration:    time.Since(start),
+	}
+	switch {
+	case err == nil:
+		inv.Status = InvocationSuccess
+	case errors.Is(err, &UsageError{}):
+		inv.Status = InvocationUsageError
+	default:
+		inv.Status = InvocationError
+	}
+	for _, sink := range sinks {
+		sink(inv)
+	}
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"errors"
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+func TestAddUsageSink(t *testing.T) {
+	usageMux.Lock()
+	usageSinks = nil
+	usageMux.Unlock()
+	t.Cleanup(func() {
+		usageMux.Lock()
+		usageSinks = nil
+		usageMux.Unlock()
+	})
+	t.Setenv("DO_NOT_TRACK", "")
+
+	var invocations []Invocation
+	AddUsageSink(func(inv Invocation) {
+		invocations = append(invocations, inv)
+	})
+	tlc := NewCommandSet("app")
+	tlc.Printer().Redirect(io.Discard)
+	cfg := tlc.AddCommand("config", "Config commands")
+	cfg.AddCommand("show", "Shows config").Does(func(flags *flag.FlagSet, out *Printer) error {
+		return nil
+	})
+	cfg.AddCommand("set", "Sets config").Does(func(flags *flag.FlagSet, out *Printer) error {
+		return NewUsageError("missing value")
+	})
+	cfg.AddCommand("fail", "Fails").Does(func(flags *flag.FlagSet, out *Printer) error {
+		return errors.New("failed")
+	})
+
+	assert.NoError(t, tlc.Exec([]string{"config", "show"}))
+	assert.Error(t, tlc.Exec([]string{"config", "set"}))
+	assert.Error(t, tlc.Exec([]string{"config", "fail"}))
+	assert.NoError(t, tlc.Exec([]string{"config", "show", "--help"}))
+	require.Len(t, invocations, 3, "Help output should not be reported")
+	assert.Equal(t, "app config show", invocations[0].CommandPath)
+	assert.Equal(t, InvocationSuccess, invocations[0].Status)
+	assert.Equal(t, InvocationUsageError, invocations[1].Status)
+	assert.Equal(t, InvocationError, invocations[2].Status)
+	assert.Equal(t, "error", invocations[2].Status.String())
+
+	t.Setenv("DO_NOT_TRACK", "1")
+	assert.True(t, UsageOptedOut())
+	assert.NoError(t, tlc.Exec([]string{"config", "show"}))
+	assert.Len(t, invocations, 3, "Opted out usage should not be reported")
+
+	assert.Panics(t, func() {
+		AddUsageSink(nil)
+	})
+}
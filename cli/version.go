@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	flag "github.com/spf13/pflag"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+const (
+	versionDevel              = "(devel)"
+	defaultUpdateCheckTimeout = 5 * time.Second
+)
+
+// BuildInfo describes the build of the running binary.
+type BuildInfo struct {
+	Path      string    `json:"path,omitempty"`
+	Version   string    `json:"version"`
+	Revision  string    `json:"revision,omitempty"`
+	Modified  bool      `json:"modified,omitempty"`
+	Time      time.Time `json:"-"` // Time is the VCS commit time, unless overridden with OptBuildTime.
+	GoVersion string    `json:"goVersion"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	Latest    string    `json:"latest,omitempty"` // Latest is the version reported by an update check, if any.
+}
+
+// MarshalJSON omits the Time if it's unknown.
+func (i BuildInfo) MarshalJSON() ([]byte, error) {
+	type plain BuildInfo
+	var t *time.Time
+	if !i.Time.IsZero() {
+		t = &i.Time
+	}
+	return json.Marshal(struct {
+		plain
+		Time *time.Time `json:"time,omitempty"`
+	}{plain(i), t})
+}
+
+// ReadBuildInfo reads the [BuildInfo] embedded in the binary by the Go toolchain.
+// The module version is only known when installed with "go install module@version", and is "(devel)" otherwise.
+func ReadBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   versionDevel,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Path = bi.Main.Path
+	if len(bi.Main.Version) > 0 {
+		info.Version = bi.Main.Version
+	}
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		case "vcs.time":
+			info.Time, _ = time.Parse(time.RFC3339, setting.Value)
+		}
+	}
+	return info
+}
+
+// UpdateCheck is called by the version command to find the latest available version.
+// An empty string may be returned if the latest version is unknown.
+type UpdateCheck func(ctx context.Context, current BuildInfo) (latest string, err error)
+
+type versionConf struct {
+	version     string
+	buildTime   time.Time
+	updateCheck UpdateCheck
+	timeout     time.Duration
+}
+
+type VersionOption func(conf *versionConf)
+
+// OptVersion overrides the version read from the build info.
+// This is useful when the version is injected at build time with -ldflags "-X ...".
+func OptVersion(version string) VersionOption {
+	return func(conf *versionConf) {
+		conf.version = version
+	}
+}
+
+// OptBuildTime overrides the VCS commit time with the time the binary was built.
+func OptBuildTime(buildTime time.Time) VersionOption {
+	return func(conf *versionConf) {
+		conf.buildTime = buildTime
+	}
+}
+
+// OptUpdateCheck sets a function to check for a newer version when the version command is run.
+// The check can be skipped by the user with --no-check, and is limited to the given timeout, or 5 seconds if <= 0.
+func OptUpdateCheck(check UpdateCheck, timeout time.Duration) VersionOption {
+	return func(conf *versionConf) {
+		conf.updateCheck = check
+		conf.timeout = timeout
+	}
+}
+
+// AddVersionCommand adds a standard "version" sub-command that prints the [BuildInfo] of the binary.
+// The --json flag outputs the [BuildInfo] as JSON, which is also the default in machine-readable mode.
+func (s *CommandSet) AddVersionCommand(opts ...VersionOption) *Command {
+	conf := versionConf{timeout: defaultUpdateCheckTimeout}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	if conf.timeout <= 0 {
+		conf.timeout = defaultUpdateCheckTimeout
+	}
+	cmd := s.AddCommand("version", "Prints version and build information")
+	var (
+		asJSON  bool
+		noCheck bool
+	)
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Prints version information as JSON")
+	if conf.updateCheck != nil {
+		cmd.Flags().BoolVar(&noCheck, "no-check", false, "Skips checking for a newer version")
+	}
+	return cmd.Does(func(_ *flag.FlagSet, p *Printer) error {
+		info := ReadBuildInfo()
+		if len(conf.version) > 0 {
+			info.Version = conf.version
+		}
+		if !conf.buildTime.IsZero() {
+			info.Time = conf.buildTime
+		}
+		if conf.updateCheck != nil && !noCheck {
+			ctx, cancel := context.WithTimeout(context.Background(), conf.timeout)
+			latest, err := conf.updateCheck(ctx, info)
+			cancel()
+			if err != nil {
+				p.Debug("Failed to check for updates: %v", err)
+			} else {
+				info.Latest = latest
+			}
+		}
+		if asJSON || p.MachineReadable() {
+			return p.JSON(info)
+		}
+		p.Println(formatVersion(info))
+		if len(info.Latest) > 0 && info.Latest != info.Version {
+			p.Warn("A newer version is available: %s", info.Latest)
+		}
+		return nil
+	})
+}
+
+func formatVersion(info BuildInfo) string {
+	text := info.Version
+	if len(info.Revision) > 0 {
+		rev := info.Revision
+		if len(rev) > 12 {
+			rev = rev[:12]
+		}
+		if info.Modified {
+			rev += "-dirty"
+		}
+		text += fmt.Sprintf(" (%s)", rev)
+	}
+	if !info.Time.IsZero() {
+		text += " " + info.Time.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s %s/%s %s", text, info.OS, info.Arch, info.GoVersion)
+}
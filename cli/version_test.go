@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testVersionSet(opts ...VersionOption) (*CommandSet, *bytes.Buffer) {
+	var buf bytes.Buffer
+	set := NewCommandSet("app")
+	set.Printer().Redirect(&buf)
+	set.AddVersionCommand(opts...)
+	return set, &buf
+}
+
+func TestReadBuildInfo(t *testing.T) {
+	info := ReadBuildInfo()
+	assert.NotEmpty(t, info.Version)
+	assert.NotEmpty(t, info.GoVersion)
+	assert.NotEmpty(t, info.OS)
+	assert.NotEmpty(t, info.Arch)
+}
+
+func TestAddVersionCommand(t *testing.T) {
+	buildTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	set, buf := testVersionSet(OptVersion("v1.2.3"), OptBuildTime(buildTime))
+	require.NoError(t, set.Exec([]string{"version"}))
+	assert.True(t, strings.HasPrefix(buf.String(), "v1.2.3"), buf.String())
+	assert.Contains(t, buf.String(), "2024-01-02T03:04:05Z")
+
+	buf.Reset()
+	require.NoError(t, set.Exec([]string{"version", "--json"}))
+	var info map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &info))
+	assert.Equal(t, "v1.2.3", info["version"])
+	assert.Equal(t, "2024-01-02T03:04:05Z", info["time"])
+}
+
+func TestAddVersionCommand_UpdateCheck(t *testing.T) {
+	var checks int
+	set, buf := testVersionSet(OptVersion("v1.0.0"), OptUpdateCheck(func(ctx context.Context, current BuildInfo) (string, error) {
+		checks++
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		assert.Equal(t, "v1.0.0", current.Version)
+		return "v1.1.0", nil
+	}, 0))
+	require.NoError(t, set.Exec([]string{"version"}))
+	assert.Contains(t, buf.String(), "A newer version is available: v1.1.0")
+
+	buf.Reset()
+	require.NoError(t, set.Exec([]string{"version", "--no-check"}))
+	assert.NotContains(t, buf.String(), "newer version")
+	assert.Equal(t, 1, checks)
+
+	set, buf = testVersionSet(OptUpdateCheck(func(ctx context.Context, current BuildInfo) (string, error) {
+		return "", errors.New("offline")
+	}, time.Second))
+	set.Printer().SetMachineReadable(true)
+	require.NoError(t, set.Exec([]string{"version"}))
+	var info map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &info), "Machine readable mode should output JSON")
+	assert.NotContains(t, info, "latest")
+}
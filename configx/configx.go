@@ -0,0 +1,435 @@
+package configx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/syncx"
+	flag "github.com/spf13/pflag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidTarget     = errors.New("config type must be a struct")
+	ErrUnsupportedFormat = errors.New("unsupported config file format")
+	ErrValidation        = errors.New("config validation failed")
+)
+
+// Source identifies where a configuration value was resolved from.
+type Source int
+
+const (
+	SourceUnset   Source = iota // SourceUnset means that no source provided a value, so the field has its zero value.
+	SourceDefault               // SourceDefault means that the value came from the `default` struct tag.
+	SourceFile                  // SourceFile means that the value came from a config file.
+	SourceEnv                   // SourceEnv means that the value came from an environment variable.
+	SourceFlag                  // SourceFlag means that the value came from a command line flag.
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceFile:
+		return "file"
+	case SourceEnv:
+		return "env"
+	case SourceFlag:
+		return "flag"
+	default:
+		return "unset"
+	}
+}
+
+// Validator may be implemented by a pointer to a config struct to validate the fully resolved configuration.
+type Validator interface {
+	Validate() error
+}
+
+// DecodeFunc decodes config file data into the target, like [json.Unmarshal].
+type DecodeFunc func(data []byte, target any) error
+
+type configFile struct {
+	path     string
+	required bool
+}
+
+type loaderConf struct {
+	files     []configFile
+	envPrefix string
+	lookupEnv func(key string) (string, bool)
+	flags     *flag.FlagSet
+	decoders  map[string]DecodeFunc
+}
+
+type LoaderOption func(conf *loaderConf) error
+
+// OptFile adds a config file that must exist when [Loader.Load] is called.
+// The file's format is determined by its extension.
+func OptFile(path string) LoaderOption {
+	return optFile(path, true)
+}
+
+// OptOptionalFile adds a config file that will be skipped if it doesn't exist.
+// The file's format is determined by its extension.
+func OptOptionalFile(path string) LoaderOption {
+	return optFile(path, false)
+}
+
+func optFile(path string, required bool) LoaderOption {
+	return func(conf *loaderConf) error {
+		if len(path) == 0 {
+			return errors.New("empty config file path")
+		}
+		conf.files = append(conf.files, configFile{path: path, required: required})
+		return nil
+	}
+}
+
+// OptEnvPrefix will prepend the prefix to every `env` struct tag name before looking up the variable.
+func OptEnvPrefix(prefix string) LoaderOption {
+	return func(conf *loaderConf) error {
+		conf.envPrefix = prefix
+		return nil
+	}
+}
+
+// OptEnvLookup overrides how environment variables are looked up, which defaults to [os.LookupEnv].
+// This is mostly useful for testing.
+func OptEnvLookup(lookup func(key string) (string, bool)) LoaderOption {
+	return func(conf *loaderConf) error {
+		if lookup == nil {
+			return errors.New("nil env lookup function")
+		}
+		conf.lookupEnv = lookup
+		return nil
+	}
+}
+
+// OptFlags registers a flag for every field with a `flag` struct tag in the given [flag.FlagSet].
+// The [flag.FlagSet] should be parsed before calling [Loader.Load].
+// Only flags that were explicitly set by the user will override other sources.
+func OptFlags(flags *flag.FlagSet) LoaderOption {
+	return func(conf *loaderConf) error {
+		if flags == nil {
+			return errors.New("nil flag set")
+		}
+		conf.flags = flags
+		return nil
+	}
+}
+
+// OptDecoder registers a [DecodeFunc] for config files with the given extension, like ".yaml".
+// Only JSON is supported by default, so this is required to load YAML or TOML files.
+func OptDecoder(ext string, decode DecodeFunc) LoaderOption {
+	return func(conf *loaderConf) error {
+		if decode == nil {
+			return fmt.Errorf("nil decoder for extension '%s'", ext)
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		conf.decoders[strings.ToLower(ext)] = decode
+		return nil
+	}
+}
+
+type field struct {
+	path     string
+	index    []int
+	env      string
+	flag     string
+	short    string
+	usage    string
+	def      string
+	hasDef   bool
+	isBool   bool
+	fieldTyp reflect.Type
+}
+
+// Loader resolves a config struct of type T from all configured sources.
+type Loader[T any] struct {
+	conf   loaderConf
+	fields []field
+
+	mux       sync.RWMutex
+	current   T
+	loaded    bool
+	sources   map[string]Source
+	observers []func(prev, next T)
+}
+
+// NewLoader creates a [Loader] for the config struct T.
+// An error is returned if T is not a struct, a tag is invalid, or an option fails.
+func NewLoader[T any](opts ...LoaderOption) (*Loader[T], error) {
+	conf := loaderConf{
+		lookupEnv: os.LookupEnv,
+		decoders: map[string]DecodeFunc{
+			".json": json.Unmarshal,
+		},
+	}
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			return nil, err
+		}
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: got %s", ErrInvalidTarget, typ)
+	}
+	l := &Loader[T]{
+		conf: conf,
+	}
+	l.fields = collectFields(typ, nil, "")
+	if err := l.validateDefaults(); err != nil {
+		return nil, err
+	}
+	if conf.flags != nil {
+		l.registerFlags()
+	}
+	RegisterEnv(l.EnvVars()...)
+	return l, nil
+}
+
+func collectFields(typ reflect.Type, index []int, prefix string) []field {
+	var fields []field
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		idx := append(append([]int{}, index...), i)
+		path := prefix + sf.Name
+		if !isLeaf(sf.Type) {
+			fields = append(fields, collectFields(sf.Type, idx, path+".")...)
+			continue
+		}
+		f := field{
+			path:     path,
+			index:    idx,
+			env:      sf.Tag.Get("env"),
+			usage:    sf.Tag.Get("usage"),
+			isBool:   sf.Type.Kind() == reflect.Bool,
+			fieldTyp: sf.Type,
+		}
+		f.def, f.hasDef = sf.Tag.Lookup("default")
+		if flagTag := sf.Tag.Get("flag"); len(flagTag) > 0 {
+			f.flag, f.short, _ = strings.Cut(flagTag, ",")
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func (l *Loader[T]) validateDefaults() error {
+	var probe T
+	val := reflect.ValueOf(&probe).Elem()
+	for _, f := range l.fields {
+		if !f.hasDef {
+			continue
+		}
+		if err := setFromString(val.FieldByIndex(f.index), f.def); err != nil {
+			return fmt.Errorf("invalid default for field %s: %w", f.path, err)
+		}
+	}
+	return nil
+}
+
+func (l *Loader[T]) registerFlags() {
+	for _, f := range l.fields {
+		if len(f.flag) == 0 {
+			continue
+		}
+		usage := f.usage
+		if len(usage) == 0 {
+			usage = "Sets " + f.path
+		}
+		if f.isBool {
+			def := f.def == "true"
+			l.conf.flags.BoolP(f.flag, f.short, def, usage)
+			continue
+		}
+		l.conf.flags.StringP(f.flag, f.short, f.def, usage)
+	}
+}
+
+// Load resolves a new config value from all sources, validates it, and stores it as the current value.
+// If the resolved value differs from a previously loaded value, then all [Loader.OnChange] functions are called.
+func (l *Loader[T]) Load() (T, error) {
+	var (
+		cfg     T
+		zero    T
+		val     = reflect.ValueOf(&cfg).Elem()
+		sources = map[string]Source{}
+	)
+
+	for _, f := range l.fields {
+		if !f.hasDef {
+			continue
+		}
+		if err := setFromString(val.FieldByIndex(f.index), f.def); err != nil {
+			return zero, fmt.Errorf("invalid default for field %s: %w", f.path, err)
+		}
+		sources[f.path] = SourceDefault
+	}
+
+	for _, file := range l.conf.files {
+		if err := l.applyFile(file, val, sources); err != nil {
+			return zero, err
+		}
+	}
+
+	for _, f := range l.fields {
+		if len(f.env) == 0 {
+			continue
+		}
+		name := l.conf.envPrefix + f.env
+		envVal, ok := l.conf.lookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := setFromString(val.FieldByIndex(f.index), envVal); err != nil {
+			return zero, fmt.Errorf("invalid value for env %s: %w", name, err)
+		}
+		sources[f.path] = SourceEnv
+	}
+
+	if l.conf.flags != nil {
+		for _, f := range l.fields {
+			if len(f.flag) == 0 || !l.conf.flags.Changed(f.flag) {
+				continue
+			}
+			flagVal := l.conf.flags.Lookup(f.flag).Value.String()
+			if err := setFromString(val.FieldByIndex(f.index), flagVal); err != nil {
+				return zero, fmt.Errorf("invalid value for flag --%s: %w", f.flag, err)
+			}
+			sources[f.path] = SourceFlag
+		}
+	}
+
+	if validator, ok := any(&cfg).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return zero, fmt.Errorf("%w: %w", ErrValidation, err)
+		}
+	}
+
+	var (
+		prev      T
+		changed   bool
+		observers []func(prev, next T)
+	)
+	syncx.LockFunc(&l.mux, func() {
+		prev = l.current
+		changed = l.loaded && !reflect.DeepEqual(prev, cfg)
+		l.current = cfg
+		l.loaded = true
+		l.sources = sources
+		observers = l.observers
+	})
+	if changed {
+		for _, obs := range observers {
+			obs(prev, cfg)
+		}
+	}
+	return cfg, nil
+}
+
+func (l *Loader[T]) applyFile(file configFile, val reflect.Value, sources map[string]Source) error {
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !file.required {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file '%s': %w", file.path, err)
+	}
+	ext := strings.ToLower(filepath.Ext(file.path))
+	decode, ok := l.conf.decoders[ext]
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, ext)
+	}
+	before := make([]any, len(l.fields))
+	for i, f := range l.fields {
+		before[i] = val.FieldByIndex(f.index).Interface()
+	}
+	if err := decode(data, val.Addr().Interface()); err != nil {
+		return fmt.Errorf("failed to decode config file '%s': %w", file.path, err)
+	}
+	// A field that's set to the same value it already had is not attributed to the file.
+	for i, f := range l.fields {
+		if !reflect.DeepEqual(before[i], val.FieldByIndex(f.index).Interface()) {
+			sources[f.path] = SourceFile
+		}
+	}
+	return nil
+}
+
+// Current returns the most recently loaded config value.
+// The zero value of T is returned if [Loader.Load] hasn't been successfully called yet.
+func (l *Loader[T]) Current() T {
+	return syncx.RLockFuncT(&l.mux, func() T {
+		return l.current
+	})
+}
+
+// Source reports where the field with the given path was resolved from in the most recent load.
+// Nested fields are referenced with a dot separated path of Go field names, like "Server.Port".
+func (l *Loader[T]) Source(path string) Source {
+	return syncx.RLockFuncT(&l.mux, func() Source {
+		return l.sources[path]
+	})
+}
+
+// OnChange registers a function that will be called with the previous and new value when a [Loader.Load] results in a changed config.
+func (l *Loader[T]) OnChange(fn func(prev, next T)) {
+	if fn == nil {
+		panic("nil change function")
+	}
+	syncx.LockFunc(&l.mux, func() {
+		l.observers = append(l.observers, fn)
+	})
+}
+
+// Watch will check config files for modification on the given interval, and call [Loader.Load] when one changes.
+// Load errors are passed to onErr if it's not nil, and the last good value is retained.
+// This blocks until the context is cancelled.
+func (l *Loader[T]) Watch(ctx context.Context, interval time.Duration, onErr func(error)) {
+	if interval <= 0 {
+		panic("watch interval must be > 0")
+	}
+	modTimes := l.fileModTimes()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			latest := l.fileModTimes()
+			if reflect.DeepEqual(modTimes, latest) {
+				continue
+			}
+			modTimes = latest
+			if _, err := l.Load(); err != nil && onErr != nil {
+				onErr(err)
+			}
+		}
+	}
+}
+
+func (l *Loader[T]) fileModTimes() map[string]time.Time {
+	times := map[string]time.Time{}
+	for _, file := range l.conf.files {
+		info, err := os.Stat(file.path)
+		if err != nil {
+			continue
+		}
+		times[file.path] = info.ModTime()
+	}
+	return times
+}
@@ -0,0 +1,180 @@
+package configx
+
+import (
+	"errors"
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	LogLevel string        `json:"logLevel" default:"info" env:"LOG_LEVEL" flag:"log-level,l"`
+	Timeout  time.Duration `json:"timeout" default:"5s" env:"TIMEOUT"`
+	Tags     []string      `json:"tags" env:"TAGS"`
+	Verbose  bool          `json:"verbose" flag:"verbose"`
+	Server   struct {
+		Host string `json:"host" default:"localhost"`
+		Port int    `json:"port" default:"8080" env:"PORT" flag:"port"`
+	} `json:"server"`
+}
+
+func (c *testConfig) Validate() error {
+	if c.Server.Port <= 0 {
+		return errors.New("port must be > 0")
+	}
+	return nil
+}
+
+func TestNewLoader_InvalidTarget(t *testing.T) {
+	_, err := NewLoader[string]()
+	assert.ErrorIs(t, err, ErrInvalidTarget)
+}
+
+func TestNewLoader_InvalidDefault(t *testing.T) {
+	type badDefault struct {
+		Port int `default:"not a number"`
+	}
+	_, err := NewLoader[badDefault]()
+	assert.Error(t, err)
+}
+
+func TestLoader_Load_Defaults(t *testing.T) {
+	l, err := NewLoader[testConfig](OptEnvLookup(testEnv(nil)))
+	require.NoError(t, err)
+	cfg, err := l.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "info", cfg.LogLevel)
+	assert.Equal(t, 5*time.Second, cfg.Timeout)
+	assert.Equal(t, "localhost", cfg.Server.Host)
+	assert.Equal(t, 8080, cfg.Server.Port)
+	assert.Equal(t, SourceDefault, l.Source("Server.Port"))
+	assert.Equal(t, SourceUnset, l.Source("Verbose"))
+}
+
+func TestLoader_Load_Precedence(t *testing.T) {
+	path := testConfigFile(t, `{"logLevel": "warn", "timeout": 1000000000, "server": {"port": 9000}}`)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	l, err := NewLoader[testConfig](
+		OptFile(path),
+		OptEnvPrefix("APP_"),
+		OptEnvLookup(testEnv(map[string]string{
+			"APP_LOG_LEVEL": "error",
+			"APP_TAGS":      "a, b,c",
+			"LOG_LEVEL":     "ignored without prefix",
+		})),
+		OptFlags(fs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, fs.Parse([]string{"--port", "9090", "--verbose"}))
+
+	cfg, err := l.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "error", cfg.LogLevel)
+	assert.Equal(t, SourceEnv, l.Source("LogLevel"))
+	assert.Equal(t, time.Second, cfg.Timeout)
+	assert.Equal(t, SourceFile, l.Source("Timeout"))
+	assert.Equal(t, []string{"a", "b", "c"}, cfg.Tags)
+	assert.Equal(t, 9090, cfg.Server.Port)
+	assert.Equal(t, SourceFlag, l.Source("Server.Port"))
+	assert.True(t, cfg.Verbose)
+	assert.Equal(t, "localhost", cfg.Server.Host)
+}
+
+func TestLoader_Load_MissingFiles(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.json")
+	l, err := NewLoader[testConfig](OptOptionalFile(missing), OptEnvLookup(testEnv(nil)))
+	require.NoError(t, err)
+	_, err = l.Load()
+	assert.NoError(t, err, "Optional files may be missing")
+
+	l, err = NewLoader[testConfig](OptFile(missing), OptEnvLookup(testEnv(nil)))
+	require.NoError(t, err)
+	_, err = l.Load()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoader_Load_UnsupportedFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("logLevel: warn"), 0600))
+	l, err := NewLoader[testConfig](OptFile(path), OptEnvLookup(testEnv(nil)))
+	require.NoError(t, err)
+	_, err = l.Load()
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestLoader_Load_Validation(t *testing.T) {
+	l, err := NewLoader[testConfig](OptEnvLookup(testEnv(map[string]string{"PORT": "0"})))
+	require.NoError(t, err)
+	_, err = l.Load()
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.Equal(t, 0, l.Current().Server.Port, "Invalid config should not be stored")
+}
+
+func TestLoader_OnChange(t *testing.T) {
+	env := map[string]string{"LOG_LEVEL": "debug"}
+	l, err := NewLoader[testConfig](OptEnvLookup(testEnv(env)))
+	require.NoError(t, err)
+
+	var changes int
+	l.OnChange(func(prev, next testConfig) {
+		changes++
+		assert.Equal(t, "debug", prev.LogLevel)
+		assert.Equal(t, "warn", next.LogLevel)
+	})
+	_, err = l.Load()
+	require.NoError(t, err)
+	_, err = l.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 0, changes, "Should not notify without a change")
+
+	env["LOG_LEVEL"] = "warn"
+	_, err = l.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 1, changes)
+	assert.Equal(t, "warn", l.Current().LogLevel)
+}
+
+func testEnv(vals map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		val, ok := vals[key]
+		return val, ok
+	}
+}
+
+func testConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoader_Describe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"server": {"host": "example.com"}}`), 0600))
+	l, err := NewLoader[testConfig](
+		OptOptionalFile(path),
+		OptEnvPrefix("APP_"),
+		OptEnvLookup(testEnv(map[string]string{"APP_PORT": "9000"})),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path}, l.Files())
+	defaults := l.Defaults()
+	assert.Equal(t, "info", defaults.LogLevel)
+	assert.Equal(t, 8080, defaults.Server.Port)
+
+	_, err = l.Load()
+	require.NoError(t, err)
+	values := map[string]FieldValue{}
+	for _, val := range l.Describe() {
+		values[val.Path] = val
+	}
+	assert.Len(t, values, 6)
+	assert.Equal(t, FieldValue{Path: "Server.Port", Value: 9000, Source: SourceEnv, Env: "APP_PORT", Flag: "port"}, values["Server.Port"])
+	assert.Equal(t, "example.com", values["Server.Host"].Value)
+	assert.Equal(t, SourceFile, values["Server.Host"].Source)
+	assert.Equal(t, SourceDefault, values["LogLevel"].Source)
+	assert.Equal(t, SourceUnset, values["Verbose"].Source)
+}
@@ -0,0 +1,58 @@
+package configx
+
+import (
+	"reflect"
+)
+
+// FieldValue describes a resolved config field, and where its value came from.
+type FieldValue struct {
+	Path   string // Path is the dot separated path of Go field names, like "Server.Port".
+	Value  any
+	Source Source
+	Env    string // Env is the full environment variable name, including any prefix, or empty if the field has no `env` tag.
+	Flag   string // Flag is the flag name, or empty if the field has no `flag` tag.
+	Usage  string
+}
+
+// Describe returns a [FieldValue] for every field in the most recently loaded config, in struct order.
+// This is useful for showing users the effective configuration.
+func (l *Loader[T]) Describe() []FieldValue {
+	cfg := l.Current()
+	val := reflect.ValueOf(&cfg).Elem()
+	values := make([]FieldValue, len(l.fields))
+	for i, f := range l.fields {
+		values[i] = FieldValue{
+			Path:   f.path,
+			Value:  val.FieldByIndex(f.index).Interface(),
+			Source: l.Source(f.path),
+			Flag:   f.flag,
+			Usage:  f.usage,
+		}
+		if len(f.env) > 0 {
+			values[i].Env = l.conf.envPrefix + f.env
+		}
+	}
+	return values
+}
+
+// Defaults returns a config value with only the `default` struct tags applied.
+// Defaults are validated by [NewLoader], so this can't fail.
+func (l *Loader[T]) Defaults() T {
+	var cfg T
+	val := reflect.ValueOf(&cfg).Elem()
+	for _, f := range l.fields {
+		if f.hasDef {
+			_ = setFromString(val.FieldByIndex(f.index), f.def)
+		}
+	}
+	return cfg
+}
+
+// Files returns the paths of config files given with [OptFile] or [OptOptionalFile], in order.
+func (l *Loader[T]) Files() []string {
+	paths := make([]string, len(l.conf.files))
+	for i, file := range l.conf.files {
+		paths[i] = file.path
+	}
+	return paths
+}
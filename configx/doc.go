@@ -0,0 +1,57 @@
+/*
+Package configx provides a layered configuration engine that resolves a tagged struct from several sources.
+
+# Precedence
+
+Values are resolved in this order, with later sources overriding earlier ones:
+
+  - Defaults specified with the `default` struct tag.
+  - Config files given with [OptFile] or [OptOptionalFile], in the order they're given.
+  - Environment variables named with the `env` struct tag, optionally prefixed with [OptEnvPrefix].
+  - Command line flags named with the `flag` struct tag, but only if the user actually set them.
+
+This means that a user can always override a value at the most specific level without having to know where else it's set.
+
+# Struct Tags
+
+A field may use any combination of these tags:
+
+	type Config struct {
+		LogLevel string        `json:"logLevel" default:"info" env:"LOG_LEVEL" flag:"log-level,l" usage:"Sets the log level"`
+		Timeout  time.Duration `json:"timeout" default:"5s" env:"TIMEOUT"`
+		Server   struct {
+			Port int `json:"port" default:"8080" env:"PORT" flag:"port"`
+		} `json:"server"`
+	}
+
+Nested structs are traversed, so their fields may be tagged too.
+
+# File Formats
+
+Only JSON config files are decoded by this package.
+YAML and TOML decoders are deliberately not included, so this module doesn't depend on a parser for either format.
+A file with any other extension fails to load with [ErrUnsupportedFormat], unless a decoder is registered for it with [OptDecoder]:
+
+	loader, err := configx.NewLoader[Config](
+		configx.OptFile("config.yaml"),
+		configx.OptDecoder(".yaml", yaml.Unmarshal), // gopkg.in/yaml.v3
+		configx.OptDecoder(".toml", toml.Unmarshal), // github.com/BurntSushi/toml
+	)
+
+Config files are decoded with the file format's own rules, so the `json` tag applies to JSON files, and the `yaml` tag to YAML files.
+The "config init" command from [github.com/saylorsolutions/x/cli.AddConfigCommands] can only write JSON files for the same reason.
+
+# Validation and Changes
+
+If a pointer to the config struct implements [Validator], then it will be called after all sources are applied.
+A validation failure will prevent the new values from being stored in the [Loader].
+
+Functions registered with [Loader.OnChange] will be called when a subsequent [Loader.Load] produces a different value.
+[Loader.Watch] can be used to reload when config files are modified.
+
+# Documenting Environment Variables
+
+Every [Loader] registers the environment variables it reads, and variables read elsewhere can be added with [RegisterEnv].
+[DocumentEnv] writes all of them as Markdown or JSON, with their type, default, and `usage` tag, so it's clear which variables a service actually consumes.
+*/
+package configx
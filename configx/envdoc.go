@@ -0,0 +1,119 @@
+package configx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/syncx"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnsupportedDocFormat = errors.New("unsupported documentation format")
+
+	envRegistryMux sync.RWMutex
+	envRegistry    = map[string]EnvVar{}
+)
+
+// EnvVar describes an environment variable that is consumed by the application.
+type EnvVar struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"hasDefault"`
+	Usage      string `json:"usage,omitempty"`
+}
+
+// DocFormat selects the output format of [DocumentEnv].
+type DocFormat int
+
+const (
+	DocMarkdown DocFormat = iota // DocMarkdown outputs a Markdown table.
+	DocJSON                      // DocJSON outputs a JSON array of [EnvVar].
+)
+
+// RegisterEnv records environment variables that are read outside a [Loader], so they're included in [DocumentEnv].
+// Every [Loader] registers the variables named by its `env` struct tags when it's created, so they don't need to be registered again.
+// A variable registered more than once is documented with the most recent registration.
+//
+// This will panic if a variable has an empty name.
+func RegisterEnv(vars ...EnvVar) {
+	for _, v := range vars {
+		if len(v.Name) == 0 {
+			panic("empty environment variable name")
+		}
+	}
+	syncx.LockFunc(&envRegistryMux, func() {
+		for _, v := range vars {
+			envRegistry[v.Name] = v
+		}
+	})
+}
+
+// RegisteredEnv returns all registered environment variables, sorted by name.
+func RegisteredEnv() []EnvVar {
+	vars := syncx.RLockFuncT(&envRegistryMux, func() []EnvVar {
+		vars := make([]EnvVar, 0, len(envRegistry))
+		for _, v := range envRegistry {
+			vars = append(vars, v)
+		}
+		return vars
+	})
+	slices.SortFunc(vars, func(a, b EnvVar) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return vars
+}
+
+// DocumentEnv writes documentation for all registered environment variables in the given format.
+// This is useful for generating a reference of every variable a service actually reads, like from a hidden sub-command or a build step.
+func DocumentEnv(w io.Writer, format DocFormat) error {
+	vars := RegisteredEnv()
+	switch format {
+	case DocJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(vars)
+	case DocMarkdown:
+		var buf strings.Builder
+		buf.WriteString("| Name | Type | Default | Description |\n")
+		buf.WriteString("| ---- | ---- | ------- | ----------- |\n")
+		for _, v := range vars {
+			def := ""
+			if v.HasDefault {
+				def = "`" + v.Default + "`"
+			}
+			_, _ = fmt.Fprintf(&buf, "| `%s` | %s | %s | %s |\n", v.Name, escapeMarkdownCell(v.Type), escapeMarkdownCell(def), escapeMarkdownCell(v.Usage))
+		}
+		_, err := io.WriteString(w, buf.String())
+		return err
+	default:
+		return fmt.Errorf("%w: %d", ErrUnsupportedDocFormat, format)
+	}
+}
+
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// EnvVars returns the environment variables read by this [Loader], including any prefix set with [OptEnvPrefix], in struct order.
+func (l *Loader[T]) EnvVars() []EnvVar {
+	var vars []EnvVar
+	for _, f := range l.fields {
+		if len(f.env) == 0 {
+			continue
+		}
+		vars = append(vars, EnvVar{
+			Name:       l.conf.envPrefix + f.env,
+			Type:       f.fieldTyp.String(),
+			Default:    f.def,
+			HasDefault: f.hasDef,
+			Usage:      f.usage,
+		})
+	}
+	return vars
+}
@@ -0,0 +1,47 @@
+package configx
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLoader_EnvVars(t *testing.T) {
+	l, err := NewLoader[testConfig](OptEnvPrefix("ENVDOC_"))
+	require.NoError(t, err)
+	vars := l.EnvVars()
+	require.Len(t, vars, 4)
+	assert.Equal(t, EnvVar{Name: "ENVDOC_LOG_LEVEL", Type: "string", Default: "info", HasDefault: true}, vars[0])
+	assert.Equal(t, EnvVar{Name: "ENVDOC_TAGS", Type: "[]string"}, vars[2])
+	assert.Equal(t, "ENVDOC_PORT", vars[3].Name)
+
+	registered := map[string]EnvVar{}
+	for _, v := range RegisteredEnv() {
+		registered[v.Name] = v
+	}
+	for _, v := range vars {
+		assert.Equal(t, v, registered[v.Name], "Loader variables should be registered")
+	}
+}
+
+func TestDocumentEnv(t *testing.T) {
+	RegisterEnv(EnvVar{Name: "ENVDOC_TEST_TOKEN", Type: "string", Usage: "Sets the API | token"})
+	assert.Panics(t, func() {
+		RegisterEnv(EnvVar{})
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, DocumentEnv(&buf, DocMarkdown))
+	assert.Contains(t, buf.String(), "| Name | Type | Default | Description |")
+	assert.Contains(t, buf.String(), "| `ENVDOC_TEST_TOKEN` | string |  | Sets the API \\| token |")
+
+	buf.Reset()
+	require.NoError(t, DocumentEnv(&buf, DocJSON))
+	var vars []EnvVar
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &vars))
+	assert.Contains(t, vars, EnvVar{Name: "ENVDOC_TEST_TOKEN", Type: "string", Usage: "Sets the API | token"})
+
+	assert.ErrorIs(t, DocumentEnv(&buf, DocFormat(-1)), ErrUnsupportedDocFormat)
+}
@@ -0,0 +1,83 @@
+package configx
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isLeaf determines whether the type should be set as a single value rather than traversed as a nested struct.
+func isLeaf(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return true
+	}
+	return reflect.PointerTo(typ).Implements(textUnmarshalerType)
+}
+
+// setFromString interprets the string value according to the target's type.
+// Slices are interpreted as comma separated values.
+func setFromString(target reflect.Value, val string) error {
+	if target.CanAddr() && target.Addr().Type().Implements(textUnmarshalerType) {
+		return target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(val))
+	}
+	if target.Type() == durationType {
+		dur, err := time.ParseDuration(val)
+		if err != nil {
+			return err
+		}
+		target.SetInt(int64(dur))
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(val, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(val, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(val, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(f)
+	case reflect.Slice:
+		if len(strings.TrimSpace(val)) == 0 {
+			target.Set(reflect.MakeSlice(target.Type(), 0, 0))
+			return nil
+		}
+		parts := strings.Split(val, ",")
+		slice := reflect.MakeSlice(target.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setFromString(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		target.Set(slice)
+	default:
+		return fmt.Errorf("unsupported field type %s", target.Type())
+	}
+	return nil
+}
@@ -14,3 +14,25 @@ func IsDone(ctx context.Context) bool {
 		return false
 	}
 }
+
+// AnyDone returns true if any of the given contexts are done.
+// Nil contexts are ignored.
+func AnyDone(ctxs ...context.Context) bool {
+	for _, ctx := range ctxs {
+		if IsDone(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllDone returns true if all the given contexts are done.
+// Nil contexts are never done, so this returns false if any are nil.
+func AllDone(ctxs ...context.Context) bool {
+	for _, ctx := range ctxs {
+		if !IsDone(ctx) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package contextx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ExtendDeadline returns a [context.Context] with the parent's values, but with a deadline d later than the parent's deadline.
+// This is useful for cleanup work that should outlive the parent's deadline by a bounded amount, like flushing a response or rolling back a transaction.
+//
+// The returned context is still cancelled if the parent is cancelled for any reason other than its deadline passing.
+// If the parent has no deadline, then the returned context has no deadline either, and only follows the parent's cancellation.
+// The returned [context.CancelFunc] should be called when the work is complete to release resources.
+//
+// If the parent is nil, then [ExtendDeadline] will panic.
+func ExtendDeadline(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		panic("nil context")
+	}
+	var (
+		ctx    context.Context
+		cancel context.CancelCauseFunc
+	)
+	if deadline, ok := parent.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithDeadline(context.WithoutCancel(parent), deadline.Add(d))
+		var cancelCause context.CancelCauseFunc
+		ctx, cancelCause = context.WithCancelCause(ctx)
+		cancel = func(cause error) {
+			cancelCause(cause)
+			cancelDeadline()
+		}
+	} else {
+		ctx, cancel = context.WithCancelCause(context.WithoutCancel(parent))
+	}
+	stop := context.AfterFunc(parent, func() {
+		if errors.Is(parent.Err(), context.DeadlineExceeded) {
+			return
+		}
+		cancel(context.Cause(parent))
+	})
+	return ctx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+// NearDeadline returns a channel that is closed when the context's deadline is within the threshold, or when the context is done.
+// This allows work to emit a warning or switch to a degraded path before the deadline actually cancels the context.
+//
+// If the context has no deadline, then the channel is only closed when the context is done.
+//
+// If the context is nil, then [NearDeadline] will panic.
+func NearDeadline(ctx context.Context, threshold time.Duration) <-chan struct{} {
+	if ctx == nil {
+		panic("nil context")
+	}
+	ch := make(chan struct{})
+	closer := sync.OnceFunc(func() {
+		close(ch)
+	})
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		context.AfterFunc(ctx, closer)
+		return ch
+	}
+	timer := time.AfterFunc(time.Until(deadline.Add(-threshold)), closer)
+	context.AfterFunc(ctx, func() {
+		timer.Stop()
+		closer()
+	})
+	return ch
+}
@@ -0,0 +1,70 @@
+package contextx
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type testKey struct{}
+
+func TestExtendDeadline(t *testing.T) {
+	parent, parentCancel := context.WithTimeout(context.WithValue(context.Background(), testKey{}, "value"), 20*time.Millisecond)
+	defer parentCancel()
+	parentDeadline, _ := parent.Deadline()
+
+	ctx, cancel := ExtendDeadline(parent, time.Minute)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, parentDeadline.Add(time.Minute), deadline)
+	assert.Equal(t, "value", ctx.Value(testKey{}))
+
+	<-parent.Done()
+	time.Sleep(10 * time.Millisecond)
+	assert.False(t, IsDone(ctx), "Extended context should outlive the parent's deadline")
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestExtendDeadline_ParentCancelled(t *testing.T) {
+	errStop := errors.New("stop")
+	parent, parentCancel := context.WithCancelCause(context.Background())
+	ctx, cancel := ExtendDeadline(parent, time.Minute)
+	defer cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	parentCancel(errStop)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Extended context should be cancelled with the parent")
+	}
+	assert.ErrorIs(t, context.Cause(ctx), errStop)
+}
+
+func TestNearDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	near := NearDeadline(ctx, 80*time.Millisecond)
+	select {
+	case <-near:
+		assert.Less(t, time.Since(start), 80*time.Millisecond)
+		assert.False(t, IsDone(ctx))
+	case <-time.After(time.Second):
+		t.Fatal("Should have been near the deadline")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	near = NearDeadline(ctx, time.Second)
+	cancel()
+	select {
+	case <-near:
+	case <-time.After(time.Second):
+		t.Fatal("Channel should be closed when the context is done")
+	}
+}
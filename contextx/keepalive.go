@@ -0,0 +1,81 @@
+package contextx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInactive = errors.New("context cancelled due to inactivity")
+)
+
+// KeepaliveContext is a [context.Context] that is cancelled if [KeepaliveContext.Touch] isn't called within an inactivity window.
+// When cancelled due to inactivity, [context.Cause] returns [ErrInactive].
+//
+// This is useful for guarding long-running work that may silently hang, like a worker holding a lease.
+// The work calls Touch as it makes progress, and anything watching the context will know when progress has stopped.
+type KeepaliveContext struct {
+	context.Context
+	cancel    context.CancelCauseFunc
+	window    time.Duration
+	mux       sync.Mutex
+	timer     *time.Timer
+	lastTouch time.Time
+}
+
+// WithKeepalive returns a [KeepaliveContext] that is cancelled with [ErrInactive] if it's not touched within the window.
+// The returned [context.CancelFunc] should be called when the work is complete to release resources.
+//
+// If the parent is nil, then [WithKeepalive] will panic.
+func WithKeepalive(parent context.Context, window time.Duration) (*KeepaliveContext, context.CancelFunc) {
+	if parent == nil {
+		panic("nil context")
+	}
+	if window <= 0 {
+		panic("keepalive window must be > 0")
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	k := &KeepaliveContext{
+		Context:   ctx,
+		cancel:    cancel,
+		window:    window,
+		lastTouch: time.Now(),
+	}
+	k.timer = time.AfterFunc(window, func() {
+		cancel(ErrInactive)
+	})
+	stop := context.AfterFunc(ctx, func() {
+		k.timer.Stop()
+	})
+	return k, func() {
+		stop()
+		k.timer.Stop()
+		cancel(context.Canceled)
+	}
+}
+
+// Touch resets the inactivity window.
+// Returns false if the context is already done, in which case touching has no effect.
+func (k *KeepaliveContext) Touch() bool {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	if IsDone(k.Context) {
+		return false
+	}
+	if !k.timer.Stop() {
+		// The timer has already fired, so the context is being cancelled.
+		return false
+	}
+	k.timer.Reset(k.window)
+	k.lastTouch = time.Now()
+	return true
+}
+
+// LastTouch returns the last time the context was touched, or when it was created if it was never touched.
+func (k *KeepaliveContext) LastTouch() time.Time {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	return k.lastTouch
+}
@@ -0,0 +1,48 @@
+package contextx
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWithKeepalive(t *testing.T) {
+	ctx, cancel := WithKeepalive(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		assert.True(t, ctx.Touch(), "Touching within the window should keep the context alive")
+	}
+	assert.False(t, IsDone(ctx))
+	assert.WithinDuration(t, time.Now(), ctx.LastTouch(), 20*time.Millisecond)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Context should have been cancelled after inactivity")
+	}
+	assert.ErrorIs(t, context.Cause(ctx), ErrInactive)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.False(t, ctx.Touch(), "Touch should fail after cancellation")
+}
+
+func TestWithKeepalive_Cancel(t *testing.T) {
+	ctx, cancel := WithKeepalive(context.Background(), time.Minute)
+	cancel()
+	assert.True(t, IsDone(ctx))
+	assert.ErrorIs(t, context.Cause(ctx), context.Canceled)
+	assert.NotErrorIs(t, context.Cause(ctx), ErrInactive)
+
+	parent, parentCancel := context.WithCancel(context.Background())
+	ctx, cancel = WithKeepalive(parent, time.Minute)
+	defer cancel()
+	parentCancel()
+	assert.True(t, IsDone(ctx), "Parent cancellation should propagate")
+	assert.False(t, ctx.Touch())
+
+	assert.Panics(t, func() {
+		WithKeepalive(context.Background(), 0)
+	})
+}
@@ -0,0 +1,300 @@
+package contextx
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EnvTrace is the environment variable that enables context tracing when the program starts, if it's set to "1" or "true".
+const EnvTrace = "CONTEXTX_TRACE"
+
+// EnableTrace makes [WithCancel], [WithCancelCause], [WithTimeout], and [WithDeadline] record where each context is created, its traced parent, and when and why it's done.
+// Use [TraceOf] to find out why a context was cancelled, and [DumpTrace] to print the tree of contexts that are not done yet.
+//
+// Only contexts created while tracing is enabled are traced.
+// This adds overhead to creating contexts, so it's only intended for debugging.
+// It can also be enabled with the [EnvTrace] environment variable.
+func EnableTrace() {
+	tracing.Store(true)
+}
+
+// DisableTrace stops tracing contexts created after it's called.
+func DisableTrace() {
+	tracing.Store(false)
+}
+
+var (
+	tracing  atomic.Bool
+	traceSeq atomic.Uint64
+	liveMux  sync.Mutex
+	live     = map[uint64]*traceNode{}
+)
+
+func init() {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(EnvTrace))) {
+	case "1", "true":
+		EnableTrace()
+	}
+}
+
+// ContextTrace describes a traced context.
+type ContextTrace struct {
+	ID      uint64
+	Kind    string    // Kind is the function that created the context, like "WithCancel".
+	Site    string    // Site is the function and line that created the context.
+	Created time.Time // Created is when the context was created.
+	Done    time.Time // Done is when the context was done, or the zero value if it's not done yet.
+	DoneBy  string    // DoneBy explains why the context is done, like the function and line that called its cancel function.
+	Cause   error     // Cause is the [context.Cause] of the context once it's done.
+	Parent  *ContextTrace
+}
+
+// String describes the context, followed by each of its traced ancestors.
+func (t *ContextTrace) String() string {
+	var buf strings.Builder
+	for cur, depth := t, 0; cur != nil; cur, depth = cur.Parent, depth+1 {
+		if depth > 0 {
+			buf.WriteString("\n" + strings.Repeat("  ", depth-1) + "created by ")
+		}
+		buf.WriteString(cur.describe(time.Now()))
+	}
+	return buf.String()
+}
+
+func (t *ContextTrace) describe(now time.Time) string {
+	desc := fmt.Sprintf("#%d %s at %s", t.ID, t.Kind, t.Site)
+	if t.Done.IsZero() {
+		return desc + fmt.Sprintf(", active for %s", now.Sub(t.Created).Round(time.Millisecond))
+	}
+	desc += fmt.Sprintf(", done after %s: %s", t.Done.Sub(t.Created).Round(time.Millisecond), t.DoneBy)
+	if t.Cause != nil {
+		desc += fmt.Sprintf(" (%v)", t.Cause)
+	}
+	return desc
+}
+
+type traceKey struct{}
+
+type traceNode struct {
+	id      uint64
+	kind    string
+	site    string
+	created time.Time
+	parent  *traceNode
+
+	mux    sync.Mutex
+	done   time.Time
+	doneBy string
+	cause  error
+}
+
+// finish records that the context is done, if it wasn't already.
+func (n *traceNode) finish(doneBy string, cause error) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	if !n.done.IsZero() {
+		return
+	}
+	n.done = time.Now()
+	n.doneBy = doneBy
+	n.cause = cause
+}
+
+func (n *traceNode) snapshot() *ContextTrace {
+	if n == nil {
+		return nil
+	}
+	n.mux.Lock()
+	trace := &ContextTrace{
+		ID:      n.id,
+		Kind:    n.kind,
+		Site:    n.site,
+		Created: n.created,
+		Done:    n.done,
+		DoneBy:  n.doneBy,
+		Cause:   n.cause,
+	}
+	n.mux.Unlock()
+	trace.Parent = n.parent.snapshot()
+	return trace
+}
+
+// TraceOf returns the trace of the context's nearest traced ancestor, which is the context itself if it was created by a traced function while tracing was enabled.
+// False is returned if there's no traced context in the chain.
+func TraceOf(ctx context.Context) (*ContextTrace, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	node, ok := ctx.Value(traceKey{}).(*traceNode)
+	if !ok {
+		return nil, false
+	}
+	return node.snapshot(), true
+}
+
+// DumpTrace writes the tree of traced contexts that are not done yet, which can help find contexts that are never cancelled.
+func DumpTrace(w io.Writer) error {
+	liveMux.Lock()
+	nodes := slices.Collect(maps.Values(live))
+	liveMux.Unlock()
+	slices.SortFunc(nodes, func(a, b *traceNode) int {
+		return cmp.Compare(a.id, b.id)
+	})
+	var (
+		now      = time.Now()
+		children = map[*traceNode][]*traceNode{}
+		isLive   = map[*traceNode]bool{}
+		roots    []*traceNode
+	)
+	for _, node := range nodes {
+		isLive[node] = true
+	}
+	for _, node := range nodes {
+		// Attach to the nearest live ancestor, since a done parent is no longer in the tree.
+		parent := node.parent
+		for parent != nil && !isLive[parent] {
+			parent = parent.parent
+		}
+		if parent == nil {
+			roots = append(roots, node)
+			continue
+		}
+		children[parent] = append(children[parent], node)
+	}
+	var write func(node *traceNode, depth int) error
+	write = func(node *traceNode, depth int) error {
+		if _, err := fmt.Fprintf(w, "%s%s\n", strings.Repeat("  ", depth), node.snapshot().describe(now)); err != nil {
+			return err
+		}
+		for _, child := range children[node] {
+			if err := write(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, root := range roots {
+		if err := write(root, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithCancel is the same as [context.WithCancel], but the context is traced if [EnableTrace] has been called.
+func WithCancel(parent context.Context) (context.Context, context.CancelFunc) {
+	if !tracing.Load() {
+		return context.WithCancel(parent)
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	ctx, cancelAt := trace(parent, ctx, "WithCancel", callerSite(0), cancel)
+	return ctx, func() {
+		cancelAt(context.Canceled, callerSite(0))
+	}
+}
+
+// WithCancelCause is the same as [context.WithCancelCause], but the context is traced if [EnableTrace] has been called.
+func WithCancelCause(parent context.Context) (context.Context, context.CancelCauseFunc) {
+	if !tracing.Load() {
+		return context.WithCancelCause(parent)
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	ctx, cancelAt := trace(parent, ctx, "WithCancelCause", callerSite(0), cancel)
+	return ctx, func(cause error) {
+		cancelAt(cause, callerSite(0))
+	}
+}
+
+// WithTimeout is the same as [context.WithTimeout], but the context is traced if [EnableTrace] has been called.
+func WithTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if !tracing.Load() {
+		return context.WithTimeout(parent, timeout)
+	}
+	ctx, cancelAt := withDeadline(parent, time.Now().Add(timeout), "WithTimeout", callerSite(0))
+	return ctx, func() {
+		cancelAt(context.Canceled, callerSite(0))
+	}
+}
+
+// WithDeadline is the same as [context.WithDeadline], but the context is traced if [EnableTrace] has been called.
+func WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	if !tracing.Load() {
+		return context.WithDeadline(parent, deadline)
+	}
+	ctx, cancelAt := withDeadline(parent, deadline, "WithDeadline", callerSite(0))
+	return ctx, func() {
+		cancelAt(context.Canceled, callerSite(0))
+	}
+}
+
+func withDeadline(parent context.Context, deadline time.Time, kind, site string) (context.Context, func(cause error, site string)) {
+	ctx, cancelDeadline := context.WithDeadline(parent, deadline)
+	ctx, cancel := context.WithCancelCause(ctx)
+	return trace(parent, ctx, kind, site, func(cause error) {
+		cancel(cause)
+		cancelDeadline()
+	})
+}
+
+// trace records a node for the context created at the site, and returns a child context that refers to it.
+// The returned function cancels the context, recording the site that cancelled it.
+func trace(parent, ctx context.Context, kind, site string, cancel context.CancelCauseFunc) (context.Context, func(cause error, site string)) {
+	node := &traceNode{
+		id:      traceSeq.Add(1),
+		kind:    kind,
+		site:    site,
+		created: time.Now(),
+	}
+	node.parent, _ = parent.Value(traceKey{}).(*traceNode)
+	liveMux.Lock()
+	live[node.id] = node
+	liveMux.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		switch {
+		case parent.Err() != nil:
+			node.finish("parent done", context.Cause(ctx))
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			node.finish("deadline exceeded", context.Cause(ctx))
+		default:
+			// The cancel function records its caller before cancelling, so this shouldn't happen.
+			node.finish("cancelled", context.Cause(ctx))
+		}
+		liveMux.Lock()
+		delete(live, node.id)
+		liveMux.Unlock()
+	})
+	return context.WithValue(ctx, traceKey{}, node), func(cause error, site string) {
+		if cause == nil {
+			cause = context.Canceled
+		}
+		if ctx.Err() == nil {
+			node.finish("cancelled by "+site, cause)
+		}
+		cancel(cause)
+	}
+}
+
+// callerSite describes the caller of the function that calls callerSite, with skip additional frames skipped, like "main.run (main.go:12)".
+func callerSite(skip int) string {
+	pc, file, line, ok := runtime.Caller(skip + 2)
+	if !ok {
+		return "unknown"
+	}
+	name := "unknown"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name = fn.Name()
+	}
+	return fmt.Sprintf("%s (%s:%d)", name, file, line)
+}
@@ -0,0 +1,99 @@
+package contextx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func enableTestTrace(t *testing.T) {
+	EnableTrace()
+	t.Cleanup(DisableTrace)
+}
+
+func TestTrace_Cancelled(t *testing.T) {
+	enableTestTrace(t)
+	parent, parentCancel := WithCancel(context.Background())
+	defer parentCancel()
+	ctx, cancel := WithTimeout(parent, time.Minute)
+	cancel()
+
+	trace, ok := TraceOf(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "WithTimeout", trace.Kind)
+	assert.Contains(t, trace.Site, "TestTrace_Cancelled", "The creation site should be the caller")
+	assert.False(t, trace.Done.IsZero())
+	assert.Contains(t, trace.DoneBy, "cancelled by")
+	assert.Contains(t, trace.DoneBy, "TestTrace_Cancelled", "The cancel site should be the caller")
+	assert.ErrorIs(t, trace.Cause, context.Canceled)
+	require.NotNil(t, trace.Parent)
+	assert.Equal(t, "WithCancel", trace.Parent.Kind)
+	assert.True(t, trace.Parent.Done.IsZero())
+	assert.Contains(t, trace.String(), "created by")
+}
+
+func TestTrace_ParentDone(t *testing.T) {
+	enableTestTrace(t)
+	errStop := errors.New("stop")
+	parent, parentCancel := WithCancelCause(context.Background())
+	ctx, cancel := WithCancel(context.WithValue(parent, testKey{}, "value"))
+	defer cancel()
+	parentCancel(errStop)
+	<-ctx.Done()
+
+	require.Eventually(t, func() bool {
+		trace, _ := TraceOf(ctx)
+		return !trace.Done.IsZero()
+	}, time.Second, time.Millisecond)
+	trace, _ := TraceOf(ctx)
+	assert.Equal(t, "parent done", trace.DoneBy)
+	assert.ErrorIs(t, trace.Cause, errStop)
+	assert.ErrorIs(t, trace.Parent.Cause, errStop)
+}
+
+func TestTrace_Deadline(t *testing.T) {
+	enableTestTrace(t)
+	ctx, cancel := WithDeadline(context.Background(), time.Now().Add(10*time.Millisecond))
+	defer cancel()
+	<-ctx.Done()
+	require.Eventually(t, func() bool {
+		trace, _ := TraceOf(ctx)
+		return !trace.Done.IsZero()
+	}, time.Second, time.Millisecond)
+	trace, _ := TraceOf(ctx)
+	assert.Equal(t, "deadline exceeded", trace.DoneBy)
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestDumpTrace(t *testing.T) {
+	enableTestTrace(t)
+	parent, parentCancel := WithCancel(context.Background())
+	child, childCancel := WithCancel(parent)
+	defer childCancel()
+	parentTrace, _ := TraceOf(parent)
+	childTrace, _ := TraceOf(child)
+
+	var buf bytes.Buffer
+	require.NoError(t, DumpTrace(&buf))
+	assert.Contains(t, buf.String(), parentTrace.Site)
+	assert.Contains(t, buf.String(), "\n  #", "The child should be nested under the parent")
+
+	parentCancel()
+	<-child.Done()
+	require.Eventually(t, func() bool {
+		buf.Reset()
+		require.NoError(t, DumpTrace(&buf))
+		return !bytes.Contains(buf.Bytes(), []byte(childTrace.Site))
+	}, time.Second, time.Millisecond, "Done contexts should be removed from the tree")
+}
+
+func TestTrace_Disabled(t *testing.T) {
+	ctx, cancel := WithCancel(context.Background())
+	defer cancel()
+	_, ok := TraceOf(ctx)
+	assert.False(t, ok)
+}
@@ -0,0 +1,86 @@
+package contextx
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// WaitAny blocks until any of the given contexts is done, and returns its index.
+// If multiple contexts are done, then the lowest index is returned.
+// If no contexts are given, then -1 is returned immediately.
+//
+// If any [context.Context] is nil, then [WaitAny] will panic.
+func WaitAny(ctxs ...context.Context) int {
+	return waitAny(nil, ctxs)
+}
+
+// WaitAnyTimeout is the same as [WaitAny], but returns -1 if no context is done before the timeout elapses.
+func WaitAnyTimeout(timeout time.Duration, ctxs ...context.Context) int {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	return waitAny(timer.C, ctxs)
+}
+
+func waitAny(timeout <-chan time.Time, ctxs []context.Context) int {
+	if len(ctxs) == 0 {
+		return -1
+	}
+	for _, ctx := range ctxs {
+		if ctx == nil {
+			panic("nil context")
+		}
+	}
+	for i, ctx := range ctxs {
+		if IsDone(ctx) {
+			return i
+		}
+	}
+	cases := make([]reflect.SelectCase, len(ctxs), len(ctxs)+1)
+	for i, ctx := range ctxs {
+		cases[i] = reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(ctx.Done()),
+		}
+	}
+	if timeout != nil {
+		cases = append(cases, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(timeout),
+		})
+	}
+	chosen, _, _ := reflect.Select(cases)
+	if chosen == len(ctxs) {
+		return -1
+	}
+	return chosen
+}
+
+// WaitAll blocks until all the given contexts are done.
+//
+// If any [context.Context] is nil, then [WaitAll] will panic.
+func WaitAll(ctxs ...context.Context) {
+	for _, ctx := range ctxs {
+		if ctx == nil {
+			panic("nil context")
+		}
+		<-ctx.Done()
+	}
+}
+
+// WaitAllTimeout is the same as [WaitAll], but returns false if not all contexts are done before the timeout elapses.
+func WaitAllTimeout(timeout time.Duration, ctxs ...context.Context) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for _, ctx := range ctxs {
+		if ctx == nil {
+			panic("nil context")
+		}
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package contextx
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWaitAny(t *testing.T) {
+	bg := context.Background()
+	a, cancelA := context.WithCancel(bg)
+	defer cancelA()
+	b, cancelB := context.WithCancel(bg)
+	defer cancelB()
+
+	assert.Equal(t, -1, WaitAny())
+	assert.Equal(t, -1, WaitAnyTimeout(10*time.Millisecond, a, b))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancelB()
+	}()
+	assert.Equal(t, 1, WaitAny(a, b))
+	assert.Equal(t, 1, WaitAnyTimeout(time.Second, a, b))
+	cancelA()
+	assert.Equal(t, 0, WaitAny(a, b), "Lowest index should be returned when multiple are done")
+
+	assert.Panics(t, func() {
+		WaitAny(a, nil)
+	})
+}
+
+func TestWaitAll(t *testing.T) {
+	bg := context.Background()
+	a, cancelA := context.WithCancel(bg)
+	defer cancelA()
+	b, cancelB := context.WithTimeout(bg, 10*time.Millisecond)
+	defer cancelB()
+
+	assert.False(t, WaitAllTimeout(20*time.Millisecond, a, b))
+	assert.True(t, AnyDone(a, b))
+	assert.False(t, AllDone(a, b))
+
+	go cancelA()
+	WaitAll(a, b)
+	assert.True(t, AllDone(a, b))
+	assert.True(t, WaitAllTimeout(time.Millisecond, a, b))
+	assert.False(t, AnyDone(nil, bg))
+	assert.False(t, AllDone(a, nil))
+}
@@ -0,0 +1,231 @@
+package encodingx
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownFields = errors.New("unknown fields in JSON input")
+
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+type decodeConf struct {
+	caseSensitive   bool
+	disallowUnknown bool
+}
+
+// DecodeOption changes the behavior of [DecodeJSON].
+type DecodeOption func(conf *decodeConf)
+
+// OptCaseSensitive makes field matching case-sensitive.
+// By default, [encoding/json] matches keys to fields case-insensitively, which can mask typos in hand-written payloads.
+// With this option, a key that only matches a field case-insensitively is reported as unknown and not applied.
+func OptCaseSensitive() DecodeOption {
+	return func(conf *decodeConf) {
+		conf.caseSensitive = true
+	}
+}
+
+// OptDisallowUnknown makes [DecodeJSON] return an error wrapping [ErrUnknownFields] instead of just reporting unknown fields.
+// The target will not be modified in this case.
+func OptDisallowUnknown() DecodeOption {
+	return func(conf *decodeConf) {
+		conf.disallowUnknown = true
+	}
+}
+
+// DecodeJSON will decode the data into target, and return the paths of all input fields that don't map to a field in target.
+// This is useful for warning about likely mistakes in config or request payloads without rejecting them outright, which [json.Decoder.DisallowUnknownFields] would do.
+//
+// Paths are dot separated, with array indexes in brackets, like "items[2].name".
+func DecodeJSON(data []byte, target any, opts ...DecodeOption) ([]string, error) {
+	var conf decodeConf
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	typ := reflect.TypeOf(target)
+	if typ == nil || typ.Kind() != reflect.Pointer {
+		return nil, fmt.Errorf("target must be a non-nil pointer, got %T", target)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	var unknown []string
+	pruned := walkUnknown(tree, typ, "", &conf, &unknown)
+	if conf.disallowUnknown && len(unknown) > 0 {
+		return unknown, fmt.Errorf("%w: %s", ErrUnknownFields, strings.Join(unknown, ", "))
+	}
+	if pruned {
+		// Keys were removed from the tree, so it needs to be re-encoded to avoid applying them.
+		var err error
+		data, err = json.Marshal(tree)
+		if err != nil {
+			return unknown, err
+		}
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return unknown, err
+	}
+	return unknown, nil
+}
+
+// DecodeJSONReader reads all data from the [io.Reader] and passes it to [DecodeJSON].
+func DecodeJSONReader(r io.Reader, target any, opts ...DecodeOption) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeJSON(data, target, opts...)
+}
+
+// walkUnknown finds unknown keys in the decoded tree relative to the type.
+// Returns true if keys were removed from the tree.
+func walkUnknown(tree any, typ reflect.Type, path string, conf *decodeConf, unknown *[]string) bool {
+	for typ.Kind() == reflect.Pointer {
+		if typ.Implements(jsonUnmarshalerType) || typ.Implements(textUnmarshalerType) {
+			return false
+		}
+		typ = typ.Elem()
+	}
+	if reflect.PointerTo(typ).Implements(jsonUnmarshalerType) || reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return false
+	}
+	var pruned bool
+	switch typ.Kind() {
+	case reflect.Struct:
+		obj, ok := tree.(map[string]any)
+		if !ok {
+			return false
+		}
+		fields := jsonFields(typ)
+		for key, val := range obj {
+			fieldTyp, ok := fields[key]
+			if !ok {
+				var folded string
+				for name := range fields {
+					if strings.EqualFold(name, key) {
+						folded = name
+						break
+					}
+				}
+				if len(folded) == 0 || conf.caseSensitive {
+					*unknown = append(*unknown, joinPath(path, key))
+					if len(folded) > 0 {
+						delete(obj, key)
+						pruned = true
+					}
+					continue
+				}
+				fieldTyp = fields[folded]
+			}
+			if walkUnknown(val, fieldTyp, joinPath(path, key), conf, unknown) {
+				pruned = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := tree.([]any)
+		if !ok {
+			return false
+		}
+		for i, val := range arr {
+			if walkUnknown(val, typ.Elem(), path+"["+strconv.Itoa(i)+"]", conf, unknown) {
+				pruned = true
+			}
+		}
+	case reflect.Map:
+		obj, ok := tree.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, val := range obj {
+			if walkUnknown(val, typ.Elem(), joinPath(path, key), conf, unknown) {
+				pruned = true
+			}
+		}
+	default:
+	}
+	return pruned
+}
+
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonFields maps JSON key names to field types, following the same naming rules as [encoding/json].
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && len(name) == 0 {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range jsonFields(embedded) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = sf.Name
+		}
+		fields[name] = sf.Type
+	}
+	return fields
+}
+
+// Pretty re-formats JSON data with indentation.
+// The default indent is two spaces, but this can be overridden.
+func Pretty(data []byte, indent ...string) ([]byte, error) {
+	_indent := "  "
+	if len(indent) > 0 {
+		_indent = indent[0]
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", _indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Compact removes insignificant whitespace from JSON data.
+func Compact(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalPretty marshals the value to JSON with two space indentation.
+func MarshalPretty(v any) ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
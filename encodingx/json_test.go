@@ -0,0 +1,76 @@
+package encodingx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type testEmbedded struct {
+	ID int `json:"id"`
+}
+
+type testPayload struct {
+	testEmbedded
+	Name  string `json:"name"`
+	Items []struct {
+		Label string `json:"label"`
+	} `json:"items"`
+	Attrs   map[string]struct{ Value string } `json:"attrs"`
+	Ignored string                            `json:"-"`
+}
+
+func TestDecodeJSON_Unknown(t *testing.T) {
+	data := []byte(`{"id": 1, "name": "test", "nmae": "typo", "items": [{"label": "a"}, {"lable": "b"}], "attrs": {"x": {"Value": "y", "extra": true}}, "Ignored": "value"}`)
+	var payload testPayload
+	unknown, err := DecodeJSON(data, &payload)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"nmae", "items[1].lable", "attrs.x.extra", "Ignored"}, unknown)
+	assert.Equal(t, 1, payload.ID)
+	assert.Equal(t, "test", payload.Name)
+	assert.Len(t, payload.Items, 2)
+	assert.Equal(t, "y", payload.Attrs["x"].Value)
+}
+
+func TestDecodeJSON_CaseSensitive(t *testing.T) {
+	data := []byte(`{"NAME": "test"}`)
+
+	var lenient testPayload
+	unknown, err := DecodeJSON(data, &lenient)
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+	assert.Equal(t, "test", lenient.Name)
+
+	var strict testPayload
+	unknown, err = DecodeJSON(data, &strict, OptCaseSensitive())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NAME"}, unknown)
+	assert.Empty(t, strict.Name, "Case mismatched key should not have been applied")
+}
+
+func TestDecodeJSON_DisallowUnknown(t *testing.T) {
+	var payload testPayload
+	unknown, err := DecodeJSON([]byte(`{"name": "test", "other": 1}`), &payload, OptDisallowUnknown())
+	assert.ErrorIs(t, err, ErrUnknownFields)
+	assert.Equal(t, []string{"other"}, unknown)
+	assert.Empty(t, payload.Name)
+}
+
+func TestDecodeJSON_InvalidTarget(t *testing.T) {
+	var payload testPayload
+	_, err := DecodeJSON([]byte(`{}`), payload)
+	assert.Error(t, err)
+}
+
+func TestPretty(t *testing.T) {
+	out, err := Pretty([]byte(`{"a":[1,2]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": [\n    1,\n    2\n  ]\n}", string(out))
+
+	compact, err := Compact(out)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":[1,2]}`, string(compact))
+
+	_, err = Pretty([]byte(`{`))
+	assert.Error(t, err)
+}
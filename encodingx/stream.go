@@ -0,0 +1,41 @@
+package encodingx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// DecodeArray returns an iterator that decodes a top-level JSON array from the reader one element at a time.
+// This avoids holding the entire array in memory, which matters for very large payloads.
+//
+// If an error occurs, then it will be yielded with the zero value of T and iteration will stop.
+func DecodeArray[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		dec := json.NewDecoder(r)
+		tok, err := dec.Token()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			yield(zero, fmt.Errorf("expected start of JSON array, got %v", tok))
+			return
+		}
+		for dec.More() {
+			var val T
+			if err := dec.Decode(&val); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(val, nil) {
+				return
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package encodingx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDecodeArray(t *testing.T) {
+	var names []string
+	for val, err := range DecodeArray[TestStreamType](strings.NewReader(`[{"name": "a"}, {"name": "b"}, {"name": "c"}]`)) {
+		assert.NoError(t, err)
+		names = append(names, val.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestDecodeArray_EarlyStop(t *testing.T) {
+	var count int
+	for range DecodeArray[int](strings.NewReader(`[1, 2, 3, 4]`)) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestDecodeArray_Errors(t *testing.T) {
+	var errs int
+	for _, err := range DecodeArray[int](strings.NewReader(`{"not": "an array"}`)) {
+		assert.Error(t, err)
+		errs++
+	}
+	assert.Equal(t, 1, errs)
+
+	var vals []int
+	errs = 0
+	for val, err := range DecodeArray[int](strings.NewReader(`[1, "two", 3]`)) {
+		if err != nil {
+			errs++
+			continue
+		}
+		vals = append(vals, val)
+	}
+	assert.Equal(t, []int{1}, vals)
+	assert.Equal(t, 1, errs)
+}
+
+type TestStreamType struct {
+	Name string `json:"name"`
+}
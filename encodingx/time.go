@@ -0,0 +1,101 @@
+package encodingx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidTime = errors.New("invalid time value")
+
+	// TimeLayouts is the list of layouts that [Time] will attempt to use when parsing a JSON string, in order.
+	TimeLayouts = []string{
+		time.RFC3339Nano,
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		time.DateOnly,
+	}
+)
+
+// Duration is a [time.Duration] that can be decoded from either a duration string like "1m30s", or a number of nanoseconds.
+// It's always encoded as a duration string for readability.
+type Duration time.Duration
+
+// Std returns the value as a [time.Duration].
+func (d Duration) Std() time.Duration {
+	return time.Duration(d)
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		dur, err := time.ParseDuration(str)
+		if err != nil {
+			return err
+		}
+		*d = Duration(dur)
+		return nil
+	}
+	ns, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid duration '%s': %w", data, err)
+	}
+	*d = Duration(ns)
+	return nil
+}
+
+// Time is a [time.Time] that can be decoded from a string in any of the [TimeLayouts], or a number of seconds since the Unix epoch.
+// It's always encoded in RFC 3339 format with nanoseconds.
+type Time time.Time
+
+// Std returns the value as a [time.Time].
+func (t Time) Std() time.Time {
+	return time.Time(t)
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Time(t).Format(time.RFC3339Nano))
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		for _, layout := range TimeLayouts {
+			parsed, err := time.Parse(layout, str)
+			if err == nil {
+				*t = Time(parsed)
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: '%s' doesn't match a known layout", ErrInvalidTime, str)
+	}
+	secs, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTime, err)
+	}
+	whole, frac := math.Modf(secs)
+	*t = Time(time.Unix(int64(whole), int64(frac*float64(time.Second))).UTC())
+	return nil
+}
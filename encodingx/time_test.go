@@ -0,0 +1,49 @@
+package encodingx
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	var val struct {
+		A Duration `json:"a"`
+		B Duration `json:"b"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"a": "1m30s", "b": 1000}`), &val))
+	assert.Equal(t, 90*time.Second, val.A.Std())
+	assert.Equal(t, time.Microsecond, val.B.Std())
+
+	out, err := json.Marshal(val)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":"1m30s","b":"1µs"}`, string(out))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"a": "soon"}`), &val))
+}
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	expected := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := map[string]string{
+		"RFC3339":      `"2024-03-04T05:06:07Z"`,
+		"No zone":      `"2024-03-04T05:06:07"`,
+		"Space":        `"2024-03-04 05:06:07"`,
+		"Unix seconds": `1709528767`,
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			var val Time
+			require.NoError(t, json.Unmarshal([]byte(input), &val))
+			assert.True(t, expected.Equal(val.Std()), "Expected %s, got %s", expected, val.Std())
+		})
+	}
+
+	var dateOnly Time
+	require.NoError(t, json.Unmarshal([]byte(`"2024-03-04"`), &dateOnly))
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), dateOnly.Std())
+
+	var invalid Time
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"March 4th"`), &invalid), ErrInvalidTime)
+}
@@ -0,0 +1,170 @@
+package httpsec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/httpx"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+)
+
+const (
+	HeaderContentDisposition = "Content-Disposition"
+	sniffLen                 = 512
+)
+
+var (
+	ErrMIMEType = errors.New("disallowed MIME type")
+)
+
+// EnableNoSniff sends "X-Content-Type-Options: nosniff" with every response.
+// This prevents user agents from guessing a different content type than the one declared, which could otherwise turn an uploaded file into executable script.
+//
+// Source: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Content-Type-Options
+func EnableNoSniff() SecurityOption {
+	return func(sec *SecurityPolicies) error {
+		sec.headers.Set(HeaderContentTypeOptions, "nosniff")
+		return nil
+	}
+}
+
+// SanitizeFilename reduces a user supplied filename to a safe base name.
+// Directory components, control characters, and characters that are problematic in headers or file systems are removed.
+// If nothing is left, then "download" is returned.
+func SanitizeFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, `\`, "/"))
+	filename = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsControl(r), r == unicode.ReplacementChar:
+			return -1
+		case strings.ContainsRune(`"/\:*?<>|`, r):
+			return '_'
+		default:
+			return r
+		}
+	}, filename)
+	filename = strings.Trim(strings.TrimSpace(filename), ".")
+	if len(filename) == 0 {
+		return "download"
+	}
+	return filename
+}
+
+// ContentDisposition returns a Content-Disposition header value for the filename, which is sanitized with [SanitizeFilename].
+// If inline is false, then the user agent will prompt to save the file rather than displaying it.
+//
+// An ASCII-only filename is always included for older user agents, and the full UTF-8 filename is included with RFC 5987 encoding if needed.
+func ContentDisposition(filename string, inline bool) string {
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+	filename = SanitizeFilename(filename)
+	fallback := strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return '_'
+		}
+		return r
+	}, filename)
+	val := fmt.Sprintf(`%s; filename="%s"`, disposition, fallback)
+	if fallback != filename {
+		val += "; filename*=UTF-8''" + encodeExtValue(filename)
+	}
+	return val
+}
+
+// encodeExtValue percent-encodes everything except attr-char from RFC 5987.
+func encodeExtValue(s string) string {
+	const hex = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < unicode.MaxASCII && (unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)) || strings.IndexByte("!#$&+-.^_`|~", c) >= 0) {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteByte('%')
+		sb.WriteByte(hex[c>>4])
+		sb.WriteByte(hex[c&0xF])
+	}
+	return sb.String()
+}
+
+// SetDownloadHeaders sets headers for serving a user supplied file as a download.
+// The Content-Disposition is set with [ContentDisposition] so the file is saved rather than displayed, and sniffing is disabled.
+// If contentType is empty, then "application/octet-stream" is used.
+func SetDownloadHeaders(w http.ResponseWriter, filename, contentType string) {
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+	h := w.Header()
+	h.Set(httpx.HeaderContentType, contentType)
+	h.Set(HeaderContentDisposition, ContentDisposition(filename, false))
+	h.Set(HeaderContentTypeOptions, "nosniff")
+}
+
+// CheckMIMEType returns an error wrapping [ErrMIMEType] if the content type is not in the allowed list.
+// Parameters like charset are ignored, and allowed entries may use a wildcard subtype, like "image/*".
+func CheckMIMEType(contentType string, allowed ...string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%w: invalid content type '%s': %v", ErrMIMEType, contentType, err)
+	}
+	for _, allow := range allowed {
+		allow = strings.ToLower(strings.TrimSpace(allow))
+		if allow == mediaType {
+			return nil
+		}
+		if prefix, ok := strings.CutSuffix(allow, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: '%s'", ErrMIMEType, mediaType)
+}
+
+// SniffMIMEType detects the content type of the data with [http.DetectContentType], and checks it with [CheckMIMEType].
+// This should be used to validate uploads, since the declared content type is controlled by the client.
+//
+// The returned [io.Reader] replays the sniffed bytes, so the whole content may still be read.
+func SniffMIMEType(r io.Reader, allowed ...string) (string, io.Reader, error) {
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, err
+	}
+	buf = buf[:n]
+	detected := http.DetectContentType(buf)
+	replay := io.MultiReader(bytes.NewReader(buf), r)
+	if err := CheckMIMEType(detected, allowed...); err != nil {
+		return detected, replay, err
+	}
+	return detected, replay, nil
+}
+
+// RestrictRequestTypes returns a [httpx.Middleware] that responds with 415 Unsupported Media Type if a request with a body declares a content type that isn't allowed.
+// Requests without a body are passed through.
+//
+// Note that this only checks the declared type, see [SniffMIMEType] for checking the actual content.
+func RestrictRequestTypes(allowed ...string) httpx.Middleware {
+	return func(next http.Handler) http.Handler {
+		if next == nil {
+			panic("nil handler")
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength == 0 || r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if err := CheckMIMEType(r.Header.Get(httpx.HeaderContentType), allowed...); err != nil {
+				http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package httpsec
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEnableNoSniff(t *testing.T) {
+	sec, err := NewSecurityPolicies(EnableNoSniff())
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	sec.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "nosniff", rec.Header().Get(HeaderContentTypeOptions))
+}
+
+func TestContentDisposition(t *testing.T) {
+	tests := map[string]struct {
+		filename string
+		inline   bool
+		expected string
+	}{
+		"Simple":        {"report.pdf", false, `attachment; filename="report.pdf"`},
+		"Inline":        {"image.png", true, `inline; filename="image.png"`},
+		"Path":          {`../../etc/passwd`, false, `attachment; filename="passwd"`},
+		"Windows path":  {`C:\Users\me\file.txt`, false, `attachment; filename="file.txt"`},
+		"Header inject": {"a\"b\r\nSet-Cookie: x.txt", false, `attachment; filename="a_bSet-Cookie_ x.txt"`},
+		"Empty":         {"..", false, `attachment; filename="download"`},
+		"Unicode":       {"résumé (1).pdf", false, `attachment; filename="r_sum_ (1).pdf"; filename*=UTF-8''r%C3%A9sum%C3%A9%20%281%29.pdf`},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ContentDisposition(tc.filename, tc.inline))
+		})
+	}
+}
+
+func TestSetDownloadHeaders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	SetDownloadHeaders(rec, "data.bin", "")
+	assert.Equal(t, "application/octet-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="data.bin"`, rec.Header().Get(HeaderContentDisposition))
+	assert.Equal(t, "nosniff", rec.Header().Get(HeaderContentTypeOptions))
+}
+
+func TestCheckMIMEType(t *testing.T) {
+	assert.NoError(t, CheckMIMEType("image/png", "image/*"))
+	assert.NoError(t, CheckMIMEType("text/plain; charset=utf-8", "application/json", "text/plain"))
+	assert.ErrorIs(t, CheckMIMEType("text/html", "image/*", "text/plain"), ErrMIMEType)
+	assert.ErrorIs(t, CheckMIMEType("", "text/plain"), ErrMIMEType)
+}
+
+func TestSniffMIMEType(t *testing.T) {
+	content := "<html><body>hi</body></html>"
+	detected, r, err := SniffMIMEType(strings.NewReader(content), "text/plain")
+	assert.ErrorIs(t, err, ErrMIMEType)
+	assert.Equal(t, "text/html; charset=utf-8", detected)
+
+	detected, r, err = SniffMIMEType(strings.NewReader(content), "text/html")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data), "Sniffed bytes should be replayed")
+}
+
+func TestRestrictRequestTypes(t *testing.T) {
+	handler := RestrictRequestTypes("application/json")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("<xml/>"))
+	req.Header.Set("Content-Type", "application/xml")
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -3,6 +3,7 @@ package httpsec
 import (
 	"errors"
 	"fmt"
+	"github.com/saylorsolutions/x/structures/set"
 	"net/http"
 	"net/textproto"
 	"net/url"
@@ -30,9 +31,9 @@ var (
 )
 
 type CORSPolicy struct {
-	allowedMethods   stringSet
-	allowedHeaders   stringSet
-	allowedOrigins   stringSet
+	allowedMethods   set.Set[string]
+	allowedHeaders   set.Set[string]
+	allowedOrigins   set.Set[string]
 	allowCredentials bool
 	maxAge           time.Duration
 	err              error
@@ -40,9 +41,9 @@ type CORSPolicy struct {
 
 func NewPolicy() *CORSPolicy {
 	return &CORSPolicy{
-		allowedMethods: stringSet{},
-		allowedHeaders: stringSet{},
-		allowedOrigins: stringSet{},
+		allowedMethods: set.New[string](),
+		allowedHeaders: set.New[string](),
+		allowedOrigins: set.New[string](),
 		maxAge:         86400 * time.Second, // Default to 24 hours.
 	}
 }
@@ -199,31 +200,31 @@ func (p *CORSPolicy) AllowMethods(methods ...string) *CORSPolicy {
 
 // AllowGet allows the GET method for this policy.
 func (p *CORSPolicy) AllowGet() *CORSPolicy {
-	p.allowedMethods.add("GET")
+	p.allowedMethods.Add(http.MethodGet)
 	return p
 }
 
 // AllowPost allows the POST method for this policy.
 func (p *CORSPolicy) AllowPost() *CORSPolicy {
-	p.allowedMethods.add("POST")
+	p.allowedMethods.Add(http.MethodPost)
 	return p
 }
 
 // AllowPut allows the PUT method for this policy.
 func (p *CORSPolicy) AllowPut() *CORSPolicy {
-	p.allowedMethods.add("PUT")
+	p.allowedMethods.Add(http.MethodPut)
 	return p
 }
 
 // AllowPatch allows the PATCH method for this policy.
 func (p *CORSPolicy) AllowPatch() *CORSPolicy {
-	p.allowedMethods.add("PATCH")
+	p.allowedMethods.Add(http.MethodPatch)
 	return p
 }
 
 // AllowDelete allows the DELETE method for this policy.
 func (p *CORSPolicy) AllowDelete() *CORSPolicy {
-	p.allowedMethods.add("DELETE")
+	p.allowedMethods.Add(http.MethodDelete)
 	return p
 }
 
@@ -231,14 +232,14 @@ func (p *CORSPolicy) AllowDelete() *CORSPolicy {
 func (p *CORSPolicy) AllowHeader(headers ...string) *CORSPolicy {
 	for _, header := range headers {
 		header = textproto.CanonicalMIMEHeaderKey(header)
-		p.allowedHeaders.add(header)
+		p.allowedHeaders.Add(header)
 	}
 	return p
 }
 
 // AllowAnyOrigin sets this policy's origin allow list to be *, allowing any origin.
 func (p *CORSPolicy) AllowAnyOrigin() *CORSPolicy {
-	p.allowedOrigins = stringSet{CORSAnyOrigin: true}
+	p.allowedOrigins = set.New(CORSAnyOrigin)
 	return p
 }
 
@@ -259,8 +260,9 @@ func (p *CORSPolicy) AllowOrigin(origins ...string) *CORSPolicy {
 		if port := u.Port(); len(port) > 0 {
 			origin += ":" + port
 		}
-		p.allowedOrigins.remove(CORSAnyOrigin)
-		p.allowedOrigins.add(origin)
+		p.allowedOrigins.Remove(CORSAnyOrigin)
+		// Origins are matched case-insensitively.
+		p.allowedOrigins.Add(strings.ToLower(origin))
 	}
 	return p
 }
@@ -147,6 +147,31 @@ func TestFallbackPolicy(t *testing.T) {
 	})
 }
 
+func TestCORSPolicy_CanonicalOrder(t *testing.T) {
+	const (
+		origin = "https://example.com"
+	)
+	policies, err := NewSecurityPolicies(
+		EnableCORS(
+			FallbackPolicy(NewPolicy().
+				AllowOrigin("HTTPS://Example.com").
+				AllowMethods("delete", "PATCH", "post", "GET").
+				AllowHeader("x-request-id", "Content-Type", "authorization"),
+			),
+		),
+	)
+	assert.NoError(t, err)
+	srv := httptest.NewServer(policies.Middleware(http.NotFoundHandler()))
+	defer srv.Close()
+
+	for i := 0; i < 5; i++ {
+		allowedOrigin, allowedMethods, allowedHeaders, _ := testPreflight(t, srv.URL, origin)
+		assert.Equal(t, origin, allowedOrigin, "Origins should be matched case-insensitively")
+		assert.Equal(t, "GET,POST,PATCH,DELETE", allowedMethods, "Methods should be in canonical order")
+		assert.Equal(t, "Authorization,Content-Type,X-Request-Id", allowedHeaders, "Headers should be sorted")
+	}
+}
+
 func TestValidateCORSPolicy(t *testing.T) {
 	tests := map[string]struct {
 		Origin  string
@@ -1,7 +1,10 @@
 package httpsec
 
 import (
+	"github.com/saylorsolutions/x/structures/set"
+	"maps"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -32,7 +35,7 @@ func (c *corsConfig) grantAllowHeaders(w http.ResponseWriter, r *http.Request) {
 		varyOrigin bool
 	)
 	switch {
-	case policy.allowedOrigins.has(CORSAnyOrigin):
+	case policy.allowedOrigins.Has(CORSAnyOrigin):
 		// All origins are allowed, output policy.
 		if policy.allowCredentials {
 			// Credentials are allowed, just output the given origin.
@@ -42,7 +45,7 @@ func (c *corsConfig) grantAllowHeaders(w http.ResponseWriter, r *http.Request) {
 			// Credentials are not allowed, we can output *
 			respOrigin = CORSAnyOrigin
 		}
-	case policy.allowedOrigins.has(reqOrigin):
+	case policy.allowedOrigins.Has(strings.ToLower(reqOrigin)):
 		// This origin is in the set of allowed origins, output policy.
 		respOrigin = reqOrigin
 		if len(policy.allowedOrigins) > 1 {
@@ -55,9 +58,9 @@ func (c *corsConfig) grantAllowHeaders(w http.ResponseWriter, r *http.Request) {
 	}
 	if r.Method == http.MethodOptions {
 		// Send the other allow headers for preflight.
-		respMethod := strings.Join(policy.allowedMethods.slice(), ",")
+		respMethod := strings.Join(canonicalMethods(policy.allowedMethods), ",")
 		w.Header().Set(HeaderCORSAllowMethods, respMethod)
-		respHeaders := strings.Join(policy.allowedHeaders.slice(), ",")
+		respHeaders := strings.Join(slices.Sorted(maps.Keys(policy.allowedHeaders)), ",")
 		if len(respHeaders) > 0 {
 			// Allowing headers isn't actually required.
 			w.Header().Set(HeaderCORSAllowHeaders, respHeaders)
@@ -73,3 +76,17 @@ func (c *corsConfig) grantAllowHeaders(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set(HeaderCORSAllowCreds, "true")
 	}
 }
+
+// corsMethodOrder is the canonical order of allowed methods in preflight responses.
+var corsMethodOrder = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// canonicalMethods returns the allowed methods in canonical order, so preflight responses are identical across requests.
+func canonicalMethods(methods set.Set[string]) []string {
+	ordered := make([]string, 0, len(methods))
+	for _, method := range corsMethodOrder {
+		if methods.Has(method) {
+			ordered = append(ordered, method)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,250 @@
+package httpsec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/cli"
+	flag "github.com/spf13/pflag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderContentTypeOptions     = "X-Content-Type-Options"
+	HeaderFrameOptions           = "X-Frame-Options"
+	HeaderReferrerPolicy         = "Referrer-Policy"
+	HeaderPermissionsPolicy      = "Permissions-Policy"
+	HeaderCrossOriginOpener      = "Cross-Origin-Opener-Policy"
+	HeaderCrossOriginResource    = "Cross-Origin-Resource-Policy"
+	minRecommendedHSTSMaxAgeSecs = 31536000 // One year
+)
+
+var (
+	ErrInsecureHeaders = errors.New("insecure response headers")
+
+	hstsMaxAgePattern = regexp.MustCompile(`(?i)max-age=(\d+)`)
+)
+
+// FindingLevel indicates how serious a [HeaderFinding] is.
+type FindingLevel int
+
+const (
+	FindingWarning FindingLevel = iota // FindingWarning is for headers that are recommended, but not critical.
+	FindingError                       // FindingError is for headers that are missing or misconfigured in a way that weakens security.
+)
+
+func (l FindingLevel) String() string {
+	switch l {
+	case FindingError:
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+// HeaderRule describes the expectation for a single response header.
+type HeaderRule struct {
+	Header string       // Header is the name of the header to check.
+	Level  FindingLevel // Level is the level of findings reported for this header.
+	Absent bool         // Absent means that the header should not be sent at all, like headers that leak server details.
+	// Check validates the header value when it's present, and returns an error describing the problem if it's misconfigured.
+	// If Check is nil, then the header only needs to be present.
+	Check func(value string) error
+}
+
+// HeaderProfile is a set of [HeaderRule] that a response should satisfy.
+type HeaderProfile []HeaderRule
+
+// HeaderFinding is a problem found with a response header.
+type HeaderFinding struct {
+	Header  string
+	Level   FindingLevel
+	Problem string
+}
+
+func (f HeaderFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Level, f.Header, f.Problem)
+}
+
+// ProfileOWASP checks headers recommended by the OWASP Secure Headers Project.
+//
+// Source: https://owasp.org/www-project-secure-headers/
+var ProfileOWASP = HeaderProfile{
+	{Header: HeaderStrictTransportSecurity, Level: FindingError, Check: checkHSTS},
+	{Header: HeaderContentSecurityPolicy, Level: FindingError, Check: checkCSP},
+	{Header: HeaderContentTypeOptions, Level: FindingError, Check: expectValues("nosniff")},
+	{Header: HeaderFrameOptions, Level: FindingWarning, Check: expectValues("DENY", "SAMEORIGIN")},
+	{Header: HeaderReferrerPolicy, Level: FindingWarning, Check: expectValues(
+		"no-referrer", "same-origin", "strict-origin", "strict-origin-when-cross-origin",
+	)},
+	{Header: HeaderPermissionsPolicy, Level: FindingWarning},
+	{Header: HeaderCrossOriginOpener, Level: FindingWarning, Check: expectValues("same-origin", "same-origin-allow-popups")},
+	{Header: HeaderCrossOriginResource, Level: FindingWarning, Check: expectValues("same-origin", "same-site")},
+	{Header: "Server", Level: FindingWarning, Absent: true},
+	{Header: "X-Powered-By", Level: FindingWarning, Absent: true},
+	{Header: "X-AspNet-Version", Level: FindingWarning, Absent: true},
+}
+
+func expectValues(allowed ...string) func(string) error {
+	return func(value string) error {
+		for _, val := range allowed {
+			if strings.EqualFold(strings.TrimSpace(value), val) {
+				return nil
+			}
+		}
+		return fmt.Errorf("value '%s' should be one of %s", value, strings.Join(allowed, ", "))
+	}
+}
+
+func checkHSTS(value string) error {
+	match := hstsMaxAgePattern.FindStringSubmatch(value)
+	if match == nil {
+		return errors.New("missing max-age directive")
+	}
+	maxAge, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid max-age: %v", err)
+	}
+	if maxAge < minRecommendedHSTSMaxAgeSecs {
+		return fmt.Errorf("max-age %d is less than the recommended %d seconds", maxAge, minRecommendedHSTSMaxAgeSecs)
+	}
+	return nil
+}
+
+func checkCSP(value string) error {
+	var unsafe []string
+	for _, keyword := range []string{"'unsafe-inline'", "'unsafe-eval'"} {
+		if strings.Contains(value, keyword) {
+			unsafe = append(unsafe, keyword)
+		}
+	}
+	if len(unsafe) > 0 {
+		return fmt.Errorf("policy allows %s", strings.Join(unsafe, " and "))
+	}
+	return nil
+}
+
+// CheckHeaders checks the response headers against the [HeaderProfile], and returns any findings.
+func CheckHeaders(header http.Header, profile HeaderProfile) []HeaderFinding {
+	var findings []HeaderFinding
+	for _, rule := range profile {
+		values := header.Values(rule.Header)
+		switch {
+		case rule.Absent:
+			if len(values) > 0 {
+				findings = append(findings, HeaderFinding{Header: rule.Header, Level: rule.Level, Problem: "should not be sent"})
+			}
+		case len(values) == 0:
+			findings = append(findings, HeaderFinding{Header: rule.Header, Level: rule.Level, Problem: "missing"})
+		case rule.Check != nil:
+			for _, val := range values {
+				if err := rule.Check(val); err != nil {
+					findings = append(findings, HeaderFinding{Header: rule.Header, Level: rule.Level, Problem: err.Error()})
+					break
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// CheckHandler sends the request to the handler, and checks the response headers against the [HeaderProfile].
+// This is useful for testing that security middleware is applied as expected.
+// If req is nil, then a GET request for "/" is used.
+func CheckHandler(handler http.Handler, req *http.Request, profile HeaderProfile) []HeaderFinding {
+	if req == nil {
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return CheckHeaders(rec.Result().Header, profile)
+}
+
+// CheckURL sends a GET request to the URL with the client, and checks the response headers against the [HeaderProfile].
+// If client is nil, then [http.DefaultClient] is used.
+func CheckURL(ctx context.Context, client *http.Client, url string, profile HeaderProfile) ([]HeaderFinding, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+	return CheckHeaders(resp.Header, profile), nil
+}
+
+// TestingT is the subset of [testing.TB] used by [AssertSecureHeaders].
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertSecureHeaders reports a test error for each error level finding from [CheckHandler].
+// Warnings are ignored, since they're recommendations.
+// Returns true if there were no error level findings.
+func AssertSecureHeaders(t TestingT, handler http.Handler, req *http.Request, profile HeaderProfile) bool {
+	t.Helper()
+	ok := true
+	for _, finding := range CheckHandler(handler, req, profile) {
+		if finding.Level == FindingError {
+			t.Errorf("%s", finding)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AddHeaderCheckCommand adds a "check-headers" command to the [cli.CommandSet] that checks a running server's response headers against [ProfileOWASP].
+// The command returns an error wrapping [ErrInsecureHeaders] if there are any error level findings, or warnings when --strict is given.
+func AddHeaderCheckCommand(set *cli.CommandSet) *cli.Command {
+	var (
+		strict  bool
+		timeout time.Duration
+	)
+	cmd := set.AddCommand("check-headers", "Checks a URL's response headers for security best practices")
+	cmd.Usage("check-headers [--strict] [--timeout=DURATION] URL")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fails if there are warnings, not just errors")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "Timeout for the request")
+	cmd.Does(func(flags *flag.FlagSet, p *cli.Printer) error {
+		var url string
+		if err := cli.MapArgs(flags.Args(), 1, &url); err != nil {
+			return cli.NewUsageError("a URL is required")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		findings, err := CheckURL(ctx, nil, url, ProfileOWASP)
+		if err != nil {
+			return err
+		}
+		if len(findings) == 0 {
+			p.Success("No issues found")
+			return nil
+		}
+		rows := make([][]string, len(findings))
+		for i, finding := range findings {
+			rows[i] = []string{finding.Level.String(), finding.Header, finding.Problem}
+		}
+		p.Table([]string{"LEVEL", "HEADER", "PROBLEM"}, rows)
+		failed := slices.ContainsFunc(findings, func(finding HeaderFinding) bool {
+			return strict || finding.Level == FindingError
+		})
+		if failed {
+			return fmt.Errorf("%w: %d issue(s) found", ErrInsecureHeaders, len(findings))
+		}
+		return nil
+	})
+	return cmd
+}
@@ -0,0 +1,87 @@
+package httpsec
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/saylorsolutions/x/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func secureTestHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set(HeaderStrictTransportSecurity, "max-age=63072000; includeSubDomains")
+		h.Set(HeaderContentSecurityPolicy, "default-src 'self'")
+		h.Set(HeaderContentTypeOptions, "nosniff")
+		h.Set(HeaderFrameOptions, "DENY")
+		h.Set(HeaderReferrerPolicy, "no-referrer")
+		h.Set(HeaderPermissionsPolicy, "geolocation=()")
+		h.Set(HeaderCrossOriginOpener, "same-origin")
+		h.Set(HeaderCrossOriginResource, "same-origin")
+		_, _ = w.Write([]byte("ok"))
+	})
+}
+
+func TestCheckHeaders(t *testing.T) {
+	assert.Empty(t, CheckHandler(secureTestHandler(), nil, ProfileOWASP))
+	AssertSecureHeaders(t, secureTestHandler(), nil, ProfileOWASP)
+
+	header := http.Header{}
+	header.Set(HeaderStrictTransportSecurity, "max-age=60")
+	header.Set(HeaderContentSecurityPolicy, "script-src 'self' 'unsafe-inline'")
+	header.Set(HeaderFrameOptions, "ALLOW-FROM https://example.com")
+	header.Set("X-Powered-By", "PHP")
+	findings := map[string]HeaderFinding{}
+	for _, finding := range CheckHeaders(header, ProfileOWASP) {
+		findings[finding.Header] = finding
+	}
+	assert.Contains(t, findings[HeaderStrictTransportSecurity].Problem, "max-age 60")
+	assert.Contains(t, findings[HeaderContentSecurityPolicy].Problem, "'unsafe-inline'")
+	assert.Equal(t, "missing", findings[HeaderContentTypeOptions].Problem)
+	assert.Equal(t, FindingError, findings[HeaderContentTypeOptions].Level)
+	assert.Equal(t, FindingWarning, findings[HeaderFrameOptions].Level)
+	assert.Equal(t, "should not be sent", findings["X-Powered-By"].Problem)
+	_, hasServer := findings["Server"]
+	assert.False(t, hasServer)
+}
+
+type recordingT struct {
+	errs []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestAssertSecureHeaders(t *testing.T) {
+	var rt recordingT
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	assert.False(t, AssertSecureHeaders(&rt, handler, nil, ProfileOWASP))
+	assert.Len(t, rt.errs, 3, "Only error level findings should be reported")
+}
+
+func TestAddHeaderCheckCommand(t *testing.T) {
+	srv := httptest.NewServer(secureTestHandler())
+	defer srv.Close()
+	var buf bytes.Buffer
+	set := cli.NewCommandSet("app")
+	set.Printer().Redirect(&buf)
+	AddHeaderCheckCommand(set)
+	require.NoError(t, set.Exec([]string{"check-headers", srv.URL}))
+	assert.Contains(t, buf.String(), "No issues found")
+
+	insecure := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderStrictTransportSecurity, fmt.Sprintf("max-age=%d", int(time.Hour.Seconds())))
+	}))
+	defer insecure.Close()
+	buf.Reset()
+	assert.ErrorIs(t, set.Exec([]string{"check-headers", insecure.URL}), ErrInsecureHeaders)
+	assert.Contains(t, buf.String(), HeaderContentTypeOptions)
+}
@@ -0,0 +1,209 @@
+package httpsec
+
+import (
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/httpx"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrRoutePolicy = errors.New("route policy error")
+)
+
+// RoutePolicies composes [SecurityPolicies] for different routes, so each part of an application can have the policies that make sense for it.
+// For example, an API may not need a Content-Security-Policy, while it's essential for pages rendered in the browser.
+//
+// Route patterns are formatted as "[METHOD ]/path", similar to [http.ServeMux].
+// A path ending in "/" matches all paths under it, and any other path must match exactly.
+// A pattern without a method matches requests with any method.
+//
+// A route inherits the options of the base policies and every less specific route that matches everything it matches, and its own options are applied last.
+// Since options are applied in order, a route's options will replace headers set by inherited options, but middleware like CORS will be stacked.
+// Use [RoutePolicies.Override] to start from the base policies instead of inheriting from parent routes,
+// and [RoutePolicies.Isolate] to ignore all inherited options.
+//
+// Each request is handled with the policies of the most specific matching route.
+// A longer path is more specific, and a pattern with a method is more specific than one without.
+// Requests that don't match any route are handled with the base policies.
+type RoutePolicies struct {
+	base   []SecurityOption
+	routes []*routePolicy
+	errs   []error
+}
+
+type inheritMode int
+
+const (
+	inheritAll inheritMode = iota
+	inheritBase
+	inheritNone
+)
+
+type routePolicy struct {
+	pattern string
+	method  string
+	path    string
+	inherit inheritMode
+	opts    []SecurityOption
+}
+
+// NewRoutePolicies creates a [RoutePolicies] with the given base options, which apply to all routes unless a route is isolated.
+func NewRoutePolicies(base ...SecurityOption) *RoutePolicies {
+	return &RoutePolicies{
+		base: base,
+	}
+}
+
+// Route attaches options to the route pattern, inheriting options from the base policies and parent routes.
+func (p *RoutePolicies) Route(pattern string, opts ...SecurityOption) *RoutePolicies {
+	p.addRoute(pattern, inheritAll, opts)
+	return p
+}
+
+// Override attaches options to the route pattern, inheriting only the base policies.
+// Routes under this one will inherit from it as normal.
+func (p *RoutePolicies) Override(pattern string, opts ...SecurityOption) *RoutePolicies {
+	p.addRoute(pattern, inheritBase, opts)
+	return p
+}
+
+// Isolate attaches options to the route pattern without inheriting any other options.
+// Routes under this one will inherit from it as normal.
+func (p *RoutePolicies) Isolate(pattern string, opts ...SecurityOption) *RoutePolicies {
+	p.addRoute(pattern, inheritNone, opts)
+	return p
+}
+
+func (p *RoutePolicies) addRoute(pattern string, inherit inheritMode, opts []SecurityOption) {
+	route, err := parseRoutePattern(pattern)
+	if err != nil {
+		p.errs = append(p.errs, err)
+		return
+	}
+	for _, existing := range p.routes {
+		if existing.method == route.method && existing.path == route.path {
+			p.errs = append(p.errs, fmt.Errorf("duplicate route pattern '%s'", pattern))
+			return
+		}
+	}
+	route.inherit = inherit
+	route.opts = opts
+	p.routes = append(p.routes, route)
+}
+
+func parseRoutePattern(pattern string) (*routePolicy, error) {
+	pattern = strings.TrimSpace(pattern)
+	route := &routePolicy{pattern: pattern, path: pattern}
+	if method, path, ok := strings.Cut(pattern, " "); ok {
+		route.method = strings.ToUpper(method)
+		route.path = strings.TrimSpace(path)
+	}
+	if !strings.HasPrefix(route.path, "/") {
+		return nil, fmt.Errorf("invalid route pattern '%s', path must start with '/'", pattern)
+	}
+	return route, nil
+}
+
+// matches returns true if the route matches the request method and path.
+func (r *routePolicy) matches(method, path string) bool {
+	if len(r.method) > 0 && r.method != method {
+		return false
+	}
+	if strings.HasSuffix(r.path, "/") {
+		return strings.HasPrefix(path, r.path)
+	}
+	return r.path == path
+}
+
+// contains returns true if every request matched by other is also matched by this route.
+func (r *routePolicy) contains(other *routePolicy) bool {
+	if r == other {
+		return false
+	}
+	if len(r.method) > 0 && r.method != other.method {
+		return false
+	}
+	if strings.HasSuffix(r.path, "/") {
+		return strings.HasPrefix(other.path, r.path)
+	}
+	return r.path == other.path
+}
+
+// compareSpecificity orders routes from least to most specific.
+func compareSpecificity(a, b *routePolicy) int {
+	if diff := len(a.path) - len(b.path); diff != 0 {
+		return diff
+	}
+	return len(a.method) - len(b.method)
+}
+
+// options returns the full set of options for the route, according to its inheritance rules.
+// Containment is transitive, so inheriting from the nearest parent also inherits from its parents.
+func (p *RoutePolicies) options(route *routePolicy) []SecurityOption {
+	var opts []SecurityOption
+	switch route.inherit {
+	case inheritNone:
+	case inheritBase:
+		opts = append(opts, p.base...)
+	default:
+		var nearest *routePolicy
+		for _, parent := range p.routes {
+			if parent.contains(route) && (nearest == nil || compareSpecificity(parent, nearest) > 0) {
+				nearest = parent
+			}
+		}
+		if nearest == nil {
+			opts = append(opts, p.base...)
+		} else {
+			opts = append(opts, p.options(nearest)...)
+		}
+	}
+	return append(opts, route.opts...)
+}
+
+// Middleware builds the [SecurityPolicies] for each route, and returns middleware that applies the most specific route's policies to each request.
+// An error wrapping [ErrRoutePolicy] is returned if a route pattern is invalid, or if any route's options fail to apply.
+func (p *RoutePolicies) Middleware() (httpx.Middleware, error) {
+	if len(p.errs) > 0 {
+		return nil, fmt.Errorf("%w: %w", ErrRoutePolicy, errors.Join(p.errs...))
+	}
+	base, err := NewSecurityPolicies(p.base...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: base policies: %w", ErrRoutePolicy, err)
+	}
+	routes := slices.Clone(p.routes)
+	// Most specific first, so the first match wins.
+	slices.SortStableFunc(routes, func(a, b *routePolicy) int {
+		return compareSpecificity(b, a)
+	})
+	policies := make([]*SecurityPolicies, len(routes))
+	for i, route := range routes {
+		sec, err := NewSecurityPolicies(p.options(route)...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: route '%s': %w", ErrRoutePolicy, route.pattern, err)
+		}
+		policies[i] = sec
+	}
+	return func(next http.Handler) http.Handler {
+		if next == nil {
+			panic("nil handler")
+		}
+		fallback := base.Middleware(next)
+		handlers := make([]http.Handler, len(policies))
+		for i, sec := range policies {
+			handlers[i] = sec.Middleware(next)
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i, route := range routes {
+				if route.matches(r.Method, r.URL.Path) {
+					handlers[i].ServeHTTP(w, r)
+					return
+				}
+			}
+			fallback.ServeHTTP(w, r)
+		})
+	}, nil
+}
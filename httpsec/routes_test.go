@@ -0,0 +1,79 @@
+package httpsec
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRoutePolicies_Inheritance(t *testing.T) {
+	mw, err := NewRoutePolicies(
+		EnableNoSniff(),
+		EnableStrictTransportSecurity(time.Hour, false),
+	).
+		Route("/api/", EnableStrictTransportSecurity(2*time.Hour, false)).
+		Route("POST /api/upload", EnableContentSecurityPolicy()).
+		Override("/public/", EnableStrictTransportSecurity(3*time.Hour, false)).
+		Route("/public/assets/", EnableContentSecurityPolicy()).
+		Isolate("/health").
+		Middleware()
+	require.NoError(t, err)
+
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	get := func(method, path string) http.Header {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec.Header()
+	}
+
+	t.Run("Unmatched uses base", func(t *testing.T) {
+		hdr := get(http.MethodGet, "/")
+		assert.Equal(t, "nosniff", hdr.Get(HeaderContentTypeOptions))
+		assert.Equal(t, "max-age=3600", hdr.Get(HeaderStrictTransportSecurity))
+	})
+	t.Run("Route overrides header", func(t *testing.T) {
+		hdr := get(http.MethodGet, "/api/users")
+		assert.Equal(t, "nosniff", hdr.Get(HeaderContentTypeOptions))
+		assert.Equal(t, "max-age=7200", hdr.Get(HeaderStrictTransportSecurity))
+		assert.Empty(t, hdr.Get(HeaderContentSecurityPolicy))
+	})
+	t.Run("Method specific route inherits parent", func(t *testing.T) {
+		hdr := get(http.MethodPost, "/api/upload")
+		assert.Equal(t, "max-age=7200", hdr.Get(HeaderStrictTransportSecurity))
+		assert.NotEmpty(t, hdr.Get(HeaderContentSecurityPolicy))
+
+		hdr = get(http.MethodGet, "/api/upload")
+		assert.Empty(t, hdr.Get(HeaderContentSecurityPolicy))
+	})
+	t.Run("Override and children", func(t *testing.T) {
+		hdr := get(http.MethodGet, "/public/assets/app.js")
+		assert.Equal(t, "nosniff", hdr.Get(HeaderContentTypeOptions))
+		assert.Equal(t, "max-age=10800", hdr.Get(HeaderStrictTransportSecurity))
+		assert.NotEmpty(t, hdr.Get(HeaderContentSecurityPolicy))
+	})
+	t.Run("Isolated route", func(t *testing.T) {
+		hdr := get(http.MethodGet, "/health")
+		assert.Empty(t, hdr.Get(HeaderContentTypeOptions))
+		assert.Empty(t, hdr.Get(HeaderStrictTransportSecurity))
+
+		hdr = get(http.MethodGet, "/health/deep")
+		assert.Equal(t, "nosniff", hdr.Get(HeaderContentTypeOptions), "Exact routes don't match sub-paths")
+	})
+}
+
+func TestRoutePolicies_Errors(t *testing.T) {
+	_, err := NewRoutePolicies().Route("api").Middleware()
+	assert.ErrorIs(t, err, ErrRoutePolicy)
+
+	_, err = NewRoutePolicies().Route("/api/").Override("/api/").Middleware()
+	assert.ErrorIs(t, err, ErrRoutePolicy)
+
+	_, err = NewRoutePolicies().Route("/api/", EnableCORS(FallbackPolicy(NewPolicy()))).Middleware()
+	assert.ErrorIs(t, err, ErrRoutePolicy)
+	assert.ErrorIs(t, err, ErrCORSPolicy)
+}
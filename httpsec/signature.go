@@ -0,0 +1,214 @@
+package httpsec
+
+import (
+	"bytes"
+	"container/heap"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/httpx"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSignatureMaxSkew  = 5 * time.Minute
+	defaultSignatureMaxBytes = 10 << 20
+)
+
+var (
+	ErrSignaturePolicy = errors.New("signature policy error")
+)
+
+// NonceCache tracks nonces that have been used by signed requests, so replayed requests can be rejected.
+type NonceCache interface {
+	// Seen records the nonce until the expiry time, and returns true if it was already recorded.
+	Seen(nonce string, expiry time.Time) bool
+}
+
+type memoryNonceCache struct {
+	mux    sync.Mutex
+	nonces map[string]time.Time
+	expiry nonceHeap // expiry orders recorded nonces by expiry, so pruning only visits expired nonces.
+	now    func() time.Time
+}
+
+// NewMemoryNonceCache creates an in-memory [NonceCache].
+// Expired nonces are pruned as new nonces are recorded, in time proportional to the number of expired nonces.
+// This is only suitable for a single server instance, a shared store should be used if requests are load balanced.
+func NewMemoryNonceCache() NonceCache {
+	return &memoryNonceCache{
+		nonces: map[string]time.Time{},
+		now:    time.Now,
+	}
+}
+
+func (c *memoryNonceCache) Seen(nonce string, expiry time.Time) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	now := c.now()
+	if exp, ok := c.nonces[nonce]; ok && exp.After(now) {
+		return true
+	}
+	for len(c.expiry) > 0 && !c.expiry[0].expiry.After(now) {
+		expired := heap.Pop(&c.expiry).(nonceExpiry)
+		// The nonce may have been recorded again after this entry expired.
+		if exp, ok := c.nonces[expired.nonce]; ok && !exp.After(now) {
+			delete(c.nonces, expired.nonce)
+		}
+	}
+	c.nonces[nonce] = expiry
+	heap.Push(&c.expiry, nonceExpiry{nonce: nonce, expiry: expiry})
+	return false
+}
+
+type nonceExpiry struct {
+	nonce  string
+	expiry time.Time
+}
+
+// nonceHeap is a [heap.Interface] with the earliest expiry first.
+type nonceHeap []nonceExpiry
+
+func (h nonceHeap) Len() int           { return len(h) }
+func (h nonceHeap) Less(i, j int) bool { return h[i].expiry.Before(h[j].expiry) }
+func (h nonceHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nonceHeap) Push(x any)        { *h = append(*h, x.(nonceExpiry)) }
+
+func (h *nonceHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+type signatureConfig struct {
+	maxSkew  time.Duration
+	maxBytes int64
+	nonces   NonceCache
+	now      func() time.Time
+	errs     []error
+}
+
+type SignatureOption func(c *signatureConfig)
+
+// SignatureMaxSkew sets the maximum difference between the signature timestamp and the server's clock.
+// Defaults to 5 minutes.
+func SignatureMaxSkew(skew time.Duration) SignatureOption {
+	return func(c *signatureConfig) {
+		if skew <= 0 {
+			c.errs = append(c.errs, errors.New("max skew <= 0"))
+			return
+		}
+		c.maxSkew = skew
+	}
+}
+
+// SignatureMaxBytes sets the maximum request body size that will be read for verification.
+// Defaults to 10MiB.
+func SignatureMaxBytes(maxBytes int64) SignatureOption {
+	return func(c *signatureConfig) {
+		if maxBytes <= 0 {
+			c.errs = append(c.errs, errors.New("max bytes <= 0"))
+			return
+		}
+		c.maxBytes = maxBytes
+	}
+}
+
+// SignatureNonceCache sets the [NonceCache] used to detect replayed requests.
+// Defaults to [NewMemoryNonceCache].
+func SignatureNonceCache(cache NonceCache) SignatureOption {
+	return func(c *signatureConfig) {
+		if cache == nil {
+			c.errs = append(c.errs, errors.New("nil nonce cache"))
+			return
+		}
+		c.nonces = cache
+	}
+}
+
+// SignatureMiddleware creates a [httpx.Middleware] that verifies requests signed with [httpx.Signer.SignRequest].
+// Requests are rejected with a 401 status if the signature is missing or invalid, the timestamp is outside the allowed skew, or the nonce has been used before.
+// A body larger than the configured max is rejected with a 413 status.
+//
+// The signature covers the request URI as the client sent it, so this won't work behind a proxy that rewrites paths.
+func SignatureMiddleware(signer *httpx.Signer, options ...SignatureOption) (httpx.Middleware, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("%w: nil signer", ErrSignaturePolicy)
+	}
+	conf := &signatureConfig{
+		maxSkew:  defaultSignatureMaxSkew,
+		maxBytes: defaultSignatureMaxBytes,
+		now:      time.Now,
+	}
+	for _, opt := range options {
+		opt(conf)
+	}
+	if len(conf.errs) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSignaturePolicy, errors.Join(conf.errs...))
+	}
+	if conf.nonces == nil {
+		conf.nonces = NewMemoryNonceCache()
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status, ok := conf.verify(signer, r)
+			if !ok {
+				http.Error(w, http.StatusText(status), status)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+func (c *signatureConfig) verify(signer *httpx.Signer, r *http.Request) (int, bool) {
+	signature := r.Header.Get(httpx.HeaderSignature)
+	nonce := r.Header.Get(httpx.HeaderSignatureNonce)
+	timestamp, err := strconv.ParseInt(r.Header.Get(httpx.HeaderSignatureTimestamp), 10, 64)
+	if len(signature) == 0 || len(nonce) == 0 || err != nil {
+		return http.StatusUnauthorized, false
+	}
+	now := c.now()
+	signedAt := time.Unix(timestamp, 0)
+	if signedAt.Before(now.Add(-c.maxSkew)) || signedAt.After(now.Add(c.maxSkew)) {
+		return http.StatusUnauthorized, false
+	}
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(io.LimitReader(r.Body, c.maxBytes+1))
+		_ = r.Body.Close()
+		if err != nil {
+			return http.StatusBadRequest, false
+		}
+		if int64(len(body)) > c.maxBytes {
+			return http.StatusRequestEntityTooLarge, false
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if !signer.Verify(r.Method, r.URL.RequestURI(), timestamp, nonce, body, signature) {
+		return http.StatusUnauthorized, false
+	}
+	// Only record the nonce for valid signatures, so an attacker can't burn nonces.
+	// It must be kept for as long as the timestamp could still be accepted.
+	if c.nonces.Seen(nonce, signedAt.Add(c.maxSkew)) {
+		return http.StatusUnauthorized, false
+	}
+	return 0, true
+}
+
+// RequireSignature adds request signature verification to the [SecurityPolicies].
+// See [SignatureMiddleware] for details.
+func RequireSignature(signer *httpx.Signer, options ...SignatureOption) SecurityOption {
+	mw, err := SignatureMiddleware(signer, options...)
+	if err != nil {
+		return configError(err)
+	}
+	return func(sec *SecurityPolicies) error {
+		sec.mw = append(sec.mw, mw)
+		return nil
+	}
+}
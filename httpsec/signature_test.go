@@ -0,0 +1,122 @@
+package httpsec
+
+import (
+	"github.com/saylorsolutions/x/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequireSignature(t *testing.T) {
+	signer := httpx.NewSigner([]byte("secret"))
+	sec, err := NewSecurityPolicies(RequireSignature(signer))
+	require.NoError(t, err)
+	var received string
+	srv := httptest.NewServer(sec.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+	})))
+	defer srv.Close()
+
+	_, status, err := httpx.PostRequest(srv.URL + "/hook").StringBody("payload").SignHMAC(signer).Send()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "payload", received, "Body should be available to the handler after verification")
+
+	_, status, err = httpx.PostRequest(srv.URL + "/hook").StringBody("payload").Send()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status, "Unsigned requests should be rejected")
+
+	_, status, err = httpx.PostRequest(srv.URL + "/hook").StringBody("payload").SignHMAC(httpx.NewSigner([]byte("wrong"))).Send()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status, "Requests signed with the wrong key should be rejected")
+}
+
+func TestSignatureMiddleware_Replay(t *testing.T) {
+	signer := httpx.NewSigner([]byte("secret"))
+	mw, err := SignatureMiddleware(signer)
+	require.NoError(t, err)
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("payload"))
+	require.NoError(t, signer.SignRequest(req))
+	headers := req.Header.Clone()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	replay := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("payload"))
+	replay.Header = headers
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, replay)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestSignatureMiddleware_Skew(t *testing.T) {
+	signer := httpx.NewSigner([]byte("secret"))
+	mw, err := SignatureMiddleware(signer, SignatureMaxSkew(time.Minute))
+	require.NoError(t, err)
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	ts := time.Now().Add(-2 * time.Minute).Unix()
+	req := httptest.NewRequest(http.MethodGet, "/hook", nil)
+	req.Header.Set(httpx.HeaderSignatureTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(httpx.HeaderSignatureNonce, "nonce")
+	req.Header.Set(httpx.HeaderSignature, signer.Sign(http.MethodGet, "/hook", ts, "nonce", nil))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestSignatureMiddleware_MaxBytes(t *testing.T) {
+	signer := httpx.NewSigner([]byte("secret"))
+	mw, err := SignatureMiddleware(signer, SignatureMaxBytes(4))
+	require.NoError(t, err)
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("too large"))
+	require.NoError(t, signer.SignRequest(req))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestSignatureMiddleware_Config(t *testing.T) {
+	_, err := SignatureMiddleware(nil)
+	assert.ErrorIs(t, err, ErrSignaturePolicy)
+	_, err = SignatureMiddleware(httpx.NewSigner([]byte("secret")), SignatureMaxSkew(0))
+	assert.ErrorIs(t, err, ErrSignaturePolicy)
+	_, err = NewSecurityPolicies(RequireSignature(httpx.NewSigner([]byte("secret")), SignatureNonceCache(nil)))
+	assert.ErrorIs(t, err, ErrSignaturePolicy)
+}
+
+func TestMemoryNonceCache(t *testing.T) {
+	now := time.Now()
+	cache := NewMemoryNonceCache().(*memoryNonceCache)
+	cache.now = func() time.Time { return now }
+	assert.False(t, cache.Seen("a", now.Add(time.Minute)))
+	assert.True(t, cache.Seen("a", now.Add(time.Minute)))
+
+	now = now.Add(2 * time.Minute)
+	assert.False(t, cache.Seen("b", now.Add(time.Minute)))
+	assert.Len(t, cache.nonces, 1, "Expired nonces should be pruned")
+	assert.False(t, cache.Seen("a", now.Add(time.Minute)), "Expired nonce may be reused")
+	assert.Len(t, cache.expiry, 2, "Expired entries should be removed from the expiry order")
+
+	now = now.Add(30 * time.Second)
+	assert.False(t, cache.Seen("c", now.Add(time.Hour)))
+	assert.False(t, cache.Seen("d", now.Add(time.Second)))
+	now = now.Add(45 * time.Second)
+	assert.False(t, cache.Seen("e", now.Add(time.Minute)))
+	assert.True(t, cache.Seen("c", now.Add(time.Hour)), "Nonces expiring later should not be pruned")
+	assert.ElementsMatch(t, []string{"c", "e"}, slices.Collect(maps.Keys(cache.nonces)))
+}
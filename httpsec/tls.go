@@ -0,0 +1,326 @@
+package httpsec
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/httpx"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	devCertValidity = 24 * time.Hour
+)
+
+var (
+	ErrTLSPolicy = errors.New("TLS policy error")
+)
+
+// DevCA is a throwaway certificate authority for development and tests.
+// It can issue server and client certificates, which makes it easy to exercise mutual TLS locally.
+//
+// Certificates are only valid for 24 hours, and keys are never written anywhere, so this must not be used in production.
+type DevCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+// NewDevCA generates a new [DevCA] with the given common name.
+func NewDevCA(commonName string) (*DevCA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template, err := devCertTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &DevCA{cert: cert, key: key}, nil
+}
+
+// Certificate returns the CA's certificate.
+func (ca *DevCA) Certificate() *x509.Certificate {
+	return ca.cert
+}
+
+// CertPool returns a new [x509.CertPool] containing the CA's certificate.
+// This can be used as the RootCAs of a client, or the ClientCAs of a server.
+func (ca *DevCA) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// CertPEM returns the CA's certificate in PEM format, so it can be added to another trust store.
+func (ca *DevCA) CertPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+// Issue creates a certificate signed by the CA that is valid for both server and client authentication.
+// Each host may be a DNS name, IP address, URI, or email address, and is added to the appropriate subject alternative name.
+func (ca *DevCA) Issue(commonName string, hosts ...string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template, err := devCertTemplate(commonName, hosts...)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return devTLSCertificate(der, key)
+}
+
+// SelfSignedCert generates a throwaway self-signed certificate for the given hosts, for local development servers.
+// If no hosts are given, then the certificate is valid for "localhost" and the loopback addresses.
+func SelfSignedCert(hosts ...string) (tls.Certificate, error) {
+	if len(hosts) == 0 {
+		hosts = []string{"localhost", "127.0.0.1", "::1"}
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template, err := devCertTemplate(hosts[0], hosts...)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return devTLSCertificate(der, key)
+}
+
+func devCertTemplate(commonName string, hosts ...string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		// Allow for some clock skew between machines.
+		NotBefore:   now.Add(-5 * time.Minute),
+		NotAfter:    now.Add(devCertValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+			continue
+		}
+		if u, err := url.Parse(host); err == nil && len(u.Scheme) > 0 && len(u.Host) > 0 {
+			template.URIs = append(template.URIs, u)
+			continue
+		}
+		if strings.Contains(host, "@") {
+			template.EmailAddresses = append(template.EmailAddresses, host)
+			continue
+		}
+		template.DNSNames = append(template.DNSNames, host)
+	}
+	return template, nil
+}
+
+func devTLSCertificate(der []byte, key crypto.Signer) (tls.Certificate, error) {
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+type mtlsConfig struct {
+	optional   bool
+	revocation func(cert *x509.Certificate) error
+	stapler    func(cert *tls.Certificate) ([]byte, error)
+	errs       []error
+}
+
+type TLSOption func(c *mtlsConfig)
+
+// TLSClientCertOptional allows clients to connect without a certificate, but a certificate that is presented must still be valid.
+// Use [ClientIdentityMiddleware] with required set to false, and check [ClientIdentityFrom] to handle both cases.
+func TLSClientCertOptional() TLSOption {
+	return func(c *mtlsConfig) {
+		c.optional = true
+	}
+}
+
+// TLSRevocationCheck sets a function that is called with the verified client certificate during the handshake.
+// Returning an error rejects the connection, which allows checking a CRL or OCSP responder.
+func TLSRevocationCheck(check func(cert *x509.Certificate) error) TLSOption {
+	return func(c *mtlsConfig) {
+		if check == nil {
+			c.errs = append(c.errs, errors.New("nil revocation check"))
+			return
+		}
+		c.revocation = check
+	}
+}
+
+// TLSOCSPStapler sets a function that provides the OCSP response stapled to the server certificate in each handshake.
+// This is called for every handshake, so the function should cache responses until they're close to expiring.
+// Returning an error omits the staple, rather than failing the handshake.
+func TLSOCSPStapler(stapler func(cert *tls.Certificate) ([]byte, error)) TLSOption {
+	return func(c *mtlsConfig) {
+		if stapler == nil {
+			c.errs = append(c.errs, errors.New("nil OCSP stapler"))
+			return
+		}
+		c.stapler = stapler
+	}
+}
+
+// MutualTLSConfig creates a server [tls.Config] that requires clients to present a certificate signed by one of the given CAs.
+// TLS 1.2 is the minimum version allowed.
+func MutualTLSConfig(cert tls.Certificate, clientCAs *x509.CertPool, options ...TLSOption) (*tls.Config, error) {
+	if clientCAs == nil {
+		return nil, fmt.Errorf("%w: nil client CA pool", ErrTLSPolicy)
+	}
+	if len(cert.Certificate) == 0 {
+		return nil, fmt.Errorf("%w: empty server certificate", ErrTLSPolicy)
+	}
+	conf := new(mtlsConfig)
+	for _, opt := range options {
+		opt(conf)
+	}
+	if len(conf.errs) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTLSPolicy, errors.Join(conf.errs...))
+	}
+	tlsConf := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	if conf.optional {
+		tlsConf.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	if conf.revocation != nil {
+		check := conf.revocation
+		tlsConf.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return nil
+			}
+			if err := check(state.PeerCertificates[0]); err != nil {
+				return fmt.Errorf("client certificate rejected: %w", err)
+			}
+			return nil
+		}
+	}
+	if conf.stapler != nil {
+		stapler := conf.stapler
+		tlsConf.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			stapled := cert
+			if staple, err := stapler(&cert); err == nil {
+				stapled.OCSPStaple = staple
+			}
+			return &stapled, nil
+		}
+		tlsConf.Certificates = nil
+	}
+	return tlsConf, nil
+}
+
+// ClientIdentity describes the verified client certificate of a mutual TLS connection.
+type ClientIdentity struct {
+	Subject        pkix.Name
+	DNSNames       []string
+	EmailAddresses []string
+	URIs           []*url.URL
+	SerialNumber   string
+	Fingerprint    string // Fingerprint is the hex encoded SHA-256 hash of the certificate.
+	Certificate    *x509.Certificate
+}
+
+// CommonName returns the common name of the certificate subject.
+func (id *ClientIdentity) CommonName() string {
+	return id.Subject.CommonName
+}
+
+type clientIdentityKey struct{}
+
+// ClientIdentityFrom returns the [ClientIdentity] set by [ClientIdentityMiddleware], if any.
+func ClientIdentityFrom(ctx context.Context) (*ClientIdentity, bool) {
+	id, ok := ctx.Value(clientIdentityKey{}).(*ClientIdentity)
+	return id, ok
+}
+
+// ClientIdentityMiddleware extracts the verified client certificate from the TLS connection into the request context, available with [ClientIdentityFrom].
+// If required is true, then requests without a verified client certificate are rejected with a 403 status.
+//
+// This relies on the server's [tls.Config] to verify certificates, like one created with [MutualTLSConfig].
+func ClientIdentityMiddleware(required bool) httpx.Middleware {
+	return func(next http.Handler) http.Handler {
+		if next == nil {
+			panic("nil handler")
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.PeerCertificates) == 0 {
+				if required {
+					http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			cert := r.TLS.PeerCertificates[0]
+			sum := sha256.Sum256(cert.Raw)
+			id := &ClientIdentity{
+				Subject:        cert.Subject,
+				DNSNames:       cert.DNSNames,
+				EmailAddresses: cert.EmailAddresses,
+				URIs:           cert.URIs,
+				SerialNumber:   cert.SerialNumber.Text(16),
+				Fingerprint:    hex.EncodeToString(sum[:]),
+				Certificate:    cert,
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIdentityKey{}, id)))
+		})
+	}
+}
+
+// RequireClientCert adds [ClientIdentityMiddleware] to the [SecurityPolicies], rejecting requests without a verified client certificate.
+func RequireClientCert() SecurityOption {
+	return func(sec *SecurityPolicies) error {
+		sec.mw = append(sec.mw, ClientIdentityMiddleware(true))
+		return nil
+	}
+}
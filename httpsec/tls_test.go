@@ -0,0 +1,115 @@
+package httpsec
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testMTLSServer(t *testing.T, ca *DevCA, options ...TLSOption) *httptest.Server {
+	serverCert, err := ca.Issue("server", "127.0.0.1", "localhost")
+	require.NoError(t, err)
+	tlsConf, err := MutualTLSConfig(serverCert, ca.CertPool(), options...)
+	require.NoError(t, err)
+	srv := httptest.NewUnstartedServer(ClientIdentityMiddleware(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := ClientIdentityFrom(r.Context())
+		if !ok {
+			_, _ = io.WriteString(w, "anonymous")
+			return
+		}
+		_, _ = io.WriteString(w, id.CommonName())
+	})))
+	srv.TLS = tlsConf
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func testMTLSClient(ca *DevCA, certs ...tls.Certificate) *http.Client {
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      ca.CertPool(),
+		Certificates: certs,
+	}}}
+}
+
+func TestMutualTLSConfig(t *testing.T) {
+	ca, err := NewDevCA("Test CA")
+	require.NoError(t, err)
+	assert.Contains(t, string(ca.CertPEM()), "BEGIN CERTIFICATE")
+	clientCert, err := ca.Issue("client-1", "client@example.com", "spiffe://example.com/client")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client@example.com"}, clientCert.Leaf.EmailAddresses)
+	assert.Len(t, clientCert.Leaf.URIs, 1)
+
+	srv := testMTLSServer(t, ca)
+	resp, err := testMTLSClient(ca, clientCert).Get(srv.URL)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "client-1", string(body))
+
+	_, err = testMTLSClient(ca).Get(srv.URL)
+	assert.Error(t, err, "Client certificate is required")
+
+	otherCA, err := NewDevCA("Other CA")
+	require.NoError(t, err)
+	untrusted, err := otherCA.Issue("client-2")
+	require.NoError(t, err)
+	_, err = testMTLSClient(ca, untrusted).Get(srv.URL)
+	assert.Error(t, err, "Client certificate must be signed by a trusted CA")
+}
+
+func TestMutualTLSConfig_Options(t *testing.T) {
+	ca, err := NewDevCA("Test CA")
+	require.NoError(t, err)
+	revoked, err := ca.Issue("revoked")
+	require.NoError(t, err)
+	srv := testMTLSServer(t, ca, TLSClientCertOptional(), TLSRevocationCheck(func(cert *x509.Certificate) error {
+		if cert.Subject.CommonName == "revoked" {
+			return errors.New("revoked")
+		}
+		return nil
+	}))
+
+	resp, err := testMTLSClient(ca).Get(srv.URL)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "anonymous", string(body))
+
+	_, err = testMTLSClient(ca, revoked).Get(srv.URL)
+	assert.Error(t, err)
+
+	_, err = MutualTLSConfig(tls.Certificate{}, ca.CertPool())
+	assert.ErrorIs(t, err, ErrTLSPolicy)
+	_, err = MutualTLSConfig(revoked, nil)
+	assert.ErrorIs(t, err, ErrTLSPolicy)
+	_, err = MutualTLSConfig(revoked, ca.CertPool(), TLSRevocationCheck(nil))
+	assert.ErrorIs(t, err, ErrTLSPolicy)
+}
+
+func TestSelfSignedCert(t *testing.T) {
+	cert, err := SelfSignedCert()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", cert.Leaf.Subject.CommonName)
+	assert.Equal(t, []string{"localhost"}, cert.Leaf.DNSNames)
+	assert.Len(t, cert.Leaf.IPAddresses, 2)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert.Leaf)
+	_, err = cert.Leaf.Verify(x509.VerifyOptions{Roots: pool, DNSName: "localhost"})
+	assert.NoError(t, err)
+}
+
+func TestRequireClientCert(t *testing.T) {
+	sec, err := NewSecurityPolicies(RequireClientCert())
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	sec.Middleware(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
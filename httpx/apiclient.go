@@ -0,0 +1,365 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidAPI = errors.New("invalid API definition")
+
+	pathParamPattern = regexp.MustCompile(`\{([^{}]+)}`)
+	contextType      = reflect.TypeFor[context.Context]()
+	errorType        = reflect.TypeFor[error]()
+)
+
+type apiClientConf struct {
+	client  *http.Client
+	headers http.Header
+	signer  *Signer
+}
+
+type APIClientOption func(conf *apiClientConf) error
+
+// OptAPIHTTPClient sets the [http.Client] used to send requests.
+// Defaults to [http.DefaultClient].
+func OptAPIHTTPClient(client *http.Client) APIClientOption {
+	return func(conf *apiClientConf) error {
+		if client == nil {
+			return errors.New("nil client")
+		}
+		conf.client = client
+		return nil
+	}
+}
+
+// OptAPIHeader sets a header on every request, like an API key or User-Agent.
+func OptAPIHeader(header, value string) APIClientOption {
+	return func(conf *apiClientConf) error {
+		conf.headers.Set(header, value)
+		return nil
+	}
+}
+
+// OptAPISigner signs every request with the given [Signer].
+func OptAPISigner(signer *Signer) APIClientOption {
+	return func(conf *apiClientConf) error {
+		if signer == nil {
+			return errors.New("nil signer")
+		}
+		conf.signer = signer
+		return nil
+	}
+}
+
+// BindAPI populates the function fields of the struct pointed to by api, so calling them sends requests to the API at baseURL.
+// This allows an API client to be declared rather than written by hand, while still using [Request] to send requests.
+//
+// Each function field to bind must have an `http` tag with the method and path, with path parameters in braces.
+// Fields without an `http` tag are left unchanged.
+//
+//	type UserAPI struct {
+//		GetUser    func(ctx context.Context, params GetUserParams) (*User, error) `http:"GET /users/{id}"`
+//		ListUsers  func(ctx context.Context) ([]User, error)                      `http:"GET /users"`
+//		DeleteUser func(ctx context.Context, params GetUserParams) error          `http:"DELETE /users/{id}"`
+//	}
+//
+// A bound function must accept a [context.Context], and may accept a params struct (or pointer to one) as the second argument.
+// The params struct's fields are mapped to the request with these tags:
+//
+//   - path:"name" fills the {name} parameter in the path.
+//   - query:"name" adds a query parameter. Slices add one parameter per element, and ",omitempty" skips zero values.
+//   - header:"Name" sets a request header.
+//   - body:"" sends the field as a JSON request body.
+//
+// A bound function must return an error as its last result, and may return a value before it, which is decoded from a JSON response body.
+// A 4xx or 5xx response results in a [StatusError], which wraps [ErrClientError] or [ErrServerError], respectively.
+//
+// An error wrapping [ErrInvalidAPI] is returned if the struct can't be bound, so mistakes are caught before any requests are sent.
+func BindAPI(baseURL string, api any, opts ...APIClientOption) error {
+	conf := apiClientConf{
+		client:  http.DefaultClient,
+		headers: http.Header{},
+	}
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			return err
+		}
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	val := reflect.ValueOf(api)
+	if val.Kind() != reflect.Pointer || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected a non-nil pointer to a struct, got %T", ErrInvalidAPI, api)
+	}
+	val = val.Elem()
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, ok := field.Tag.Lookup("http")
+		if !ok {
+			continue
+		}
+		if !field.IsExported() || field.Type.Kind() != reflect.Func {
+			return fmt.Errorf("%w: field '%s' must be an exported function", ErrInvalidAPI, field.Name)
+		}
+		ep, err := parseEndpoint(base, tag, field.Type)
+		if err != nil {
+			return fmt.Errorf("%w: field '%s': %v", ErrInvalidAPI, field.Name, err)
+		}
+		ep.conf = &conf
+		val.Field(i).Set(reflect.MakeFunc(field.Type, ep.call))
+	}
+	return nil
+}
+
+type endpointParam struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+type apiEndpoint struct {
+	conf      *apiClientConf
+	method    string
+	base      *url.URL
+	path      string
+	hasParams bool
+	paramPtr  bool
+	pathVals  []endpointParam
+	queryVals []endpointParam
+	headers   []endpointParam
+	bodyIndex int
+	result    reflect.Type
+}
+
+func parseEndpoint(base *url.URL, tag string, fnType reflect.Type) (*apiEndpoint, error) {
+	method, path, ok := strings.Cut(strings.TrimSpace(tag), " ")
+	path = strings.TrimSpace(path)
+	if !ok || len(method) == 0 || !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("tag '%s' should be formatted as 'METHOD /path'", tag)
+	}
+	ep := &apiEndpoint{
+		method:    strings.ToUpper(method),
+		base:      base,
+		path:      path,
+		bodyIndex: -1,
+	}
+
+	switch fnType.NumIn() {
+	case 2:
+		paramType := fnType.In(1)
+		if paramType.Kind() == reflect.Pointer {
+			ep.paramPtr = true
+			paramType = paramType.Elem()
+		}
+		if paramType.Kind() != reflect.Struct {
+			return nil, errors.New("params argument must be a struct or pointer to a struct")
+		}
+		ep.hasParams = true
+		if err := ep.parseParams(paramType); err != nil {
+			return nil, err
+		}
+		fallthrough
+	case 1:
+		if fnType.In(0) != contextType {
+			return nil, errors.New("first argument must be a context.Context")
+		}
+	default:
+		return nil, errors.New("function must accept a context.Context and optional params struct")
+	}
+	if fnType.IsVariadic() {
+		return nil, errors.New("function must not be variadic")
+	}
+
+	switch fnType.NumOut() {
+	case 2:
+		ep.result = fnType.Out(0)
+		fallthrough
+	case 1:
+		if fnType.Out(fnType.NumOut()-1) != errorType {
+			return nil, errors.New("last result must be an error")
+		}
+	default:
+		return nil, errors.New("function must return an error and optional result")
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		var found bool
+		for _, param := range ep.pathVals {
+			if param.name == match[1] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no params field for path parameter '%s'", match[1])
+		}
+	}
+	return ep, nil
+}
+
+func (ep *apiEndpoint) parseParams(typ reflect.Type) error {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if name, ok := field.Tag.Lookup("path"); ok {
+			if !strings.Contains(ep.path, "{"+name+"}") {
+				return fmt.Errorf("path parameter '%s' is not in path '%s'", name, ep.path)
+			}
+			ep.pathVals = append(ep.pathVals, endpointParam{index: i, name: name})
+		}
+		if tag, ok := field.Tag.Lookup("query"); ok {
+			name, opt, _ := strings.Cut(tag, ",")
+			if len(name) == 0 {
+				name = field.Name
+			}
+			ep.queryVals = append(ep.queryVals, endpointParam{index: i, name: name, omitEmpty: opt == "omitempty"})
+		}
+		if name, ok := field.Tag.Lookup("header"); ok {
+			if len(name) == 0 {
+				name = field.Name
+			}
+			ep.headers = append(ep.headers, endpointParam{index: i, name: name})
+		}
+		if _, ok := field.Tag.Lookup("body"); ok {
+			if ep.bodyIndex >= 0 {
+				return errors.New("multiple body fields")
+			}
+			ep.bodyIndex = i
+		}
+	}
+	return nil
+}
+
+func (ep *apiEndpoint) call(args []reflect.Value) []reflect.Value {
+	ctx, _ := args[0].Interface().(context.Context)
+	var params reflect.Value
+	if ep.hasParams {
+		params = args[1]
+		if ep.paramPtr {
+			if params.IsNil() {
+				params = reflect.Zero(params.Type().Elem())
+			} else {
+				params = params.Elem()
+			}
+		}
+	}
+	result, err := ep.send(ctx, params)
+	if ep.result == nil {
+		return []reflect.Value{errValue(err)}
+	}
+	if !result.IsValid() {
+		result = reflect.Zero(ep.result)
+	}
+	return []reflect.Value{result, errValue(err)}
+}
+
+func errValue(err error) reflect.Value {
+	if err == nil {
+		return reflect.Zero(errorType)
+	}
+	return reflect.ValueOf(&err).Elem()
+}
+
+func (ep *apiEndpoint) buildURL(params reflect.Value) string {
+	var (
+		path    = ep.path
+		rawPath = ep.path
+		query   = url.Values{}
+	)
+	for _, param := range ep.pathVals {
+		val := fmt.Sprint(params.Field(param.index).Interface())
+		path = strings.ReplaceAll(path, "{"+param.name+"}", val)
+		rawPath = strings.ReplaceAll(rawPath, "{"+param.name+"}", url.PathEscape(val))
+	}
+	for _, param := range ep.queryVals {
+		field := params.Field(param.index)
+		if param.omitEmpty && field.IsZero() {
+			continue
+		}
+		if field.Kind() == reflect.Slice || field.Kind() == reflect.Array {
+			for i := 0; i < field.Len(); i++ {
+				query.Add(param.name, fmt.Sprint(field.Index(i).Interface()))
+			}
+			continue
+		}
+		query.Add(param.name, fmt.Sprint(field.Interface()))
+	}
+	// Both forms of the path are set, so escaped characters like "/" in a param aren't decoded or escaped again.
+	u := *ep.base
+	u.RawPath = strings.TrimSuffix(ep.base.EscapedPath(), "/") + rawPath
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+func (ep *apiEndpoint) send(ctx context.Context, params reflect.Value) (reflect.Value, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !params.IsValid() {
+		params = reflect.New(reflect.TypeFor[struct{}]()).Elem()
+	}
+	r := NewRequest(ep.method, ep.buildURL(params)).WithContext(ctx).WithClient(ep.conf.client)
+	for header, vals := range ep.conf.headers {
+		for _, val := range vals {
+			r.AddHeader(header, val)
+		}
+	}
+	for _, param := range ep.headers {
+		field := params.Field(param.index)
+		if field.IsZero() {
+			continue
+		}
+		r.SetHeader(param.name, fmt.Sprint(field.Interface()))
+	}
+	if ep.bodyIndex >= 0 {
+		r.JSONBody(params.Field(ep.bodyIndex).Interface())
+	}
+	if ep.result != nil {
+		r.SetHeader("Accept", ContentTypeJSON)
+	}
+	if ep.conf.signer != nil {
+		r.SignHMAC(ep.conf.signer)
+	}
+	resp, status, err := r.Send()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	body, err := resp.Body()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	defer func() {
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(body, 64<<10))
+		_ = body.Close()
+	}()
+	if status >= 400 {
+		msg, _ := io.ReadAll(io.LimitReader(body, 4<<10))
+		return reflect.Value{}, NewStatusError(status, "%s %s: %s", ep.method, resp.req.URL.Path, strings.TrimSpace(string(msg)))
+	}
+	if ep.result == nil {
+		return reflect.Value{}, nil
+	}
+	result := reflect.New(ep.result)
+	if err := json.NewDecoder(body).Decode(result.Interface()); err != nil && !errors.Is(err, io.EOF) {
+		return reflect.Value{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Elem(), nil
+}
@@ -0,0 +1,121 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type testUserParams struct {
+	ID    string   `path:"id"`
+	Tags  []string `query:"tag,omitempty"`
+	Trace string   `header:"X-Trace"`
+}
+
+type testCreateParams struct {
+	User testUser `body:""`
+}
+
+type testUserAPI struct {
+	GetUser    func(ctx context.Context, params testUserParams) (*testUser, error)   `http:"GET /users/{id}"`
+	ListUsers  func(ctx context.Context) ([]testUser, error)                         `http:"GET /users"`
+	CreateUser func(ctx context.Context, params *testCreateParams) (testUser, error) `http:"POST /users"`
+	DeleteUser func(ctx context.Context, params testUserParams) error                `http:"DELETE /users/{id}"`
+	Unbound    func()
+}
+
+func TestBindAPI(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "missing" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, []string{"a", "b"}, r.URL.Query()["tag"])
+		assert.Equal(t, "trace-id", r.Header.Get("X-Trace"))
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		_ = json.NewEncoder(w).Encode(testUser{ID: r.PathValue("id"), Name: "bob"})
+	})
+	mux.HandleFunc("GET /api/users", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]testUser{{ID: "1"}, {ID: "2"}})
+	})
+	mux.HandleFunc("POST /api/users", func(w http.ResponseWriter, r *http.Request) {
+		var user testUser
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&user))
+		user.ID = "new"
+		_ = json.NewEncoder(w).Encode(user)
+	})
+	mux.HandleFunc("DELETE /api/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var api testUserAPI
+	require.NoError(t, BindAPI(srv.URL+"/api", &api, OptAPIHeader("X-API-Key", "secret")))
+	assert.Nil(t, api.Unbound, "Untagged fields should not be bound")
+	ctx := context.Background()
+
+	user, err := api.GetUser(ctx, testUserParams{ID: "123", Tags: []string{"a", "b"}, Trace: "trace-id"})
+	require.NoError(t, err)
+	assert.Equal(t, &testUser{ID: "123", Name: "bob"}, user)
+
+	user, err = api.GetUser(ctx, testUserParams{ID: "missing"})
+	assert.ErrorIs(t, err, ErrClientError)
+	assert.Nil(t, user)
+
+	users, err := api.ListUsers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+
+	created, err := api.CreateUser(ctx, &testCreateParams{User: testUser{Name: "alice"}})
+	require.NoError(t, err)
+	assert.Equal(t, testUser{ID: "new", Name: "alice"}, created)
+
+	assert.NoError(t, api.DeleteUser(ctx, testUserParams{ID: "123"}))
+}
+
+func TestBindAPI_Invalid(t *testing.T) {
+	var notPointer testUserAPI
+	assert.ErrorIs(t, BindAPI("http://localhost", notPointer), ErrInvalidAPI)
+
+	var missingPath struct {
+		Get func(ctx context.Context) error `http:"GET /users/{id}"`
+	}
+	assert.ErrorIs(t, BindAPI("http://localhost", &missingPath), ErrInvalidAPI)
+
+	var noError struct {
+		Get func(ctx context.Context) string `http:"GET /users"`
+	}
+	assert.ErrorIs(t, BindAPI("http://localhost", &noError), ErrInvalidAPI)
+
+	var badTag struct {
+		Get func(ctx context.Context) error `http:"/users"`
+	}
+	assert.ErrorIs(t, BindAPI("http://localhost", &badTag), ErrInvalidAPI)
+}
+
+func TestBindAPI_PathEscaping(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/users/a%20b%2Fc%25d%C3%A9", r.URL.EscapedPath())
+		_ = json.NewEncoder(w).Encode(testUser{ID: r.PathValue("id")})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var api testUserAPI
+	require.NoError(t, BindAPI(srv.URL+"/api", &api))
+	user, err := api.GetUser(context.Background(), testUserParams{ID: "a b/c%dé"})
+	require.NoError(t, err)
+	assert.Equal(t, "a b/c%dé", user.ID, "Reserved characters should reach the server unchanged")
+}
@@ -0,0 +1,182 @@
+package httpx
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// minExpansionCheck is the number of decompressed bytes allowed before the expansion ratio is enforced.
+	// Small, highly compressible responses are common, and shouldn't be mistaken for decompression bombs.
+	minExpansionCheck = 1 << 20
+)
+
+var (
+	ErrResponseTooLarge  = errors.New("response body is too large")
+	ErrDecompressionBomb = errors.New("response body expands too much when decompressed")
+)
+
+// MaxResponseBytes limits the size of the response body to n bytes.
+// Reading more than that returns an error wrapping [ErrResponseTooLarge], so a misbehaving server can't exhaust memory with [Response.String] or [Response.Bytes].
+// The limit applies to the decompressed body if [Request.Decompress] is used.
+// A limit <= 0 removes the limit.
+func (r *Request) MaxResponseBytes(n int64) *Request {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.maxResponseBytes = n
+	return r
+}
+
+// Decompress requests a gzip or deflate encoded response, and transparently decompresses it.
+// If the decompressed body grows to more than maxRatio times the compressed bytes read, then reading returns an error wrapping [ErrDecompressionBomb].
+// The ratio is only enforced after the first MiB of decompressed data, so small responses aren't rejected.
+//
+// This should be combined with [Request.MaxResponseBytes] to bound memory use.
+// Without calling this, the standard library transparently decompresses gzip responses without any expansion limit.
+func (r *Request) Decompress(maxRatio int) *Request {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.err != nil {
+		return r
+	}
+	if maxRatio < 1 {
+		r.err = fmt.Errorf("max expansion ratio '%d' is invalid, must be >= 1", maxRatio)
+		return r
+	}
+	r.maxExpansion = maxRatio
+	r.headers.Set("Accept-Encoding", "gzip, deflate")
+	return r
+}
+
+// guardBody wraps the response body to enforce the size and expansion limits.
+func guardBody(resp *http.Response, maxBytes int64, maxExpansion int) error {
+	if maxExpansion > 0 && hasBody(resp) {
+		encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+		if len(encoding) > 0 && encoding != "identity" {
+			compressed := &countingReader{r: resp.Body}
+			var (
+				decoded io.ReadCloser
+				err     error
+			)
+			switch encoding {
+			case "gzip", "x-gzip":
+				decoded, err = gzip.NewReader(compressed)
+			case "deflate":
+				decoded, err = newDeflateReader(compressed)
+			default:
+				return fmt.Errorf("unsupported content encoding '%s'", encoding)
+			}
+			switch {
+			case errors.Is(err, io.EOF):
+				// An empty body, like one with chunked encoding, has nothing to decompress.
+				resp.Header.Del("Content-Encoding")
+				return guardBody(resp, maxBytes, 0)
+			case err != nil:
+				return fmt.Errorf("failed to decompress response: %w", err)
+			}
+			resp.Body = &expansionReader{
+				decoded:    decoded,
+				compressed: compressed,
+				closer:     resp.Body,
+				maxRatio:   int64(maxExpansion),
+			}
+			resp.Header.Del("Content-Encoding")
+			resp.Header.Del("Content-Length")
+			resp.ContentLength = -1
+			resp.Uncompressed = true
+		}
+	}
+	if maxBytes > 0 {
+		resp.Body = &limitedBody{r: resp.Body, remaining: maxBytes, limit: maxBytes}
+	}
+	return nil
+}
+
+// hasBody reports whether the response may have a body, since responses to HEAD requests, and 204 and 304 responses, can have a Content-Encoding without one.
+func hasBody(resp *http.Response) bool {
+	if resp.ContentLength == 0 || resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
+		return false
+	}
+	return resp.Request == nil || resp.Request.Method != http.MethodHead
+}
+
+// newDeflateReader handles both zlib wrapped and raw deflate, since servers disagree about what "deflate" means.
+func newDeflateReader(r io.Reader) (io.ReadCloser, error) {
+	buf := bufio.NewReader(r)
+	header, err := buf.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+	// A zlib header's first byte has compression method 8, and the 16-bit header is a multiple of 31.
+	if header[0]&0x0F == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buf)
+	}
+	return flate.NewReader(buf), nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type expansionReader struct {
+	decoded    io.ReadCloser
+	compressed *countingReader
+	closer     io.Closer
+	maxRatio   int64
+	n          int64
+}
+
+func (e *expansionReader) Read(p []byte) (int, error) {
+	n, err := e.decoded.Read(p)
+	e.n += int64(n)
+	if e.n > minExpansionCheck && e.n > e.maxRatio*e.compressed.n {
+		return n, fmt.Errorf("%w: %d bytes from %d compressed bytes exceeds ratio %d", ErrDecompressionBomb, e.n, e.compressed.n, e.maxRatio)
+	}
+	return n, err
+}
+
+func (e *expansionReader) Close() error {
+	return errors.Join(e.decoded.Close(), e.closer.Close())
+}
+
+type limitedBody struct {
+	r         io.ReadCloser
+	remaining int64
+	limit     int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Check if there's more data, without returning it.
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, l.limit)
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	return l.r.Close()
+}
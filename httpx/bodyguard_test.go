@@ -0,0 +1,134 @@
+package httpx
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequest_MaxResponseBytes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", 100)))
+	}))
+	defer srv.Close()
+
+	resp, _, err := GetRequest(srv.URL).MaxResponseBytes(10).Send()
+	require.NoError(t, err)
+	_, err = resp.String()
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	resp, _, err = GetRequest(srv.URL).MaxResponseBytes(100).Send()
+	require.NoError(t, err)
+	body, err := resp.String()
+	assert.NoError(t, err, "A body exactly at the limit should be allowed")
+	assert.Len(t, body, 100)
+}
+
+func compressedHandler(encoding string, data []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			buf bytes.Buffer
+			enc io.WriteCloser
+		)
+		switch encoding {
+		case "gzip":
+			enc = gzip.NewWriter(&buf)
+		case "zlib":
+			enc = zlib.NewWriter(&buf)
+			encoding = "deflate"
+		case "deflate":
+			enc, _ = flate.NewWriter(&buf, flate.BestCompression)
+		}
+		_, _ = enc.Write(data)
+		_ = enc.Close()
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), encoding) {
+			http.Error(w, "unexpected accept encoding", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Encoding", encoding)
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+func TestRequest_Decompress(t *testing.T) {
+	data := []byte(strings.Repeat("hello world ", 100))
+	for _, encoding := range []string{"gzip", "zlib", "deflate"} {
+		t.Run(encoding, func(t *testing.T) {
+			srv := httptest.NewServer(compressedHandler(encoding, data))
+			defer srv.Close()
+			resp, status, err := GetRequest(srv.URL).Decompress(100).Send()
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, status)
+			_, hasEncoding := resp.GetHeader("Content-Encoding")
+			assert.False(t, hasEncoding)
+			body, err := resp.Bytes()
+			require.NoError(t, err)
+			assert.Equal(t, data, body)
+		})
+	}
+}
+
+func TestRequest_Decompress_Bomb(t *testing.T) {
+	srv := httptest.NewServer(compressedHandler("gzip", make([]byte, 8<<20)))
+	defer srv.Close()
+
+	resp, _, err := GetRequest(srv.URL).Decompress(100).Send()
+	require.NoError(t, err)
+	_, err = resp.Bytes()
+	assert.ErrorIs(t, err, ErrDecompressionBomb)
+
+	resp, _, err = GetRequest(srv.URL).Decompress(100_000).MaxResponseBytes(1 << 20).Send()
+	require.NoError(t, err)
+	_, err = resp.Bytes()
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	_, _, err = GetRequest(srv.URL).Decompress(0).Send()
+	assert.Error(t, err)
+}
+
+func TestRequest_Decompress_EmptyBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		switch r.URL.Path {
+		case "/no-content":
+			w.WriteHeader(http.StatusNoContent)
+		case "/not-modified":
+			w.WriteHeader(http.StatusNotModified)
+		case "/chunked":
+			// Flushing before writing anything forces chunked encoding, so there's no Content-Length.
+			w.(http.Flusher).Flush()
+		default:
+			w.Header().Set("Content-Length", "20")
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	tests := map[string]struct {
+		req    *Request
+		status int
+	}{
+		"No content":   {GetRequest(srv.URL + "/no-content"), http.StatusNoContent},
+		"Not modified": {GetRequest(srv.URL + "/not-modified"), http.StatusNotModified},
+		"HEAD":         {NewRequest(http.MethodHead, srv.URL), http.StatusOK},
+		"Chunked":      {GetRequest(srv.URL + "/chunked"), http.StatusOK},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, status, err := tc.req.Decompress(100).Send()
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, status)
+			body, err := resp.Bytes()
+			require.NoError(t, err)
+			assert.Empty(t, body)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package httpx
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Resolver looks up the addresses for a host name.
+// [net.Resolver] implements this interface.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+type clientConf struct {
+	client       *http.Client
+	transport    *http.Transport
+	disableHTTP2 bool
+}
+
+type ClientOption func(conf *clientConf) error
+
+// OptClientTimeout sets the overall timeout for a request, including reading the response body.
+// Defaults to no timeout, in which case the request context should be used to limit requests.
+func OptClientTimeout(timeout time.Duration) ClientOption {
+	return func(conf *clientConf) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout '%s' is invalid, must be >= 0", timeout)
+		}
+		conf.client.Timeout = timeout
+		return nil
+	}
+}
+
+// OptClientMaxIdlePerHost sets the maximum number of idle connections kept for reuse per host.
+// The standard library default of 2 is low for clients that make many concurrent requests to the same API.
+func OptClientMaxIdlePerHost(num int) ClientOption {
+	return func(conf *clientConf) error {
+		if num < 0 {
+			return fmt.Errorf("max idle connections '%d' is invalid, must be >= 0", num)
+		}
+		conf.transport.MaxIdleConnsPerHost = num
+		conf.transport.MaxIdleConns = max(conf.transport.MaxIdleConns, num)
+		return nil
+	}
+}
+
+// OptClientIdleTimeout sets how long an idle connection is kept for reuse before it's closed.
+// A timeout of 0 keeps idle connections indefinitely.
+func OptClientIdleTimeout(timeout time.Duration) ClientOption {
+	return func(conf *clientConf) error {
+		if timeout < 0 {
+			return fmt.Errorf("idle timeout '%s' is invalid, must be >= 0", timeout)
+		}
+		conf.transport.IdleConnTimeout = timeout
+		return nil
+	}
+}
+
+// OptClientHTTP2 enables or disables HTTP/2 for TLS connections.
+// HTTP/2 is enabled by default.
+func OptClientHTTP2(enabled bool) ClientOption {
+	return func(conf *clientConf) error {
+		conf.disableHTTP2 = !enabled
+		return nil
+	}
+}
+
+// OptClientExpectContinue sets how long to wait for a "100 Continue" response when a request has an "Expect: 100-continue" header.
+// A timeout of 0 sends the body immediately without waiting.
+func OptClientExpectContinue(timeout time.Duration) ClientOption {
+	return func(conf *clientConf) error {
+		if timeout < 0 {
+			return fmt.Errorf("expect continue timeout '%s' is invalid, must be >= 0", timeout)
+		}
+		conf.transport.ExpectContinueTimeout = timeout
+		return nil
+	}
+}
+
+// OptClientResolver sets the [Resolver] used to look up host names when dialing, like a [CachingResolver].
+func OptClientResolver(resolver Resolver) ClientOption {
+	return func(conf *clientConf) error {
+		if resolver == nil {
+			return errors.New("nil resolver")
+		}
+		conf.transport.DialContext = resolvingDialer(resolver)
+		return nil
+	}
+}
+
+// OptClientTLSConfig sets the TLS configuration used for HTTPS connections.
+func OptClientTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(conf *clientConf) error {
+		if tlsConfig == nil {
+			return errors.New("nil TLS config")
+		}
+		conf.transport.TLSClientConfig = tlsConfig.Clone()
+		return nil
+	}
+}
+
+// NewClient creates an [http.Client] with a transport based on [http.DefaultTransport], tuned with the given options.
+// Use [Request.WithClient] to send a [Request] with the client.
+func NewClient(opts ...ClientOption) (*http.Client, error) {
+	conf := clientConf{
+		client:    new(http.Client),
+		transport: http.DefaultTransport.(*http.Transport).Clone(),
+	}
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			return nil, err
+		}
+	}
+	if conf.disableHTTP2 {
+		conf.transport.ForceAttemptHTTP2 = false
+		// A non-nil, empty map disables HTTP/2 in the transport.
+		conf.transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		if conf.transport.TLSClientConfig != nil {
+			conf.transport.TLSClientConfig.NextProtos = slices.DeleteFunc(slices.Clone(conf.transport.TLSClientConfig.NextProtos), func(proto string) bool {
+				return proto == "h2"
+			})
+		}
+	}
+	conf.client.Transport = conf.transport
+	return conf.client, nil
+}
+
+func resolvingDialer(resolver Resolver) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		addrs, err := resolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var errs []error
+		for _, ip := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			}
+			errs = append(errs, err)
+		}
+		if len(errs) == 0 {
+			return nil, fmt.Errorf("no addresses found for host '%s'", host)
+		}
+		return nil, errors.Join(errs...)
+	}
+}
+
+type cachedHost struct {
+	addrs   []string
+	expires time.Time
+}
+
+// CachingResolver caches successful lookups from another [Resolver] for a fixed TTL.
+// This reduces DNS traffic for clients that make many connections to the same hosts.
+// Failed lookups are not cached.
+type CachingResolver struct {
+	next  Resolver
+	ttl   time.Duration
+	now   func() time.Time
+	mux   sync.RWMutex
+	cache map[string]cachedHost
+}
+
+// NewCachingResolver creates a [CachingResolver] that caches lookups from next for the TTL.
+// If next is nil, then [net.DefaultResolver] is used.
+func NewCachingResolver(next Resolver, ttl time.Duration) *CachingResolver {
+	if ttl <= 0 {
+		panic("cache TTL must be > 0")
+	}
+	if next == nil {
+		next = net.DefaultResolver
+	}
+	return &CachingResolver{
+		next:  next,
+		ttl:   ttl,
+		now:   time.Now,
+		cache: map[string]cachedHost{},
+	}
+}
+
+func (r *CachingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mux.RLock()
+	cached, ok := r.cache[host]
+	r.mux.RUnlock()
+	if ok && r.now().Before(cached.expires) {
+		return cached.addrs, nil
+	}
+	addrs, err := r.next.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.cache[host] = cachedHost{addrs: addrs, expires: r.now().Add(r.ttl)}
+	return addrs, nil
+}
+
+// Flush removes all cached lookups.
+func (r *CachingResolver) Flush() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	clear(r.cache)
+}
+
+// WithClient sets the [http.Client] used to send this [Request], instead of [http.DefaultClient].
+func (r *Request) WithClient(client *http.Client) *Request {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.err != nil {
+		return r
+	}
+	if client == nil {
+		r.err = errors.New("nil client")
+		return r
+	}
+	r.client = client
+	return r
+}
@@ -0,0 +1,107 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testResolver struct {
+	lookups atomic.Int32
+	addrs   []string
+	err     error
+}
+
+func (r *testResolver) LookupHost(_ context.Context, _ string) ([]string, error) {
+	r.lookups.Add(1)
+	return r.addrs, r.err
+}
+
+func TestNewClient_HTTP2(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+	tlsConfig := srv.Client().Transport.(*http.Transport).TLSClientConfig
+
+	for _, enabled := range []bool{true, false} {
+		client, err := NewClient(OptClientTLSConfig(tlsConfig), OptClientHTTP2(enabled), OptClientMaxIdlePerHost(10))
+		require.NoError(t, err)
+		resp, status, err := GetRequest(srv.URL).WithClient(client).Send()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		proto, err := resp.String()
+		require.NoError(t, err)
+		if enabled {
+			assert.Equal(t, "HTTP/2.0", proto)
+		} else {
+			assert.Equal(t, "HTTP/1.1", proto)
+		}
+	}
+}
+
+func TestNewClient_Resolver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	inner := &testResolver{addrs: []string{u.Hostname()}}
+	resolver := NewCachingResolver(inner, time.Minute)
+	client, err := NewClient(OptClientResolver(resolver), OptClientIdleTimeout(0), OptClientTimeout(time.Second))
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		resp, status, err := GetRequest("http://api.test:" + u.Port()).WithClient(client).Send()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		_ = resp.Close()
+		client.CloseIdleConnections()
+	}
+	assert.Equal(t, int32(1), inner.lookups.Load(), "Lookups should be cached")
+
+	resolver.Flush()
+	_, err = resolver.LookupHost(context.Background(), "api.test")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), inner.lookups.Load())
+}
+
+func TestCachingResolver_Expiry(t *testing.T) {
+	inner := &testResolver{addrs: []string{"127.0.0.1"}}
+	resolver := NewCachingResolver(inner, time.Minute)
+	now := time.Now()
+	resolver.now = func() time.Time {
+		return now
+	}
+	_, _ = resolver.LookupHost(context.Background(), "a")
+	_, _ = resolver.LookupHost(context.Background(), "a")
+	assert.Equal(t, int32(1), inner.lookups.Load())
+	now = now.Add(2 * time.Minute)
+	_, _ = resolver.LookupHost(context.Background(), "a")
+	assert.Equal(t, int32(2), inner.lookups.Load())
+
+	inner.err = errors.New("lookup failed")
+	now = now.Add(2 * time.Minute)
+	_, err := resolver.LookupHost(context.Background(), "a")
+	assert.Error(t, err)
+}
+
+func TestNewClient_InvalidOptions(t *testing.T) {
+	_, err := NewClient(OptClientTimeout(-1))
+	assert.Error(t, err)
+	_, err = NewClient(OptClientResolver(nil))
+	assert.Error(t, err)
+	_, _, err = GetRequest("http://localhost").WithClient(nil).Send()
+	assert.Error(t, err)
+}
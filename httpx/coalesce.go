@@ -0,0 +1,148 @@
+package httpx
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type coalescedResponse struct {
+	done      chan struct{}
+	abandoned bool // abandoned is true if the response can't be shared, because the handler panicked or the request was cancelled.
+	status    int
+	header    http.Header
+	body      []byte
+}
+
+func (c *coalescedResponse) writeTo(w http.ResponseWriter) {
+	for key, vals := range c.header {
+		w.Header()[key] = append([]string(nil), vals...)
+	}
+	w.WriteHeader(c.status)
+	_, _ = w.Write(c.body)
+}
+
+type coalesceWriter struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (c *coalesceWriter) Header() http.Header {
+	return c.header
+}
+
+func (c *coalesceWriter) WriteHeader(statusCode int) {
+	if c.wroteHeader {
+		return
+	}
+	c.wroteHeader = true
+	c.status = statusCode
+}
+
+func (c *coalesceWriter) Write(data []byte) (int, error) {
+	c.WriteHeader(http.StatusOK)
+	return c.body.Write(data)
+}
+
+// CoalesceMiddleware creates a [Middleware] that combines identical concurrent GET requests, so only one of them calls the wrapped handler.
+// The response from that call is buffered and written to every waiting request, which protects expensive endpoints from a thundering herd.
+//
+// Requests are identical if they have the same host, path, query, and values for each of the given vary headers.
+// Requests with an Authorization or Cookie header are never coalesced unless that header is included in the vary headers, to avoid sharing responses between users.
+//
+// If the request calling the handler panics or is cancelled, then waiting requests call the handler themselves instead of receiving its response.
+// Since responses are buffered, this should not be used with streaming responses.
+func CoalesceMiddleware(varyHeaders ...string) Middleware {
+	vary := make([]string, len(varyHeaders))
+	var varyCredentials bool
+	for i, header := range varyHeaders {
+		vary[i] = http.CanonicalHeaderKey(header)
+		if vary[i] == "Authorization" || vary[i] == "Cookie" {
+			varyCredentials = true
+		}
+	}
+	var (
+		mux      sync.Mutex
+		inFlight = map[string]*coalescedResponse{}
+	)
+	return func(next http.Handler) http.Handler {
+		if next == nil {
+			panic("nil handler")
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !varyCredentials && (len(r.Header.Get("Authorization")) > 0 || len(r.Header.Values("Cookie")) > 0) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			key := coalesceKey(r, vary)
+			mux.Lock()
+			resp, waiting := inFlight[key]
+			if !waiting {
+				resp = &coalescedResponse{done: make(chan struct{})}
+				inFlight[key] = resp
+			}
+			mux.Unlock()
+
+			if waiting {
+				select {
+				case <-r.Context().Done():
+					return
+				case <-resp.done:
+				}
+				if resp.abandoned {
+					next.ServeHTTP(w, r)
+					return
+				}
+				resp.writeTo(w)
+				return
+			}
+
+			cw := &coalesceWriter{header: http.Header{}, status: http.StatusOK}
+			defer func() {
+				mux.Lock()
+				delete(inFlight, key)
+				mux.Unlock()
+				if p := recover(); p != nil {
+					// Let waiting requests try for themselves.
+					resp.abandoned = true
+					close(resp.done)
+					panic(p)
+				}
+				if r.Context().Err() != nil {
+					// The handler may have stopped early for this request, so the response isn't shared.
+					resp.abandoned = true
+					close(resp.done)
+					return
+				}
+				resp.status = cw.status
+				resp.header = cw.header
+				resp.body = cw.body.Bytes()
+				close(resp.done)
+				resp.writeTo(w)
+			}()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+func coalesceKey(r *http.Request, vary []string) string {
+	var sb strings.Builder
+	sb.WriteString(r.Host)
+	sb.WriteString(r.URL.Path)
+	sb.WriteByte('?')
+	sb.WriteString(r.URL.RawQuery)
+	for _, header := range vary {
+		sb.WriteByte('\n')
+		sb.WriteString(header)
+		sb.WriteByte(':')
+		sb.WriteString(strings.Join(r.Header.Values(header), ","))
+	}
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package httpx
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceMiddleware(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+	)
+	handler := CoalesceMiddleware("Accept")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("X-Test", "value")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("response for " + r.Header.Get("Accept")))
+	}))
+
+	const numRequests = 5
+	var (
+		wg   sync.WaitGroup
+		recs = make([]*httptest.ResponseRecorder, numRequests)
+	)
+	wg.Add(numRequests)
+	for i := range recs {
+		recs[i] = httptest.NewRecorder()
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/expensive?a=1", nil)
+			req.Header.Set("Accept", "text/plain")
+			handler.ServeHTTP(recs[i], req)
+		}()
+	}
+	// Give all requests a chance to join the first.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load(), "Handler should only be called once")
+	for _, rec := range recs {
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Equal(t, "value", rec.Header().Get("X-Test"))
+		assert.Equal(t, "response for text/plain", rec.Body.String())
+	}
+}
+
+func TestCoalesceMiddleware_NotCoalesced(t *testing.T) {
+	var calls atomic.Int32
+	handler := CoalesceMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	tests := map[string]*http.Request{
+		"POST":          httptest.NewRequest(http.MethodPost, "/", nil),
+		"Authorization": httptest.NewRequest(http.MethodGet, "/", nil),
+		"Cookie":        httptest.NewRequest(http.MethodGet, "/", nil),
+	}
+	tests["Authorization"].Header.Set("Authorization", "Bearer token")
+	tests["Cookie"].Header.Set("Cookie", "session=abc")
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls.Store(0)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, int32(1), calls.Load())
+		})
+	}
+	assert.NotEqual(t,
+		coalesceKey(httptest.NewRequest(http.MethodGet, "/a?x=1", nil), nil),
+		coalesceKey(httptest.NewRequest(http.MethodGet, "/a?x=2", nil), nil),
+	)
+}
+
+func TestCoalesceMiddleware_Panic(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		release = make(chan struct{})
+	)
+	handler := CoalesceMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			<-release
+			panic("intentional")
+		}
+		_, _ = w.Write([]byte("recovered"))
+	}))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Panics(t, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	rec := httptest.NewRecorder()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	<-done
+	assert.Equal(t, "recovered", rec.Body.String(), "Waiting request should call the handler itself")
+}
+
+func TestCoalesceMiddleware_LeaderCancelled(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		started = make(chan struct{})
+	)
+	handler := CoalesceMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-r.Context().Done()
+			http.Error(w, "cancelled", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("complete"))
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	}()
+	<-started
+	rec := httptest.NewRecorder()
+	go func() {
+		// Give the waiting request a chance to join the first.
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	<-done
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "complete", rec.Body.String(), "Waiting request should not receive the cancelled response")
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -32,7 +32,9 @@ type JSONErrorHandler[E any] func(err error) E
 // HandleJSON produces a [http.Handler] from a [JSONErrorHandler] and [JSONHandler] pair.
 // It will handle deserialization of the JSON request payload, serialization of the JSON response payload, and serialization of JSON error responses.
 // This will also handle closing the request body to ensure that resource usage is kept minimal.
-// Client errors will result in a 400 status code being sent to the client. All other errors will result in a 500 status code.
+// Client errors will result in a 400 status code being sent to the client.
+// If the [JSONHandler] returns a [StatusError], then its code is sent to the client, and the [StatusError] is passed to the [JSONErrorHandler].
+// All other errors will result in a 500 status code.
 func HandleJSON[T any, R any, E any](errHandler JSONErrorHandler[E], handler JSONHandler[T, R]) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
@@ -40,26 +42,22 @@ func HandleJSON[T any, R any, E any](errHandler JSONErrorHandler[E], handler JSO
 		}()
 		var request T
 		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-			errVal := errHandler(fmt.Errorf("%w: %v", ErrClientError, err))
-			w.WriteHeader(400)
-			w.Header().Set(HeaderContentType, ContentTypeJSON)
-			_ = json.NewEncoder(w).Encode(errVal)
+			writeJSONError(w, http.StatusBadRequest, errHandler(fmt.Errorf("%w: %v", ErrClientError, err)))
 			return
 		}
 		resp, err := handler(&request)
 		if err != nil {
-			errVal := errHandler(fmt.Errorf("%w: %v", ErrServerError, err))
-			w.WriteHeader(500)
-			w.Header().Set(HeaderContentType, ContentTypeJSON)
-			_ = json.NewEncoder(w).Encode(errVal)
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) && statusErr.Code >= 400 {
+				writeJSONError(w, statusErr.Code, errHandler(statusErr))
+				return
+			}
+			writeJSONError(w, http.StatusInternalServerError, errHandler(fmt.Errorf("%w: %v", ErrServerError, err)))
 			return
 		}
 		out, err := json.Marshal(resp)
 		if err != nil {
-			errVal := errHandler(fmt.Errorf("%w: %v", ErrServerError, err))
-			w.WriteHeader(500)
-			w.Header().Set(HeaderContentType, ContentTypeJSON)
-			_ = json.NewEncoder(w).Encode(errVal)
+			writeJSONError(w, http.StatusInternalServerError, errHandler(fmt.Errorf("%w: %v", ErrServerError, err)))
 			return
 		}
 		w.Header().Set(HeaderContentType, ContentTypeJSON)
@@ -71,3 +69,10 @@ func HandleJSON[T any, R any, E any](errHandler JSONErrorHandler[E], handler JSO
 		}
 	})
 }
+
+func writeJSONError(w http.ResponseWriter, code int, errVal any) {
+	// Headers must be set before the status is written.
+	w.Header().Set(HeaderContentType, ContentTypeJSON)
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errVal)
+}
@@ -0,0 +1,106 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+var (
+	ErrTooManyRedirects = errors.New("too many redirects")
+)
+
+// NoFollowRedirects returns redirect responses to the caller instead of following them.
+// The Location header can be read from the returned [Response] with [Response.GetHeader].
+func (r *Request) NoFollowRedirects() *Request {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.noRedirects = true
+	return r
+}
+
+// MaxRedirects limits the number of redirects that will be followed.
+// Sending the request returns an error wrapping [ErrTooManyRedirects] if more redirects are needed.
+// Without calling this, the client's redirect policy is used, which follows up to 10 redirects by default.
+func (r *Request) MaxRedirects(n int) *Request {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.err != nil {
+		return r
+	}
+	if n < 0 {
+		r.err = fmt.Errorf("max redirects '%d' is invalid, must be >= 0", n)
+		return r
+	}
+	r.maxRedirects = &n
+	return r
+}
+
+// redirectTracker records the redirect chain of a request, and applies its redirect policy.
+type redirectTracker struct {
+	mux         sync.Mutex
+	noRedirects bool
+	max         *int
+	next        func(req *http.Request, via []*http.Request) error
+	chain       []*url.URL
+}
+
+// client returns a shallow copy of the client that uses the tracker's redirect policy.
+func (t *redirectTracker) client(client *http.Client) *http.Client {
+	c := *client
+	t.next = client.CheckRedirect
+	c.CheckRedirect = t.checkRedirect
+	return &c
+}
+
+func (t *redirectTracker) checkRedirect(req *http.Request, via []*http.Request) error {
+	t.mux.Lock()
+	t.chain = t.chain[:0]
+	for _, prev := range via {
+		t.chain = append(t.chain, prev.URL)
+	}
+	t.mux.Unlock()
+	if t.noRedirects {
+		return http.ErrUseLastResponse
+	}
+	if t.max != nil {
+		if len(via) > *t.max {
+			return fmt.Errorf("%w: stopped after %d redirects", ErrTooManyRedirects, *t.max)
+		}
+		return nil
+	}
+	if t.next != nil {
+		return t.next(req, via)
+	}
+	if len(via) >= 10 {
+		return fmt.Errorf("%w: stopped after 10 redirects", ErrTooManyRedirects)
+	}
+	return nil
+}
+
+func (t *redirectTracker) redirects() []*url.URL {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if t.noRedirects {
+		// The redirect wasn't followed, so the chain only contains the original request.
+		return nil
+	}
+	return append([]*url.URL(nil), t.chain...)
+}
+
+// Redirects returns the URLs of each request that was redirected, in order, starting with the original request URL.
+// This is empty if no redirects were followed.
+// Use [Response.FinalURL] to get the URL that produced this response.
+func (r *Response) Redirects() []*url.URL {
+	return r.redirects
+}
+
+// FinalURL returns the URL of the request that produced this response, after any redirects were followed.
+func (r *Response) FinalURL() *url.URL {
+	if r.resp != nil && r.resp.Request != nil {
+		return r.resp.Request.URL
+	}
+	return r.req.URL
+}
@@ -0,0 +1,63 @@
+package httpx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequest_Redirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/b", http.StatusFound)
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/c", http.StatusFound)
+	})
+	mux.HandleFunc("/c", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	t.Run("Follow", func(t *testing.T) {
+		resp, status, err := GetRequest(srv.URL + "/a").Send()
+		require.NoError(t, err)
+		defer func() {
+			_ = resp.Close()
+		}()
+		assert.Equal(t, http.StatusOK, status)
+		redirects := resp.Redirects()
+		require.Len(t, redirects, 2)
+		assert.Equal(t, "/a", redirects[0].Path)
+		assert.Equal(t, "/b", redirects[1].Path)
+		assert.Equal(t, "/c", resp.FinalURL().Path)
+	})
+	t.Run("No follow", func(t *testing.T) {
+		resp, status, err := GetRequest(srv.URL + "/a").NoFollowRedirects().Send()
+		require.NoError(t, err)
+		defer func() {
+			_ = resp.Close()
+		}()
+		assert.Equal(t, http.StatusFound, status)
+		loc, ok := resp.GetHeader("Location")
+		assert.True(t, ok)
+		assert.Equal(t, "/b", loc)
+		assert.Empty(t, resp.Redirects())
+		assert.Equal(t, "/a", resp.FinalURL().Path)
+	})
+	t.Run("Max redirects", func(t *testing.T) {
+		_, _, err := GetRequest(srv.URL + "/a").MaxRedirects(1).Send()
+		assert.ErrorIs(t, err, ErrTooManyRedirects)
+
+		resp, status, err := GetRequest(srv.URL + "/a").MaxRedirects(2).Send()
+		require.NoError(t, err)
+		_ = resp.Close()
+		assert.Equal(t, http.StatusOK, status)
+
+		_, _, err = GetRequest(srv.URL + "/a").MaxRedirects(-1).Send()
+		assert.Error(t, err)
+	})
+}
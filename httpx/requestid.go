@@ -0,0 +1,57 @@
+package httpx
+
+import (
+	"context"
+	"github.com/saylorsolutions/x/idx"
+	"net/http"
+)
+
+const (
+	// HeaderRequestID is the header used to pass a request ID between services.
+	HeaderRequestID = "X-Request-ID"
+	// maxRequestIDLength limits the length of an incoming request ID that will be trusted.
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+// RequestIDMiddleware ensures that each request has an ID, available with [RequestID].
+// If the incoming request has a reasonable [HeaderRequestID] header then it's reused, otherwise a new [idx.ULID] is generated.
+// The ID is also set in the response headers.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	if next == nil {
+		panic("nil handler")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(HeaderRequestID)
+		if !validRequestID(id) {
+			id = idx.NewULID().String()
+		}
+		w.Header().Set(HeaderRequestID, id)
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
+
+func validRequestID(id string) bool {
+	if len(id) == 0 || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		// Only printable ASCII, to avoid log injection.
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID returns a child context with the given request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID set by [RequestIDMiddleware], or an empty string if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,35 @@
+package httpx
+
+import (
+	"github.com/saylorsolutions/x/idx"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	h := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	_, err := idx.ParseULID(seen)
+	assert.NoError(t, err, "A new ULID should have been generated")
+	assert.Equal(t, seen, rec.Header().Get(HeaderRequestID))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderRequestID, "upstream-id")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, "upstream-id", seen)
+	assert.Equal(t, "upstream-id", rec.Header().Get(HeaderRequestID))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderRequestID, "bad id\n")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	assert.NotEqual(t, "bad id\n", seen)
+	assert.NotEmpty(t, seen)
+}
@@ -22,6 +22,14 @@ type Request struct {
 	headers http.Header
 	ctx     context.Context
 	client  *http.Client
+	signer  *Signer
+	trace   bool
+
+	maxResponseBytes int64
+	maxExpansion     int
+	noRedirects      bool
+	maxRedirects     *int
+	statusErrors     bool
 }
 
 func requestInit(u string) *Request {
@@ -76,6 +84,9 @@ func DeleteRequest(u string) *Request {
 func (r *Request) SetHeader(header, value string) *Request {
 	r.mux.Lock()
 	defer r.mux.Unlock()
+	if r.err != nil {
+		return r
+	}
 	r.headers.Set(header, value)
 	return r
 }
@@ -169,7 +180,12 @@ func (r *Request) StdRequest() (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
-	req.Header = r.headers
+	req.Header = r.headers.Clone()
+	if r.signer != nil {
+		if err := r.signer.SignRequest(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -180,6 +180,46 @@ func TestReadJSON(t *testing.T) {
 	assert.True(t, body.closeCalled, "Close wasn't called on response body")
 }
 
+func TestStreamJSONArray(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+	newResp := func(body string) (*Response, *bufCloser) {
+		rc := &bufCloser{Reader: strings.NewReader(body)}
+		return &Response{resp: &http.Response{Body: rc}}, rc
+	}
+
+	resp, body := newResp(`[{"id":1}, {"id":2}, {"id":3}]`)
+	var ids []int
+	for val, err := range StreamJSONArray[item](resp) {
+		assert.NoError(t, err)
+		ids = append(ids, val.ID)
+	}
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	assert.True(t, body.closeCalled, "Close wasn't called on response body")
+
+	resp, body = newResp(`[{"id":1}, {"id":2}]`)
+	for val := range StreamJSONArray[item](resp) {
+		assert.Equal(t, 1, val.ID)
+		break
+	}
+	assert.True(t, body.closeCalled, "Close should be called when stopping early")
+
+	resp, _ = newResp(`{"id":1}`)
+	for _, err := range StreamJSONArray[item](resp) {
+		assert.ErrorContains(t, err, "expected start of JSON array")
+	}
+
+	resp, _ = newResp(`[{"id":1}, {"id":`)
+	var errs int
+	for _, err := range StreamJSONArray[item](resp) {
+		if err != nil {
+			errs++
+		}
+	}
+	assert.Equal(t, 1, errs)
+}
+
 func TestRequestAuth(t *testing.T) {
 	var (
 		basicAuth, bearerAuth bool
@@ -229,3 +269,9 @@ func TestRequestAuth(t *testing.T) {
 		assert.True(t, bearerAuth, "Should have capture bearer auth credentials")
 	})
 }
+
+func TestRequest_SetHeaderAfterError(t *testing.T) {
+	r := PostRequest("http://bad host/%zz").SetHeader("Content-Type", "application/json")
+	_, err := r.StdRequest()
+	assert.Error(t, err)
+}
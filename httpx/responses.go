@@ -3,8 +3,11 @@ package httpx
 import (
 	"encoding/json"
 	"errors"
+	"github.com/saylorsolutions/x/encodingx"
 	"io"
+	"iter"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 )
@@ -14,22 +17,48 @@ var (
 )
 
 type Response struct {
-	req     *http.Request
-	resp    *http.Response
-	mux     sync.Mutex
-	hasRead bool
+	req       *http.Request
+	resp      *http.Response
+	mux       sync.Mutex
+	hasRead   bool
+	timings   *Timings
+	redirects []*url.URL
 }
 
 func (r *Request) Send() (*Response, int, error) {
 	req, err := r.StdRequest()
+	if err != nil {
+		return nil, 0, err
+	}
+	var rec *timingRecorder
+	r.mux.RLock()
+	if r.trace {
+		req, rec = traceRequest(req)
+	}
+	maxBytes, maxExpansion, statusErrors := r.maxResponseBytes, r.maxExpansion, r.statusErrors
+	tracker := &redirectTracker{noRedirects: r.noRedirects, max: r.maxRedirects}
+	client := tracker.client(r.client)
+	r.mux.RUnlock()
 	_resp := &Response{
 		req: req,
 	}
-	resp, err := r.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, 0, err
 	}
+	if err := guardBody(resp, maxBytes, maxExpansion); err != nil {
+		_ = resp.Body.Close()
+		return nil, 0, err
+	}
+	if statusErrors && resp.StatusCode >= 400 {
+		return nil, resp.StatusCode, responseStatusError(resp)
+	}
 	_resp.resp = resp
+	_resp.redirects = tracker.redirects()
+	if rec != nil {
+		timings := rec.timings()
+		_resp.timings = &timings
+	}
 	return _resp, resp.StatusCode, nil
 }
 
@@ -104,3 +133,25 @@ func ReadJSON[T any](r *Response) (*T, error) {
 	}
 	return &val, nil
 }
+
+// StreamJSONArray decodes a top-level JSON array in the response body one element at a time, so large responses don't need to be fully buffered like [ReadJSON].
+// The body is closed when iteration completes or stops early.
+// If an error occurs, then it's yielded with a zero value and iteration stops.
+func StreamJSONArray[T any](r *Response) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var mt T
+		reader, err := r.Body()
+		if err != nil {
+			yield(mt, err)
+			return
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+		for val, err := range encodingx.DecodeArray[T](reader) {
+			if !yield(val, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package httpx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestSigner_SignHMAC(t *testing.T) {
+	signer := NewSigner([]byte("secret"))
+	var verified bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "payload", string(body), "Body should still be sent after signing")
+		ts, err := strconv.ParseInt(r.Header.Get(HeaderSignatureTimestamp), 10, 64)
+		require.NoError(t, err)
+		verified = signer.Verify(r.Method, r.URL.RequestURI(), ts, r.Header.Get(HeaderSignatureNonce), body, r.Header.Get(HeaderSignature))
+	}))
+	defer srv.Close()
+
+	_, status, err := PostRequest(srv.URL + "/hook?id=1").StringBody("payload").SignHMAC(signer).Send()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, verified)
+}
+
+func TestSigner_Verify(t *testing.T) {
+	signer := NewSigner([]byte("secret"))
+	sig := signer.Sign("post", "/path", 100, "nonce", []byte("body"))
+	assert.True(t, signer.Verify("POST", "/path", 100, "nonce", []byte("body"), sig), "Method should be case-insensitive")
+	assert.False(t, signer.Verify("POST", "/path", 101, "nonce", []byte("body"), sig))
+	assert.False(t, signer.Verify("POST", "/other", 100, "nonce", []byte("body"), sig))
+	assert.False(t, signer.Verify("POST", "/path", 100, "nonce", []byte("tampered"), sig))
+	assert.False(t, signer.Verify("POST", "/path", 100, "nonce", []byte("body"), "not hex"))
+	assert.False(t, NewSigner([]byte("other")).Verify("POST", "/path", 100, "nonce", []byte("body"), sig))
+	assert.Panics(t, func() {
+		NewSigner(nil)
+	})
+}
+
+func TestRequest_SendError(t *testing.T) {
+	_, _, err := GetRequest("http://localhost").SignHMAC(nil).Send()
+	assert.Error(t, err, "Builder errors should be returned from Send")
+}
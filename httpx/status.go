@@ -0,0 +1,159 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StatusError is an error with an HTTP status code.
+// It's returned by the client for unsuccessful responses when [Request.ErrorForStatus] is used, and [HandleJSON] responds with its Code when a [JSONHandler] returns one.
+//
+// A StatusError with a 4xx code wraps [ErrClientError], and a 5xx code wraps [ErrServerError], so it can be checked with [errors.Is] like other errors from this package.
+type StatusError struct {
+	Code    int
+	Message string // Message is an optional description, like the body of an error response.
+	Err     error  // Err is an optional underlying error.
+}
+
+// NewStatusError creates a [StatusError] with the code, and a message formatted with [fmt.Sprintf].
+func NewStatusError(code int, format string, args ...any) *StatusError {
+	return &StatusError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// WrapStatus creates a [StatusError] with the code that wraps the error.
+func WrapStatus(code int, err error) *StatusError {
+	return &StatusError{
+		Code: code,
+		Err:  err,
+	}
+}
+
+func (e *StatusError) Error() string {
+	var buf strings.Builder
+	if kind := e.kind(); kind != nil {
+		buf.WriteString(kind.Error())
+		buf.WriteString(": ")
+	}
+	_, _ = fmt.Fprintf(&buf, "status %d", e.Code)
+	if text := http.StatusText(e.Code); len(text) > 0 {
+		buf.WriteString(" " + text)
+	}
+	if len(e.Message) > 0 {
+		buf.WriteString(": " + e.Message)
+	}
+	if e.Err != nil {
+		buf.WriteString(": " + e.Err.Error())
+	}
+	return buf.String()
+}
+
+func (e *StatusError) Unwrap() []error {
+	var errs []error
+	if kind := e.kind(); kind != nil {
+		errs = append(errs, kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+func (e *StatusError) kind() error {
+	switch {
+	case e.Code >= 500:
+		return ErrServerError
+	case e.Code >= 400:
+		return ErrClientError
+	default:
+		return nil
+	}
+}
+
+// Retryable reports whether the request may succeed if it's sent again later, like after a 429 Too Many Requests or 503 Service Unavailable.
+func (e *StatusError) Retryable() bool {
+	switch e.Code {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// ErrorForStatus makes [Request.Send] return a [StatusError] for 4xx and 5xx responses, instead of a [Response].
+// The [StatusError] Message is the start of the response body, and the body is closed.
+func (r *Request) ErrorForStatus() *Request {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.statusErrors = true
+	return r
+}
+
+func responseStatusError(resp *http.Response) error {
+	defer func() {
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		_ = resp.Body.Close()
+	}()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	return &StatusError{
+		Code:    resp.StatusCode,
+		Message: strings.TrimSpace(string(msg)),
+	}
+}
+
+// StatusCode returns the code of the [StatusError] in the error's chain, or false if there is none.
+func StatusCode(err error) (int, bool) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code, true
+	}
+	return 0, false
+}
+
+func hasStatus(err error, code int) bool {
+	actual, ok := StatusCode(err)
+	return ok && actual == code
+}
+
+// IsBadRequest reports whether the error is a [StatusError] with [http.StatusBadRequest].
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized reports whether the error is a [StatusError] with [http.StatusUnauthorized].
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether the error is a [StatusError] with [http.StatusForbidden].
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsNotFound reports whether the error is a [StatusError] with [http.StatusNotFound].
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether the error is a [StatusError] with [http.StatusConflict].
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsTooManyRequests reports whether the error is a [StatusError] with [http.StatusTooManyRequests].
+func IsTooManyRequests(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsRetryable reports whether the error is a [StatusError] that is [StatusError.Retryable].
+func IsRetryable(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Retryable()
+}
@@ -0,0 +1,103 @@
+package httpx
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusError(t *testing.T) {
+	err := NewStatusError(http.StatusNotFound, "no user %d", 5)
+	assert.Equal(t, "client error: status 404 Not Found: no user 5", err.Error())
+	assert.ErrorIs(t, err, ErrClientError)
+	assert.NotErrorIs(t, err, ErrServerError)
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsConflict(err))
+	assert.False(t, err.Retryable())
+
+	cause := errors.New("database unavailable")
+	wrapped := WrapStatus(http.StatusServiceUnavailable, cause)
+	assert.ErrorIs(t, wrapped, ErrServerError)
+	assert.ErrorIs(t, wrapped, cause)
+	assert.True(t, IsRetryable(wrapped))
+
+	code, ok := StatusCode(errors.Join(errors.New("other"), wrapped))
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	_, ok = StatusCode(cause)
+	assert.False(t, ok)
+}
+
+func TestRequest_ErrorForStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ok" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, status, err := GetRequest(srv.URL + "/limited").ErrorForStatus().Send()
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.True(t, IsTooManyRequests(err))
+	assert.True(t, IsRetryable(err))
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, "slow down", statusErr.Message)
+
+	resp, status, err := GetRequest(srv.URL + "/ok").ErrorForStatus().Send()
+	require.NoError(t, err)
+	_ = resp.Close()
+	assert.Equal(t, http.StatusOK, status)
+
+	resp, status, err = GetRequest(srv.URL + "/limited").Send()
+	require.NoError(t, err, "Unsuccessful responses should not be errors by default")
+	_ = resp.Close()
+	assert.Equal(t, http.StatusTooManyRequests, status)
+}
+
+func TestHandleJSON_StatusError(t *testing.T) {
+	var handledErr error
+	errHandler := JSONErrorHandler[TestErrorType](func(err error) TestErrorType {
+		handledErr = err
+		return TestErrorType{Error: err.Error()}
+	})
+	handler := HandleJSON(errHandler, func(req *TestRequestType) (*TestResponseType, error) {
+		switch req.Word {
+		case "missing":
+			return nil, NewStatusError(http.StatusNotFound, "no word")
+		case "taken":
+			return nil, WrapStatus(http.StatusConflict, errors.New("already exists"))
+		default:
+			return nil, errors.New("unexpected")
+		}
+	})
+
+	tests := map[string]int{
+		"missing": http.StatusNotFound,
+		"taken":   http.StatusConflict,
+		"other":   http.StatusInternalServerError,
+	}
+	for word, expected := range tests {
+		t.Run(word, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			body := bytes.NewBufferString(`{"data":"` + word + `"}`)
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", body))
+			assert.Equal(t, expected, rec.Code)
+			assert.Equal(t, ContentTypeJSON, rec.Header().Get(HeaderContentType))
+			code, ok := StatusCode(handledErr)
+			if expected == http.StatusInternalServerError {
+				assert.False(t, ok)
+				assert.ErrorIs(t, handledErr, ErrServerError)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, expected, code)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package httpx
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timings records how long each phase of an outgoing request took, using [httptrace].
+// Phases that didn't happen, like DNS for an IP address or connecting for a reused connection, have a zero duration.
+type Timings struct {
+	DNS             time.Duration // DNS is the time spent resolving the host name.
+	Connect         time.Duration // Connect is the time spent establishing the TCP connection.
+	TLSHandshake    time.Duration // TLSHandshake is the time spent in the TLS handshake.
+	TimeToFirstByte time.Duration // TimeToFirstByte is the time from starting the request to receiving the first response byte.
+	Total           time.Duration // Total is the time from starting the request to receiving the response headers, and doesn't include reading the body.
+	ConnReused      bool          // ConnReused is true if a pooled connection was used.
+}
+
+type timingRecorder struct {
+	mux                sync.Mutex
+	start              time.Time
+	dnsStart, dnsDone  time.Time
+	connStart, connEnd time.Time
+	tlsStart, tlsDone  time.Time
+	firstByte          time.Time
+	reused             bool
+}
+
+func (rec *timingRecorder) mark(t *time.Time) func() {
+	return func() {
+		rec.mux.Lock()
+		defer rec.mux.Unlock()
+		*t = time.Now()
+	}
+}
+
+// traceRequest returns a copy of the request that records timings with the returned recorder.
+func traceRequest(req *http.Request) (*http.Request, *timingRecorder) {
+	rec := &timingRecorder{start: time.Now()}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			rec.mark(&rec.dnsStart)()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			rec.mark(&rec.dnsDone)()
+		},
+		ConnectStart: func(_, _ string) {
+			rec.mux.Lock()
+			defer rec.mux.Unlock()
+			// Multiple addresses may be attempted, so keep the first start.
+			if rec.connStart.IsZero() {
+				rec.connStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				rec.mark(&rec.connEnd)()
+			}
+		},
+		TLSHandshakeStart: rec.mark(&rec.tlsStart),
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			rec.mark(&rec.tlsDone)()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			rec.mux.Lock()
+			defer rec.mux.Unlock()
+			rec.reused = info.Reused
+		},
+		GotFirstResponseByte: rec.mark(&rec.firstByte),
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), rec
+}
+
+func span(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+func (rec *timingRecorder) timings() Timings {
+	rec.mux.Lock()
+	defer rec.mux.Unlock()
+	return Timings{
+		DNS:             span(rec.dnsStart, rec.dnsDone),
+		Connect:         span(rec.connStart, rec.connEnd),
+		TLSHandshake:    span(rec.tlsStart, rec.tlsDone),
+		TimeToFirstByte: span(rec.start, rec.firstByte),
+		Total:           time.Since(rec.start),
+		ConnReused:      rec.reused,
+	}
+}
+
+// Trace enables recording [Timings] for this request, which are available with [Response.Timings] after [Request.Send].
+func (r *Request) Trace() *Request {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.trace = true
+	return r
+}
+
+// Timings returns the [Timings] recorded for the request.
+// False is returned if [Request.Trace] wasn't called before sending the request.
+func (r *Response) Timings() (Timings, bool) {
+	if r.timings == nil {
+		return Timings{}, false
+	}
+	return *r.timings, true
+}
+
+// TimingFunc is called with the [Timings] of a completed request.
+type TimingFunc func(req *http.Request, timings Timings)
+
+type traceTransport struct {
+	next     http.RoundTripper
+	onTiming TimingFunc
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, rec := traceRequest(req)
+	resp, err := t.next.RoundTrip(req)
+	t.onTiming(req, rec.timings())
+	return resp, err
+}
+
+// TraceTransport wraps an [http.RoundTripper] so the [Timings] of every request sent through it are passed to onTiming.
+// This can be used with any [http.Client] to log slow external calls or record connection pool metrics.
+// If next is nil, then [http.DefaultTransport] is used.
+//
+//	client := &http.Client{
+//		Transport: httpx.TraceTransport(nil, func(req *http.Request, timings httpx.Timings) {
+//			if timings.Total > time.Second {
+//				slog.Warn("Slow request", "url", req.URL.String(), "total", timings.Total)
+//			}
+//		}),
+//	}
+func TraceTransport(next http.RoundTripper, onTiming TimingFunc) http.RoundTripper {
+	if onTiming == nil {
+		panic("nil timing func")
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &traceTransport{next: next, onTiming: onTiming}
+}
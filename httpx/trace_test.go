@@ -0,0 +1,57 @@
+package httpx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequest_Trace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	resp, _, err := GetRequest(srv.URL).Send()
+	require.NoError(t, err)
+	_, err = resp.String()
+	require.NoError(t, err)
+	_, ok := resp.Timings()
+	assert.False(t, ok, "Timings should not be recorded without tracing")
+
+	resp, status, err := GetRequest(srv.URL).Trace().Send()
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Close()
+	}()
+	assert.Equal(t, 200, status)
+	timings, ok := resp.Timings()
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, timings.TimeToFirstByte, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, timings.Total, timings.TimeToFirstByte)
+	assert.True(t, timings.ConnReused, "Should have reused the first request's connection")
+}
+
+func TestTraceTransport(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	var recorded []Timings
+	client := srv.Client()
+	client.Transport = TraceTransport(client.Transport, func(req *http.Request, timings Timings) {
+		recorded = append(recorded, timings)
+	})
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Len(t, recorded, 1)
+	assert.Greater(t, recorded[0].Connect, time.Duration(0))
+	assert.Greater(t, recorded[0].TLSHandshake, time.Duration(0))
+	assert.False(t, recorded[0].ConnReused)
+}
@@ -0,0 +1,302 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/idx"
+	"github.com/saylorsolutions/x/patterns/retry"
+	"github.com/saylorsolutions/x/structures/queue"
+	"github.com/saylorsolutions/x/syncx"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// HeaderWebhookID is a unique ID for a webhook delivery, which is the same for each attempt.
+	// Receivers may use this to de-duplicate deliveries.
+	HeaderWebhookID = "X-Webhook-ID"
+	// HeaderWebhookAttempt is the 1-based attempt number of a webhook delivery.
+	HeaderWebhookAttempt = "X-Webhook-Attempt"
+	// HeaderWebhookEvent is the optional event name of a webhook delivery.
+	HeaderWebhookEvent = "X-Webhook-Event"
+)
+
+var (
+	ErrWebhookDelivery = errors.New("webhook delivery failed")
+	ErrWebhookStopped  = errors.New("webhook sender is stopped")
+)
+
+// Webhook is a single payload to be delivered to a subscriber.
+type Webhook struct {
+	URL         string
+	Event       string // Event is sent in the HeaderWebhookEvent header if not empty.
+	ContentType string // ContentType defaults to application/json.
+	Payload     []byte
+}
+
+// DeliveryAttempt records the outcome of one attempt to deliver a [Webhook].
+type DeliveryAttempt struct {
+	Attempt    int
+	StatusCode int // StatusCode is 0 if no response was received.
+	Err        error
+	Started    time.Time
+	Duration   time.Duration
+}
+
+// DeliveryResult is the final outcome of delivering a [Webhook].
+type DeliveryResult struct {
+	ID         string
+	Webhook    Webhook
+	StatusCode int
+	Attempts   []DeliveryAttempt
+}
+
+type webhookJob struct {
+	id     string
+	hook   Webhook
+	result syncx.FutureErr[DeliveryResult]
+}
+
+type webhookConf struct {
+	signer     *Signer
+	settings   retry.Settings
+	numWorkers int
+	client     *http.Client
+}
+
+type WebhookOption func(conf *webhookConf) error
+
+// OptWebhookSigner signs each delivery attempt with the given [Signer].
+func OptWebhookSigner(signer *Signer) WebhookOption {
+	return func(conf *webhookConf) error {
+		if signer == nil {
+			return errors.New("nil signer")
+		}
+		conf.signer = signer
+		return nil
+	}
+}
+
+// OptWebhookRetry sets the retry behavior for failed deliveries.
+// The Context field is ignored, the [WebhookSender] context is used instead.
+// Defaults to 5 tries, starting at 1 second between tries and doubling each time.
+func OptWebhookRetry(settings retry.Settings) WebhookOption {
+	return func(conf *webhookConf) error {
+		if settings.MaxTries <= 1 {
+			return fmt.Errorf("%w: max tries should be > 1", retry.ErrInvalidSettings)
+		}
+		conf.settings = settings.Copy()
+		return nil
+	}
+}
+
+// OptWebhookWorkers sets the number of goroutines delivering webhooks concurrently.
+// Defaults to 4.
+func OptWebhookWorkers(num int) WebhookOption {
+	return func(conf *webhookConf) error {
+		if num < 1 {
+			return fmt.Errorf("number of workers '%d' is invalid, must be >= 1", num)
+		}
+		conf.numWorkers = num
+		return nil
+	}
+}
+
+// OptWebhookClient sets the [http.Client] used for deliveries.
+// Defaults to a client with a 30 second timeout.
+func OptWebhookClient(client *http.Client) WebhookOption {
+	return func(conf *webhookConf) error {
+		if client == nil {
+			return errors.New("nil client")
+		}
+		conf.client = client
+		return nil
+	}
+}
+
+// WebhookSender delivers webhooks in the background, retrying with backoff when a delivery fails.
+// Deliveries are queued, so sending never blocks on network activity.
+//
+// A delivery is considered failed if the request can't be sent, or the response status is 429 or 5xx.
+// Other 4xx responses are not retried, since they're unlikely to succeed later.
+type WebhookSender struct {
+	conf    webhookConf
+	ctx     context.Context
+	cancel  context.CancelFunc
+	queue   *queue.ChannelQueue[*webhookJob]
+	workers sync.WaitGroup
+}
+
+// NewWebhookSender creates a [WebhookSender] and starts its workers.
+// Cancelling the context stops the sender, see [WebhookSender.Stop].
+func NewWebhookSender(ctx context.Context, opts ...WebhookOption) (*WebhookSender, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	conf := webhookConf{
+		settings: retry.Settings{
+			TimeBetweenRetries: time.Second,
+			BackoffFactor:      2,
+			MaxTries:           5,
+		},
+		numWorkers: 4,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	q, err := queue.NewChannelQueue[*webhookJob](ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	conf.settings.Context = ctx
+	s := &WebhookSender{
+		conf:   conf,
+		ctx:    ctx,
+		cancel: cancel,
+		queue:  q,
+	}
+	s.workers.Add(conf.numWorkers)
+	for i := 0; i < conf.numWorkers; i++ {
+		go s.worker()
+	}
+	return s, nil
+}
+
+// Send queues the webhook for delivery.
+// The returned [syncx.FutureErr] resolves with the result after the final attempt.
+// The error wraps [ErrWebhookDelivery] if delivery ultimately failed.
+func (s *WebhookSender) Send(hook Webhook) syncx.FutureErr[DeliveryResult] {
+	return s.SendRanked(hook, 0)
+}
+
+// SendRanked is the same as [WebhookSender.Send], but queued deliveries with a higher ranking are sent first.
+func (s *WebhookSender) SendRanked(hook Webhook, ranking uint) syncx.FutureErr[DeliveryResult] {
+	job := &webhookJob{
+		id:     idx.NewULID().String(),
+		hook:   hook,
+		result: syncx.NewFutureErr[DeliveryResult](),
+	}
+	if len(job.hook.ContentType) == 0 {
+		job.hook.ContentType = "application/json"
+	}
+	if _, err := url.Parse(job.hook.URL); err != nil {
+		job.result.ResolveErr(DeliveryResult{ID: job.id, Webhook: job.hook}, fmt.Errorf("%w: invalid URL: %w", ErrWebhookDelivery, err))
+		return job.result
+	}
+	if !s.queue.PushRanked(job, ranking) {
+		job.result.ResolveErr(DeliveryResult{ID: job.id, Webhook: job.hook}, ErrWebhookStopped)
+	}
+	return job.result
+}
+
+// SendJSON marshals the payload to JSON and queues it for delivery with [WebhookSender.Send].
+func (s *WebhookSender) SendJSON(url, event string, payload any) syncx.FutureErr[DeliveryResult] {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return syncx.StaticFutureErr(DeliveryResult{}, err)
+	}
+	return s.Send(Webhook{
+		URL:     url,
+		Event:   event,
+		Payload: data,
+	})
+}
+
+// Stop stops accepting new deliveries.
+// Deliveries that are already queued resolve with the context error rather than being attempted.
+func (s *WebhookSender) Stop() {
+	s.cancel()
+}
+
+// AwaitStop calls [WebhookSender.Stop] and waits for all workers to exit.
+func (s *WebhookSender) AwaitStop() {
+	s.Stop()
+	s.queue.Await()
+	s.workers.Wait()
+}
+
+// Pending returns the number of queued deliveries that haven't been started.
+func (s *WebhookSender) Pending() int {
+	return s.queue.Len()
+}
+
+func (s *WebhookSender) worker() {
+	defer s.workers.Done()
+	for job := range s.queue.C {
+		result, err := s.deliver(job)
+		job.result.ResolveErr(result, err)
+	}
+}
+
+func (s *WebhookSender) deliver(job *webhookJob) (DeliveryResult, error) {
+	result := DeliveryResult{
+		ID:      job.id,
+		Webhook: job.hook,
+	}
+	err := retry.WithSettings(s.conf.settings.Copy(), func() (bool, error) {
+		attempt := s.attempt(job, len(result.Attempts)+1)
+		result.Attempts = append(result.Attempts, attempt)
+		result.StatusCode = attempt.StatusCode
+		if attempt.Err != nil {
+			return true, attempt.Err
+		}
+		switch {
+		case attempt.StatusCode == http.StatusTooManyRequests || attempt.StatusCode >= 500:
+			return true, fmt.Errorf("received status %d", attempt.StatusCode)
+		case attempt.StatusCode >= 400:
+			return false, fmt.Errorf("received status %d", attempt.StatusCode)
+		default:
+			return false, nil
+		}
+	})
+	if err != nil {
+		return result, fmt.Errorf("%w: %w", ErrWebhookDelivery, err)
+	}
+	return result, nil
+}
+
+func (s *WebhookSender) attempt(job *webhookJob, num int) DeliveryAttempt {
+	attempt := DeliveryAttempt{
+		Attempt: num,
+		Started: time.Now(),
+	}
+	r := PostRequest(job.hook.URL).
+		WithContext(s.ctx).
+		BytesBody(job.hook.Payload).
+		SetHeader("Content-Type", job.hook.ContentType).
+		SetHeader(HeaderWebhookID, job.id).
+		SetHeader(HeaderWebhookAttempt, strconv.Itoa(num))
+	if len(job.hook.Event) > 0 {
+		r.SetHeader(HeaderWebhookEvent, job.hook.Event)
+	}
+	if s.conf.signer != nil {
+		r.SignHMAC(s.conf.signer)
+	}
+	req, err := r.StdRequest()
+	if err != nil {
+		attempt.Err = err
+		return attempt
+	}
+	resp, err := s.conf.client.Do(req)
+	attempt.Duration = time.Since(attempt.Started)
+	if err != nil {
+		attempt.Err = err
+		return attempt
+	}
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+	attempt.StatusCode = resp.StatusCode
+	return attempt
+}
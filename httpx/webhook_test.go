@@ -0,0 +1,111 @@
+package httpx
+
+import (
+	"context"
+	"github.com/saylorsolutions/x/patterns/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWebhookSender_Send(t *testing.T) {
+	signer := NewSigner([]byte("secret"))
+	var (
+		calls    atomic.Int32
+		verified atomic.Bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get(HeaderSignatureTimestamp), 10, 64)
+		verified.Store(signer.Verify(r.Method, r.URL.RequestURI(), ts, r.Header.Get(HeaderSignatureNonce), body, r.Header.Get(HeaderSignature)))
+		assert.Equal(t, "created", r.Header.Get(HeaderWebhookEvent))
+		assert.Equal(t, strconv.Itoa(int(n)), r.Header.Get(HeaderWebhookAttempt))
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sender, err := NewWebhookSender(context.Background(),
+		OptWebhookSigner(signer),
+		OptWebhookRetry(retry.Settings{TimeBetweenRetries: time.Millisecond, BackoffFactor: 1, MaxTries: 3}),
+	)
+	require.NoError(t, err)
+	defer sender.AwaitStop()
+
+	result, err := sender.SendJSON(srv.URL, "created", TestJSONType{Name: "bob"}).AwaitErr(5 * time.Second)
+	require.NoError(t, err)
+	assert.True(t, verified.Load())
+	assert.Equal(t, http.StatusNoContent, result.StatusCode)
+	assert.NotEmpty(t, result.ID)
+	require.Len(t, result.Attempts, 3)
+	assert.Equal(t, http.StatusServiceUnavailable, result.Attempts[0].StatusCode)
+	assert.Equal(t, 3, result.Attempts[2].Attempt)
+}
+
+func TestWebhookSender_Failure(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	sender, err := NewWebhookSender(context.Background(),
+		OptWebhookRetry(retry.Settings{BackoffFactor: 1, MaxTries: 2}),
+		OptWebhookWorkers(1),
+	)
+	require.NoError(t, err)
+	defer sender.AwaitStop()
+
+	result, err := sender.Send(Webhook{URL: srv.URL, Payload: []byte(`{}`)}).AwaitErr(5 * time.Second)
+	assert.ErrorIs(t, err, ErrWebhookDelivery)
+	assert.ErrorIs(t, err, retry.ErrMaxRetries)
+	assert.Len(t, result.Attempts, 2)
+
+	calls.Store(0)
+	result, err = sender.Send(Webhook{URL: srv.URL + "/gone", Payload: []byte(`{}`)}).AwaitErr(5 * time.Second)
+	assert.ErrorIs(t, err, ErrWebhookDelivery)
+	assert.Len(t, result.Attempts, 1, "Client errors should not be retried")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestWebhookSender_Stopped(t *testing.T) {
+	sender, err := NewWebhookSender(context.Background())
+	require.NoError(t, err)
+	sender.AwaitStop()
+
+	_, err = sender.Send(Webhook{URL: "http://localhost"}).AwaitErr(time.Second)
+	assert.ErrorIs(t, err, ErrWebhookStopped)
+}
+
+func TestWebhookSender_InvalidURL(t *testing.T) {
+	sender, err := NewWebhookSender(context.Background())
+	require.NoError(t, err)
+	defer sender.AwaitStop()
+
+	_, err = sender.Send(Webhook{URL: "http://bad host/%zz"}).AwaitErr(time.Second)
+	assert.ErrorIs(t, err, ErrWebhookDelivery)
+}
+
+func TestNewWebhookSender_Options(t *testing.T) {
+	_, err := NewWebhookSender(context.Background(), OptWebhookWorkers(0))
+	assert.Error(t, err)
+	_, err = NewWebhookSender(context.Background(), OptWebhookRetry(retry.Settings{MaxTries: 1}))
+	assert.ErrorIs(t, err, retry.ErrInvalidSettings)
+	_, err = NewWebhookSender(context.Background(), OptWebhookSigner(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package httpx
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrContentType = errors.New("unexpected content type")
+	ContentTypeXML = "application/xml" // This can be used to customize the content type sent in XML requests.
+)
+
+// XMLBody marshals the body as XML, including the standard XML header, and sets the content type to [ContentTypeXML].
+func (r *Request) XMLBody(body any) *Request {
+	data, err := xml.Marshal(body)
+	if err != nil {
+		r.mux.Lock()
+		defer r.mux.Unlock()
+		r.err = err
+		return r
+	}
+	r.SetHeader(HeaderContentType, ContentTypeXML)
+	r.BytesBody(append([]byte(xml.Header), data...))
+	return r
+}
+
+// ReadXML decodes the XML response body into a new T.
+// If the response specifies a content type that isn't XML, then an error wrapping [ErrContentType] is returned.
+//
+// UTF-8, US-ASCII, and ISO-8859-1 encoded documents are supported.
+func ReadXML[T any](r *Response) (*T, error) {
+	return readXML[T](r, true)
+}
+
+// ReadXMLLenient is the same as [ReadXML], except that the decoder is not strict.
+// This allows common HTML-isms like unclosed tags, unquoted attributes, and HTML entities, which some legacy services produce.
+func ReadXMLLenient[T any](r *Response) (*T, error) {
+	return readXML[T](r, false)
+}
+
+func readXML[T any](r *Response, strict bool) (*T, error) {
+	if ct, ok := r.GetHeader(HeaderContentType); ok && !isXMLContentType(ct) {
+		return nil, fmt.Errorf("%w: expected XML, got '%s'", ErrContentType, ct)
+	}
+	reader, err := r.Body()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	dec := xml.NewDecoder(reader)
+	dec.CharsetReader = xmlCharsetReader
+	if !strict {
+		dec.Strict = false
+		dec.AutoClose = xml.HTMLAutoClose
+		dec.Entity = xml.HTMLEntity
+	}
+	var val T
+	if err := dec.Decode(&val); err != nil {
+		return nil, err
+	}
+	return &val, nil
+}
+
+func isXMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// xmlCharsetReader converts single byte charsets commonly used by legacy services to UTF-8.
+func xmlCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "us-ascii", "ascii", "iso-8859-1", "latin1", "latin-1":
+		return &latin1Reader{r: bufio.NewReader(input)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported XML charset '%s'", charset)
+	}
+}
+
+type latin1Reader struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+func (l *latin1Reader) Read(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		if len(l.buf) > 0 {
+			c := copy(p[n:], l.buf)
+			l.buf = l.buf[c:]
+			n += c
+			continue
+		}
+		b, err := l.r.ReadByte()
+		if err != nil {
+			if n > 0 && errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, err
+		}
+		if b < utf8.RuneSelf {
+			p[n] = b
+			n++
+			continue
+		}
+		l.buf = utf8.AppendRune(l.buf[:0], rune(b))
+	}
+	return n, nil
+}
@@ -0,0 +1,85 @@
+package httpx
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type TestXMLType struct {
+	XMLName xml.Name `xml:"person"`
+	Name    string   `xml:"name"`
+}
+
+func TestRequest_XMLBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ContentTypeXML, r.Header.Get(HeaderContentType))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, xml.Header+"<person><name>bob</name></person>", string(body))
+		w.Header().Set(HeaderContentType, "text/xml; charset=utf-8")
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	resp, _, err := PostRequest(srv.URL).XMLBody(TestXMLType{Name: "bob"}).Send()
+	require.NoError(t, err)
+	val, err := ReadXML[TestXMLType](resp)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", val.Name)
+
+	_, _, err = PostRequest(srv.URL).XMLBody(make(chan int)).Send()
+	assert.Error(t, err, "Marshal errors should be returned")
+}
+
+func TestReadXML(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		body        string
+		lenient     bool
+		expected    string
+		err         error
+	}{
+		"No content type":    {"", "<person><name>bob</name></person>", false, "bob", nil},
+		"Vendor type":        {"application/soap+xml", "<person><name>bob</name></person>", false, "bob", nil},
+		"Wrong content type": {"application/json", `{"name": "bob"}`, false, "", ErrContentType},
+		"Latin-1":            {"application/xml", "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><person><name>Jos\xe9</name></person>", false, "José", nil},
+		"Lenient entities":   {"application/xml", "<person><name>a&nbsp;b</name></person>", true, "a b", nil},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(HeaderContentType, tc.contentType)
+				_, _ = io.WriteString(w, tc.body)
+			}))
+			defer srv.Close()
+			resp, _, err := GetRequest(srv.URL).Send()
+			require.NoError(t, err)
+			read := ReadXML[TestXMLType]
+			if tc.lenient {
+				read = ReadXMLLenient[TestXMLType]
+			}
+			val, err := read(resp)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, val.Name)
+		})
+	}
+}
+
+func TestReadXML_Strict(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "<person><name>a&nbsp;b</name></person>")
+	}))
+	defer srv.Close()
+	resp, _, err := GetRequest(srv.URL).Send()
+	require.NoError(t, err)
+	_, err = ReadXML[TestXMLType](resp)
+	assert.Error(t, err, "HTML entities are invalid in strict mode")
+}
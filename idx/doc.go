@@ -0,0 +1,10 @@
+// Package idx provides unique ID generation.
+//
+// The available ID types are:
+//   - [ULID]: 128-bit, lexically sortable by creation time, encoded as 26 Crockford base32 characters.
+//   - [KSUID]: 160-bit, sortable by creation time with second precision, encoded as 27 base62 characters.
+//   - [UUID]: RFC 9562 UUIDs, either random (version 4) or time-ordered (version 7).
+//   - Short IDs: Random, human-readable Crockford base32 strings with a trailing check symbol to catch typos, see [NewShortID].
+//
+// All randomness comes from [crypto/rand].
+package idx
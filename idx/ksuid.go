@@ -0,0 +1,109 @@
+package idx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidLength    = 27
+	// KSUIDEpoch is the Unix time (in seconds) that KSUID timestamps are relative to.
+	KSUIDEpoch = 1400000000
+)
+
+var (
+	ErrInvalidKSUID = errors.New("invalid KSUID")
+
+	base62   = big.NewInt(62)
+	maxKSUID = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+)
+
+// KSUID is a K-Sortable Unique IDentifier.
+// The first 32 bits are a timestamp in seconds since [KSUIDEpoch], and the remaining 128 bits are random.
+// The string form is always 27 base62 characters, so string and byte ordering are the same.
+type KSUID [20]byte
+
+// NewKSUID creates a new [KSUID] with the current time.
+func NewKSUID() KSUID {
+	return NewKSUIDAt(time.Now())
+}
+
+// NewKSUIDAt creates a new [KSUID] with the given time.
+// Times before [KSUIDEpoch], or more than 2^32 seconds after it, cannot be represented and will panic.
+func NewKSUIDAt(t time.Time) KSUID {
+	ts := t.Unix() - KSUIDEpoch
+	if ts < 0 || ts > 1<<32-1 {
+		panic("idx: time is outside of the KSUID range")
+	}
+	var id KSUID
+	binary.BigEndian.PutUint32(id[:4], uint32(ts))
+	readRandom(id[4:])
+	return id
+}
+
+// ParseKSUID parses the string form of a [KSUID].
+func ParseKSUID(s string) (KSUID, error) {
+	var id KSUID
+	if len(s) != ksuidLength {
+		return id, fmt.Errorf("%w: expected %d characters, got %d", ErrInvalidKSUID, ksuidLength, len(s))
+	}
+	val := new(big.Int)
+	digit := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		pos := strings.IndexByte(base62Alphabet, s[i])
+		if pos < 0 {
+			return id, fmt.Errorf("%w: invalid character '%c'", ErrInvalidKSUID, s[i])
+		}
+		val.Mul(val, base62)
+		val.Add(val, digit.SetInt64(int64(pos)))
+	}
+	if val.Cmp(maxKSUID) > 0 {
+		return id, fmt.Errorf("%w: value overflows 160 bits", ErrInvalidKSUID)
+	}
+	val.FillBytes(id[:])
+	return id, nil
+}
+
+// Time returns the timestamp portion of the [KSUID].
+func (id KSUID) Time() time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint32(id[:4]))+KSUIDEpoch, 0)
+}
+
+// Payload returns the random portion of the [KSUID].
+func (id KSUID) Payload() []byte {
+	return id[4:]
+}
+
+// IsZero returns true if this is the zero value of a [KSUID].
+func (id KSUID) IsZero() bool {
+	return id == KSUID{}
+}
+
+func (id KSUID) String() string {
+	val := new(big.Int).SetBytes(id[:])
+	mod := new(big.Int)
+	var out [ksuidLength]byte
+	for i := ksuidLength - 1; i >= 0; i-- {
+		val.DivMod(val, base62, mod)
+		out[i] = base62Alphabet[mod.Int64()]
+	}
+	return string(out[:])
+}
+
+func (id KSUID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *KSUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseKSUID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
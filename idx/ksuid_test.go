@@ -0,0 +1,48 @@
+package idx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestKSUID_RoundTrip(t *testing.T) {
+	id := NewKSUID()
+	str := id.String()
+	assert.Len(t, str, 27)
+	parsed, err := ParseKSUID(str)
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+	assert.WithinDuration(t, time.Now(), id.Time(), 2*time.Second)
+	assert.Len(t, id.Payload(), 16)
+}
+
+func TestKSUID_Bounds(t *testing.T) {
+	assert.Equal(t, "000000000000000000000000000", KSUID{}.String())
+	var max KSUID
+	for i := range max {
+		max[i] = 0xFF
+	}
+	assert.Equal(t, "aWgEPTl1tmebfsQzFP4bxwgy80V", max.String())
+	parsed, err := ParseKSUID("aWgEPTl1tmebfsQzFP4bxwgy80V")
+	require.NoError(t, err)
+	assert.Equal(t, max, parsed)
+
+	_, err = ParseKSUID("aWgEPTl1tmebfsQzFP4bxwgy80W")
+	assert.ErrorIs(t, err, ErrInvalidKSUID)
+	_, err = ParseKSUID("short")
+	assert.ErrorIs(t, err, ErrInvalidKSUID)
+	_, err = ParseKSUID("00000000000000000000000000-")
+	assert.ErrorIs(t, err, ErrInvalidKSUID)
+}
+
+func TestKSUID_Sortable(t *testing.T) {
+	a := NewKSUIDAt(time.Unix(KSUIDEpoch+100, 0))
+	b := NewKSUIDAt(time.Unix(KSUIDEpoch+101, 0))
+	assert.Less(t, a.String(), b.String())
+	assert.Equal(t, int64(KSUIDEpoch+100), a.Time().Unix())
+	assert.Panics(t, func() {
+		NewKSUIDAt(time.Unix(KSUIDEpoch-1, 0))
+	})
+}
@@ -0,0 +1,16 @@
+package idx
+
+import (
+	"crypto/rand"
+	"io"
+)
+
+// randReader is the source of randomness for all IDs, and may be replaced in tests.
+var randReader io.Reader = rand.Reader
+
+func readRandom(buf []byte) {
+	if _, err := io.ReadFull(randReader, buf); err != nil {
+		// crypto/rand is documented to never fail on supported platforms.
+		panic("idx: failed to read random bytes: " + err.Error())
+	}
+}
@@ -0,0 +1,77 @@
+package idx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// crockfordCheckAlphabet extends the Crockford base32 alphabet with the 5 extra symbols used for mod 37 check symbols.
+const crockfordCheckAlphabet = crockfordAlphabet + "*~$=U"
+
+var (
+	ErrInvalidShortID = errors.New("invalid short ID")
+	ErrShortIDCheck   = errors.New("short ID check symbol mismatch")
+)
+
+// NewShortID creates a random, human-readable ID with the given number of Crockford base32 characters, followed by a check symbol.
+// Each character carries 5 bits of randomness, so a length of 10 gives 50 random bits.
+//
+// Crockford base32 avoids the easily confused letters I, L, O, and U, which makes these IDs suitable for reading aloud or typing by hand.
+// Use [NormalizeShortID] to validate user input.
+func NewShortID(length int) string {
+	if length <= 0 {
+		panic("idx: short ID length must be positive")
+	}
+	buf := make([]byte, length)
+	readRandom(buf)
+	var (
+		sb  strings.Builder
+		sum int
+	)
+	sb.Grow(length + 1)
+	for _, b := range buf {
+		v := int(b & 0x1F)
+		sb.WriteByte(crockfordAlphabet[v])
+		sum = (sum*32 + v) % 37
+	}
+	sb.WriteByte(crockfordCheckAlphabet[sum])
+	return sb.String()
+}
+
+// NormalizeShortID validates an ID created with [NewShortID], returning its canonical form.
+// Hyphens and spaces are ignored, so IDs may be grouped for display, and case is ignored.
+// The letters I and L are read as 1, and O as 0.
+//
+// An error wrapping [ErrShortIDCheck] is returned if the check symbol doesn't match, which usually means a character was mistyped.
+func NormalizeShortID(id string) (string, error) {
+	var sb strings.Builder
+	sb.Grow(len(id))
+	for i := 0; i < len(id); i++ {
+		if id[i] == '-' || id[i] == ' ' {
+			continue
+		}
+		sb.WriteByte(id[i])
+	}
+	norm := strings.ToUpper(sb.String())
+	if len(norm) < 2 {
+		return "", fmt.Errorf("%w: too short", ErrInvalidShortID)
+	}
+	body, check := norm[:len(norm)-1], norm[len(norm)-1]
+	sb.Reset()
+	var sum int
+	for i := 0; i < len(body); i++ {
+		v := crockfordValues[body[i]]
+		if v == 0xFF {
+			return "", fmt.Errorf("%w: invalid character '%c'", ErrInvalidShortID, body[i])
+		}
+		sb.WriteByte(crockfordAlphabet[v])
+		sum = (sum*32 + int(v)) % 37
+	}
+	expected := crockfordCheckAlphabet[sum]
+	if check != expected && !(expected == '1' && (check == 'I' || check == 'L')) && !(expected == '0' && check == 'O') {
+		return "", ErrShortIDCheck
+	}
+	sb.WriteByte(expected)
+	return sb.String(), nil
+}
@@ -0,0 +1,53 @@
+package idx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestNewShortID(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		id := NewShortID(8)
+		assert.Len(t, id, 9)
+		norm, err := NormalizeShortID(id)
+		require.NoError(t, err)
+		assert.Equal(t, id, norm)
+	}
+	assert.Panics(t, func() {
+		NewShortID(0)
+	})
+}
+
+func TestNormalizeShortID(t *testing.T) {
+	// 1*32 + 0 = 32, check symbol is '*' (32 % 37 = 32)
+	norm, err := NormalizeShortID("10*")
+	require.NoError(t, err)
+	assert.Equal(t, "10*", norm)
+
+	norm, err = NormalizeShortID("lo-*")
+	require.NoError(t, err)
+	assert.Equal(t, "10*", norm, "Confusable characters and separators should be normalized")
+
+	_, err = NormalizeShortID("11*")
+	assert.ErrorIs(t, err, ErrShortIDCheck)
+
+	_, err = NormalizeShortID("1U*")
+	assert.ErrorIs(t, err, ErrInvalidShortID)
+
+	_, err = NormalizeShortID("1")
+	assert.ErrorIs(t, err, ErrInvalidShortID)
+}
+
+func TestNormalizeShortID_Typo(t *testing.T) {
+	id := NewShortID(10)
+	// Replace a single character with a different one, which the check symbol should always catch.
+	replacement := "0"
+	if id[3] == '0' {
+		replacement = "1"
+	}
+	typo := id[:3] + replacement + id[4:]
+	_, err := NormalizeShortID(strings.ToLower(typo))
+	assert.ErrorIs(t, err, ErrShortIDCheck)
+}
@@ -0,0 +1,168 @@
+package idx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	ulidLength        = 26
+	maxULIDTime       = 1<<48 - 1
+)
+
+var (
+	ErrInvalidULID = errors.New("invalid ULID")
+)
+
+// crockfordValues maps encoded characters to their value, or 0xFF if invalid.
+// Lower case characters are accepted, as are the commonly confused I, L, and O.
+var crockfordValues = func() [256]byte {
+	var vals [256]byte
+	for i := range vals {
+		vals[i] = 0xFF
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		c := crockfordAlphabet[i]
+		vals[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			vals[c+'a'-'A'] = byte(i)
+		}
+	}
+	vals['I'], vals['i'], vals['L'], vals['l'] = 1, 1, 1, 1
+	vals['O'], vals['o'] = 0, 0
+	return vals
+}()
+
+// ULID is a Universally Unique Lexicographically Sortable Identifier.
+// The first 48 bits are a millisecond Unix timestamp, and the remaining 80 bits are random.
+//
+// ULIDs created by [NewULID] in the same process are strictly increasing, even within the same millisecond.
+type ULID [16]byte
+
+type ulidGenerator struct {
+	mux      sync.Mutex
+	lastTime uint64
+	lastRand [10]byte
+}
+
+var defaultULIDs ulidGenerator
+
+// NewULID creates a new [ULID] with the current time.
+func NewULID() ULID {
+	return defaultULIDs.next(time.Now())
+}
+
+// NewULIDAt creates a new [ULID] with the given time, and random bits that are not guaranteed to be monotonic.
+func NewULIDAt(t time.Time) ULID {
+	var id ULID
+	setULIDTime(&id, uint64(t.UnixMilli()))
+	readRandom(id[6:])
+	return id
+}
+
+func setULIDTime(id *ULID, ms uint64) {
+	if ms > maxULIDTime {
+		panic("idx: time is too far in the future for a ULID")
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], ms)
+	copy(id[:6], buf[2:])
+}
+
+func (g *ulidGenerator) next(t time.Time) ULID {
+	ms := uint64(t.UnixMilli())
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	if ms <= g.lastTime {
+		// Same millisecond (or the clock went backward), so increment the random portion instead.
+		ms = g.lastTime
+		if !incrementBytes(g.lastRand[:]) {
+			// Random bits overflowed, so move to the next millisecond.
+			ms++
+			readRandom(g.lastRand[:])
+		}
+	} else {
+		readRandom(g.lastRand[:])
+	}
+	g.lastTime = ms
+	var id ULID
+	setULIDTime(&id, ms)
+	copy(id[6:], g.lastRand[:])
+	return id
+}
+
+// incrementBytes treats buf as a big-endian integer and adds one, returning false if it overflowed.
+func incrementBytes(buf []byte) bool {
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i]++
+		if buf[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseULID parses the string form of a [ULID].
+// Parsing is case-insensitive.
+func ParseULID(s string) (ULID, error) {
+	var id ULID
+	if len(s) != ulidLength {
+		return id, fmt.Errorf("%w: expected %d characters, got %d", ErrInvalidULID, ulidLength, len(s))
+	}
+	var hi, lo uint64
+	for i := 0; i < ulidLength; i++ {
+		v := crockfordValues[s[i]]
+		if v == 0xFF {
+			return id, fmt.Errorf("%w: invalid character '%c'", ErrInvalidULID, s[i])
+		}
+		if i == 0 && v > 7 {
+			return id, fmt.Errorf("%w: value overflows 128 bits", ErrInvalidULID)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
+
+// Time returns the timestamp portion of the [ULID].
+func (id ULID) Time() time.Time {
+	var buf [8]byte
+	copy(buf[2:], id[:6])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(buf[:])))
+}
+
+// IsZero returns true if this is the zero value of a [ULID].
+func (id ULID) IsZero() bool {
+	return id == ULID{}
+}
+
+func (id ULID) String() string {
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	var out [ulidLength]byte
+	for i := ulidLength - 1; i >= 0; i-- {
+		out[i] = crockfordAlphabet[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+func (id ULID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
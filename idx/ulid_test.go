@@ -0,0 +1,63 @@
+package idx
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestULID_RoundTrip(t *testing.T) {
+	id := NewULID()
+	str := id.String()
+	assert.Len(t, str, 26)
+	parsed, err := ParseULID(str)
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+	assert.WithinDuration(t, time.Now(), id.Time(), time.Second)
+}
+
+func TestULID_Known(t *testing.T) {
+	id, err := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1469922850259), id.Time().UnixMilli())
+	lower, err := ParseULID("01arz3ndektsv4rrffq69g5fav")
+	require.NoError(t, err)
+	assert.Equal(t, id, lower)
+
+	max, err := ParseULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	require.NoError(t, err)
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", max.String())
+	assert.Equal(t, "00000000000000000000000000", ULID{}.String())
+}
+
+func TestParseULID_Invalid(t *testing.T) {
+	for _, input := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "01ARZ3NDEKTSV4RRFFQ69G5FAU", "80000000000000000000000000"} {
+		_, err := ParseULID(input)
+		assert.ErrorIs(t, err, ErrInvalidULID, input)
+	}
+}
+
+func TestULID_Monotonic(t *testing.T) {
+	var gen ulidGenerator
+	now := time.Now()
+	prev := gen.next(now)
+	for i := 0; i < 1000; i++ {
+		next := gen.next(now)
+		assert.Less(t, prev.String(), next.String())
+		prev = next
+	}
+	// Clock going backward should still produce increasing IDs.
+	next := gen.next(now.Add(-time.Second))
+	assert.Less(t, prev.String(), next.String())
+}
+
+func TestULID_JSON(t *testing.T) {
+	id := NewULID()
+	data, err := json.Marshal(id)
+	require.NoError(t, err)
+	var out ULID
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, id, out)
+}
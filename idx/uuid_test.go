@@ -0,0 +1,54 @@
+package idx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNewUUIDv4(t *testing.T) {
+	id := NewUUIDv4()
+	assert.Equal(t, 4, id.Version())
+	assert.Equal(t, byte(0x80), id[8]&0xC0)
+	_, ok := id.Time()
+	assert.False(t, ok)
+
+	parsed, err := ParseUUID(id.String())
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+}
+
+func TestNewUUIDv7(t *testing.T) {
+	ts := time.UnixMilli(1700000000123)
+	id := NewUUIDv7At(ts)
+	assert.Equal(t, 7, id.Version())
+	idTime, ok := id.Time()
+	require.True(t, ok)
+	assert.Equal(t, ts, idTime)
+
+	later := NewUUIDv7At(ts.Add(time.Millisecond))
+	assert.Less(t, id.String(), later.String())
+}
+
+func TestParseUUID(t *testing.T) {
+	expected := "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	for _, input := range []string{
+		expected,
+		"F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+		"f81d4fae7dec11d0a76500a0c91e6bf6",
+		"urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}",
+	} {
+		id, err := ParseUUID(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, id.String())
+		assert.Equal(t, 1, id.Version())
+	}
+
+	for _, input := range []string{"", "f81d4fae-7dec-11d0-a765", "f81d4fae_7dec_11d0_a765_00a0c91e6bf6", "g81d4fae-7dec-11d0-a765-00a0c91e6bf6"} {
+		_, err := ParseUUID(input)
+		assert.ErrorIs(t, err, ErrInvalidUUID, input)
+	}
+	assert.True(t, UUID{}.IsZero())
+}
@@ -0,0 +1,57 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrQueueFull = errors.New("event queue is full")
+)
+
+// OptMaxQueueDepth limits the number of dispatches that may be queued for a worker before [EventBus.TryDispatch] rejects new events with [ErrQueueFull].
+// This allows producers to apply backpressure or shed load instead of growing the queue without bound.
+//
+// Other dispatch methods are not limited, so that events like errors and replies are never dropped.
+func OptMaxQueueDepth(depth int) ConfigOption {
+	return func(conf *busConf) error {
+		if depth < 1 {
+			return fmt.Errorf("depth '%d' is invalid, must be >= 1", depth)
+		}
+		conf.maxQueueDepth = depth
+		return nil
+	}
+}
+
+// TryDispatch is the same as [EventBus.Dispatch], but returns an error wrapping [ErrQueueFull] instead of queuing the event if the limit set with [OptMaxQueueDepth] is reached.
+// Without [OptMaxQueueDepth], this never returns [ErrQueueFull].
+// [ErrShuttingDown] is returned if the [EventBus] is stopping, and [ErrInvalidEvent] is returned for [EventNone].
+//
+// This can safely be called from within a [Handler].
+func (b *EventBus) TryDispatch(evt Event, params ...Param) error {
+	if evt == EventNone {
+		b.DispatchError(ErrInvalidEvent)
+		return ErrInvalidEvent
+	}
+	b.tryMux.Lock()
+	defer b.tryMux.Unlock()
+	if limit := b.conf.maxQueueDepth; limit > 0 {
+		if depth := b.queueDepth(); depth >= limit {
+			return fmt.Errorf("%w: %d events are queued", ErrQueueFull, depth)
+		}
+	}
+	dispatch := newDispatch(evt, params, symbolicFuture)
+	b.record(dispatch, 0)
+	if !b.events.Push(dispatch) {
+		return ErrShuttingDown
+	}
+	return nil
+}
+
+// queueDepth returns the approximate number of dispatches waiting for a worker.
+func (b *EventBus) queueDepth() int {
+	if b.events == nil {
+		return 0
+	}
+	return b.events.Len() + len(b.events.C)
+}
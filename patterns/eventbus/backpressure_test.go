@@ -0,0 +1,47 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEventBus_TryDispatch(t *testing.T) {
+	bus := NewEventBus(OptMaxQueueDepth(2)).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	release := make(chan struct{})
+	bus.RegisterFunc("blocking", testEvent, func(evt Event, params ...Param) error {
+		<-release
+		return nil
+	})
+	require.NoError(t, bus.TryDispatch(testEvent))
+	require.Eventually(t, func() bool {
+		return bus.Stats().BusyWorkers == 1
+	}, time.Second, time.Millisecond)
+
+	var (
+		accepted int
+		err      error
+	)
+	for ; accepted < 10; accepted++ {
+		if err = bus.TryDispatch(testEvent); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, ErrQueueFull)
+	assert.GreaterOrEqual(t, accepted, 2, "Dispatches up to the limit should be accepted")
+	assert.Less(t, accepted, 10, "Dispatches should be rejected once the limit is reached")
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return bus.Pending() == 0
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, bus.TryDispatch(testEvent), "Dispatches should be accepted once the queue drains")
+	assert.ErrorIs(t, bus.TryDispatch(EventNone), ErrInvalidEvent)
+
+	bus.AwaitStop(testShutdownTimeout)
+	assert.ErrorIs(t, bus.TryDispatch(testEvent), ErrShuttingDown)
+}
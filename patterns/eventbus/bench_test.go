@@ -0,0 +1,57 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func benchmarkBus(b *testing.B) *EventBus {
+	bus := NewEventBus().Start(context.Background())
+	b.Cleanup(func() {
+		bus.AwaitStop(testShutdownTimeout)
+	})
+	bus.RegisterFunc("bench", testEvent, func(evt Event, params ...Param) error {
+		return nil
+	})
+	return bus
+}
+
+func awaitIdle(bus *EventBus) {
+	for bus.Pending() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func BenchmarkEventBus_Dispatch(b *testing.B) {
+	bus := benchmarkBus(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bus.Dispatch(testEvent, "param")
+	}
+	b.StopTimer()
+	awaitIdle(bus)
+}
+
+func BenchmarkEventBus_DispatchParallel(b *testing.B) {
+	bus := benchmarkBus(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			bus.Dispatch(testEvent, "param")
+		}
+	})
+	b.StopTimer()
+	awaitIdle(bus)
+}
+
+func BenchmarkEventBus_DispatchResult(b *testing.B) {
+	bus := benchmarkBus(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = bus.DispatchResult(testEvent, "param").Await()
+	}
+}
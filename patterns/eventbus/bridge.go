@@ -0,0 +1,191 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/structures/set"
+	"github.com/saylorsolutions/x/syncx"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	ErrBridgeClosed = errors.New("remote bridge is closed")
+
+	bridgeCounter atomic.Uint64
+)
+
+// bridgeMessage is the wire format for a forwarded event.
+type bridgeMessage struct {
+	Event  Event   `json:"event"`
+	Params []Param `json:"params,omitempty"`
+}
+
+type bridgeConf struct {
+	accept set.Set[Event]
+}
+
+type BridgeOption func(conf *bridgeConf) error
+
+// OptBridgeAccept restricts the events that will be dispatched to the local [EventBus] when received from the remote side.
+// By default, any event other than [EventNone] and [EventAsyncError] is accepted.
+func OptBridgeAccept(events ...Event) BridgeOption {
+	return func(conf *bridgeConf) error {
+		if len(events) == 0 {
+			return errors.New("no accepted events specified")
+		}
+		conf.accept = set.New(events...)
+		return nil
+	}
+}
+
+// RemoteBridge connects an [EventBus] to another over a connection, so multiple processes can share an event space without a message broker.
+// Selected local events are forwarded to the remote side, and events received from the remote side are dispatched to the local [EventBus].
+// Events received from the remote side are never forwarded back to it, so both sides may forward the same events.
+//
+// Events are encoded as JSON lines, so params must be JSON serializable, and are received as generic JSON values, with the same caveats as [FileJournal].
+type RemoteBridge struct {
+	bus     *EventBus
+	id      HandlerID
+	conn    io.ReadWriteCloser
+	conf    bridgeConf
+	writeMu sync.Mutex
+	enc     *json.Encoder
+	done    chan struct{}
+	once    sync.Once
+	err     syncx.AtomicError
+}
+
+// NewRemoteBridge starts bridging the [EventBus] over the connection, forwarding the given events to the remote side.
+// The connection is usually a [net.Conn] from [net.Dial] or a [net.Listener], but anything that frames a byte stream may be used, like a WebSocket adapter.
+//
+// The bridge is closed when [RemoteBridge.Close] is called, when the [EventBus] stops, or when the connection fails.
+// The bus should be started before creating a bridge.
+func NewRemoteBridge(bus *EventBus, conn io.ReadWriteCloser, forward []Event, opts ...BridgeOption) (*RemoteBridge, error) {
+	if bus == nil {
+		panic("nil event bus")
+	}
+	if conn == nil {
+		panic("nil connection")
+	}
+	var conf bridgeConf
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			return nil, err
+		}
+	}
+	for _, evt := range forward {
+		if evt == EventNone || evt == EventAsyncError {
+			return nil, fmt.Errorf("reserved event %d cannot be forwarded", evt)
+		}
+	}
+	r := &RemoteBridge{
+		bus:  bus,
+		id:   HandlerID(fmt.Sprintf("remote-bridge-%d", bridgeCounter.Add(1))),
+		conn: conn,
+		conf: conf,
+		enc:  json.NewEncoder(conn),
+		done: make(chan struct{}),
+	}
+	if len(forward) > 0 {
+		bus.Register(r.id, forward[0], r)
+		for _, evt := range forward[1:] {
+			if err := bus.AddHandledEvent(r.id, evt); err != nil {
+				bus.UnRegister(r.id)
+				return nil, err
+			}
+		}
+	}
+	go r.receive()
+	return r, nil
+}
+
+// HandleEvent forwards the event to the remote side.
+func (r *RemoteBridge) HandleEvent(evt Event, params ...Param) error {
+	select {
+	case <-r.done:
+		return ErrBridgeClosed
+	default:
+	}
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	if err := r.enc.Encode(bridgeMessage{Event: evt, Params: params}); err != nil {
+		r.fail(err)
+		return fmt.Errorf("failed to forward event %d: %w", evt, err)
+	}
+	return nil
+}
+
+// Stop closes the bridge, and is called when the bridge is unregistered or the [EventBus] stops.
+func (r *RemoteBridge) Stop() {
+	r.close()
+}
+
+func (r *RemoteBridge) receive() {
+	dec := json.NewDecoder(r.conn)
+	for {
+		var msg bridgeMessage
+		if err := dec.Decode(&msg); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				r.fail(err)
+			}
+			r.Close()
+			return
+		}
+		if !r.accepts(msg.Event) {
+			r.bus.DispatchErrorf("remote bridge received unaccepted event %d", msg.Event)
+			continue
+		}
+		dispatch := &busDispatch{
+			event:  msg.Event,
+			params: msg.Params,
+			future: syncx.SymbolicFuture[error](),
+			skip:   r.id,
+		}
+		r.bus.record(dispatch, 0)
+		if !r.bus.events.Push(dispatch) {
+			r.Close()
+			return
+		}
+	}
+}
+
+func (r *RemoteBridge) accepts(evt Event) bool {
+	if evt == EventNone || evt == EventAsyncError {
+		return false
+	}
+	return r.conf.accept == nil || r.conf.accept.Has(evt)
+}
+
+func (r *RemoteBridge) fail(err error) {
+	if r.err.StoreFirst(err) {
+		r.bus.DispatchError(fmt.Errorf("remote bridge failed: %w", err))
+	}
+}
+
+func (r *RemoteBridge) close() {
+	r.once.Do(func() {
+		close(r.done)
+		_ = r.conn.Close()
+	})
+}
+
+// Close stops forwarding events and closes the connection.
+// This is safe to call multiple times.
+func (r *RemoteBridge) Close() {
+	r.close()
+	r.bus.UnRegister(r.id)
+}
+
+// Done returns a channel that is closed when the bridge is closed.
+func (r *RemoteBridge) Done() <-chan struct{} {
+	return r.done
+}
+
+// Err returns the error that caused the bridge to close, if any.
+func (r *RemoteBridge) Err() error {
+	return r.err.Load()
+}
@@ -0,0 +1,96 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testRemoteEvent Event = 100
+
+func TestRemoteBridge(t *testing.T) {
+	local := NewEventBus().Start(context.Background())
+	defer local.AwaitStop(testShutdownTimeout)
+	remote := NewEventBus().Start(context.Background())
+	defer remote.AwaitStop(testShutdownTimeout)
+
+	var (
+		mux         sync.Mutex
+		localCalls  []Param
+		remoteCalls []Param
+	)
+	local.RegisterFunc("local", testEvent, func(evt Event, params ...Param) error {
+		mux.Lock()
+		defer mux.Unlock()
+		localCalls = append(localCalls, params...)
+		return nil
+	})
+	remote.RegisterFunc("remote", testEvent, func(evt Event, params ...Param) error {
+		mux.Lock()
+		defer mux.Unlock()
+		remoteCalls = append(remoteCalls, params...)
+		return nil
+	})
+
+	localConn, remoteConn := net.Pipe()
+	localBridge, err := NewRemoteBridge(local, localConn, []Event{testEvent})
+	require.NoError(t, err)
+	remoteBridge, err := NewRemoteBridge(remote, remoteConn, []Event{testEvent}, OptBridgeAccept(testEvent))
+	require.NoError(t, err)
+
+	local.Dispatch(testEvent, "from local")
+	remote.Dispatch(testEvent, "from remote")
+	assert.Eventually(t, func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(localCalls) == 2 && len(remoteCalls) == 2
+	}, time.Second, 5*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	mux.Lock()
+	assert.ElementsMatch(t, []Param{"from local", "from remote"}, localCalls, "Events should not echo back to their origin")
+	assert.ElementsMatch(t, []Param{"from local", "from remote"}, remoteCalls)
+	mux.Unlock()
+
+	localBridge.Close()
+	select {
+	case <-remoteBridge.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Remote bridge should close when the connection closes")
+	}
+	assert.NoError(t, remoteBridge.Err())
+	assert.ErrorIs(t, localBridge.HandleEvent(testEvent), ErrBridgeClosed)
+}
+
+func TestRemoteBridge_Accept(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	errs := make(chan error, 1)
+	bus.RegisterErrorHandler("errors", func(err error) {
+		errs <- err
+	})
+	bus.RegisterFunc("handler", testEvent, func(evt Event, params ...Param) error {
+		t.Error("Unaccepted event should not be dispatched")
+		return nil
+	})
+
+	conn, other := net.Pipe()
+	bridge, err := NewRemoteBridge(bus, conn, nil, OptBridgeAccept(testRemoteEvent))
+	require.NoError(t, err)
+	defer bridge.Close()
+	_, err = other.Write([]byte(`{"event":1}` + "\n"))
+	require.NoError(t, err)
+	select {
+	case err := <-errs:
+		assert.ErrorContains(t, err, "unaccepted event")
+	case <-time.After(time.Second):
+		t.Fatal("Expected an error for the unaccepted event")
+	}
+
+	_, err = NewRemoteBridge(bus, other, []Event{EventAsyncError})
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"github.com/saylorsolutions/x/idx"
+	"github.com/saylorsolutions/x/syncx"
+)
+
+// ContextHandler is a [Handler] that also accepts the [context.Context] given to [EventBus.DispatchCtx].
+// HandleEventCtx is called instead of HandleEvent for every dispatch, with [context.Background] for dispatches without a context.
+type ContextHandler interface {
+	Handler
+	HandleEventCtx(ctx context.Context, evt Event, params ...Param) error
+}
+
+// HandlerFuncCtx is a function that implements [ContextHandler].
+type HandlerFuncCtx func(ctx context.Context, evt Event, params ...Param) error
+
+func (f HandlerFuncCtx) HandleEvent(evt Event, params ...Param) error {
+	return f(context.Background(), evt, params...)
+}
+
+func (f HandlerFuncCtx) HandleEventCtx(ctx context.Context, evt Event, params ...Param) error {
+	return f(ctx, evt, params...)
+}
+
+func (f HandlerFuncCtx) Stop() {}
+
+// TraceHook is called before each [Handler] call, and may return a derived context, like one with a tracing span.
+// The returned end function, if not nil, is called with the handler's result once it returns.
+type TraceHook func(ctx context.Context, evt Event, id HandlerID) (context.Context, func(err error))
+
+// OptTraceHook configures the [EventBus] to call the [TraceHook] around every [Handler] call.
+// This can be used to start and end an OpenTelemetry span for each handler, with the span from the dispatching context as its parent.
+func OptTraceHook(hook TraceHook) ConfigOption {
+	return func(conf *busConf) error {
+		if hook == nil {
+			return errors.New("nil trace hook")
+		}
+		conf.traceHook = hook
+		return nil
+	}
+}
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a child context with the given correlation ID.
+// This can be used to continue an existing ID, like a request ID, in events dispatched with [EventBus.DispatchCtx].
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID of the context, or an empty string if there is none.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// ensureCorrelationID returns a context with a correlation ID, generating a new [idx.ULID] if needed.
+func ensureCorrelationID(ctx context.Context) context.Context {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if len(CorrelationID(ctx)) > 0 {
+		return ctx
+	}
+	return WithCorrelationID(ctx, idx.NewULID().String())
+}
+
+// DispatchCtx is the same as [EventBus.Dispatch], but the context is passed to each [ContextHandler], so values like trace spans and correlation IDs propagate through a chain of events.
+// If the context has no [CorrelationID], then a new one is generated.
+// Handlers that dispatch with the context they received continue the same correlation ID.
+//
+// Handlers are still called if the context is cancelled before the event is handled, so a handler that outlives a request should use [context.WithoutCancel].
+//
+// This can safely be called from within a [Handler].
+func (b *EventBus) DispatchCtx(ctx context.Context, evt Event, params ...Param) {
+	ctx = ensureCorrelationID(ctx)
+	if evt == EventNone {
+		b.DispatchError(ErrInvalidEvent)
+		return
+	}
+	dispatch := newDispatch(evt, params, symbolicFuture)
+	dispatch.ctx = ctx
+	b.record(dispatch, 0)
+	b.events.Push(dispatch)
+}
+
+// DispatchResultCtx is the same as [EventBus.DispatchResult], with the context propagation of [EventBus.DispatchCtx].
+//
+// NOTE: This should not be called from within a [Handler], for the same reasons as [EventBus.DispatchResult].
+func (b *EventBus) DispatchResultCtx(ctx context.Context, evt Event, params ...Param) syncx.Future[error] {
+	ctx = ensureCorrelationID(ctx)
+	if evt == EventNone {
+		b.DispatchError(ErrInvalidEvent)
+		return syncx.StaticFuture(ErrInvalidEvent)
+	}
+	future := syncx.NewFuture[error]()
+	dispatch := newDispatch(evt, params, future)
+	dispatch.ctx = ctx
+	b.record(dispatch, 0)
+	if !b.events.Push(dispatch) {
+		future.Resolve(ErrShuttingDown)
+	}
+	return future
+}
+
+// withContext adapts a [ContextHandler] to a [Handler] that passes the context, so it can be wrapped with [Middleware].
+func withContext(ctx context.Context, handler Handler) Handler {
+	ctxHandler, ok := handler.(ContextHandler)
+	if !ok {
+		return handler
+	}
+	return HandlerFunc(func(evt Event, params ...Param) error {
+		return ctxHandler.HandleEventCtx(ctx, evt, params...)
+	})
+}
@@ -0,0 +1,86 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testSpanKey struct{}
+
+func TestEventBus_DispatchCtx(t *testing.T) {
+	var (
+		mux   sync.Mutex
+		spans []string
+	)
+	bus := NewEventBus(OptTraceHook(func(ctx context.Context, evt Event, id HandlerID) (context.Context, func(error)) {
+		return context.WithValue(ctx, testSpanKey{}, string(id)), func(err error) {
+			mux.Lock()
+			defer mux.Unlock()
+			spans = append(spans, string(id))
+		}
+	})).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	ids := make(chan string, 2)
+	bus.Register("first", testEvent, HandlerFuncCtx(func(ctx context.Context, evt Event, params ...Param) error {
+		assert.Equal(t, "first", ctx.Value(testSpanKey{}))
+		ids <- CorrelationID(ctx)
+		bus.DispatchCtx(ctx, testEvent+1)
+		return nil
+	}))
+	bus.RegisterOnce("second", testEvent+1, HandlerFuncCtx(func(ctx context.Context, evt Event, params ...Param) error {
+		assert.Equal(t, "second", ctx.Value(testSpanKey{}))
+		ids <- CorrelationID(ctx)
+		return nil
+	}))
+
+	require.NoError(t, bus.DispatchResultCtx(WithCorrelationID(context.Background(), "abc"), testEvent).Await(time.Second))
+	for i := 0; i < 2; i++ {
+		select {
+		case id := <-ids:
+			assert.Equal(t, "abc", id, "The correlation ID should propagate through the chain of events")
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for handler")
+		}
+	}
+	require.Eventually(t, func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(spans) == 2
+	}, time.Second, time.Millisecond)
+
+	bus.RegisterFunc("plain", testEvent+2, func(evt Event, params ...Param) error {
+		return nil
+	})
+	require.NoError(t, bus.DispatchResultCtx(context.Background(), testEvent+2).Await(time.Second), "Plain handlers should still be called")
+
+	bus.Dispatch(testEvent)
+	select {
+	case id := <-ids:
+		assert.Empty(t, id, "Dispatches without a context should have no correlation ID")
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for handler")
+	}
+}
+
+func TestEventBus_DispatchCtx_GeneratesCorrelationID(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	ids := make(chan string, 1)
+	bus.Register("handler", testEvent, HandlerFuncCtx(func(ctx context.Context, evt Event, params ...Param) error {
+		ids <- CorrelationID(ctx)
+		return nil
+	}))
+	bus.DispatchCtx(context.Background(), testEvent)
+	select {
+	case id := <-ids:
+		assert.NotEmpty(t, id)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for handler")
+	}
+}
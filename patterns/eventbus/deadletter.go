@@ -0,0 +1,75 @@
+package eventbus
+
+import (
+	"fmt"
+	"time"
+)
+
+// DeadLetter is an event that was dispatched when no [Handler] was registered for it.
+type DeadLetter struct {
+	Event  Event
+	Params []Param
+	Time   time.Time
+}
+
+// OptDeadLetters configures the [EventBus] to keep up to capacity events that were dispatched with no registered [Handler], so they can be inspected with [EventBus.DeadLetters], or dispatched again with [EventBus.RedispatchDeadLetters] once a handler is registered.
+// When the capacity is reached, the oldest dead letter is discarded.
+//
+// Dispatches with no handler are still reported with [ErrNoHandler].
+// Errors and internal events are never kept as dead letters.
+func OptDeadLetters(capacity int) ConfigOption {
+	return func(conf *busConf) error {
+		if capacity < 1 {
+			return fmt.Errorf("capacity '%d' is invalid, must be >= 1", capacity)
+		}
+		conf.deadLetters = capacity
+		return nil
+	}
+}
+
+func (b *EventBus) deadLetter(dispatch *busDispatch) {
+	if b.deadLetters == nil || dispatch.event == EventAsyncError || dispatch.event == eventReply {
+		return
+	}
+	_ = b.deadLetters.Push(DeadLetter{
+		Event:  dispatch.event,
+		Params: dispatch.params,
+		Time:   time.Now(),
+	})
+}
+
+// DeadLetters returns the events that were dispatched with no registered [Handler], from oldest to newest, without removing them.
+// This returns nil if [OptDeadLetters] wasn't used.
+func (b *EventBus) DeadLetters() []DeadLetter {
+	if b.deadLetters == nil {
+		return nil
+	}
+	return b.deadLetters.Snapshot()
+}
+
+// DroppedDeadLetters returns the number of dead letters that were discarded because the capacity set with [OptDeadLetters] was reached.
+func (b *EventBus) DroppedDeadLetters() uint64 {
+	if b.deadLetters == nil {
+		return 0
+	}
+	return b.deadLetters.Dropped()
+}
+
+// RedispatchDeadLetters removes all dead letters and dispatches them again, from oldest to newest, returning the number dispatched.
+// This is useful once a handler for the events has been registered.
+// Events that still have no [Handler] will become dead letters again.
+func (b *EventBus) RedispatchDeadLetters() int {
+	if b.deadLetters == nil {
+		return 0
+	}
+	// Only the current letters are popped, since redispatched events may become dead letters again while this runs.
+	var count int
+	for n := b.deadLetters.Len(); count < n; count++ {
+		letter, ok := b.deadLetters.Pop()
+		if !ok {
+			break
+		}
+		b.Dispatch(letter.Event, letter.Params...)
+	}
+	return count
+}
@@ -0,0 +1,49 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEventBus_DeadLetters(t *testing.T) {
+	bus := NewEventBus(OptDeadLetters(2)).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	assert.ErrorIs(t, bus.DispatchResult(testEvent, "a").Await(time.Second), ErrNoHandler, "Dead letters should still report no handler")
+	assert.ErrorIs(t, bus.DispatchResult(testEvent, "b").Await(time.Second), ErrNoHandler)
+	assert.ErrorIs(t, bus.DispatchResult(testEvent, "c").Await(time.Second), ErrNoHandler)
+	letters := bus.DeadLetters()
+	require.Len(t, letters, 2)
+	assert.Equal(t, testEvent, letters[0].Event)
+	assert.Equal(t, []Param{"b"}, letters[0].Params, "The oldest dead letter should be discarded")
+	assert.Equal(t, []Param{"c"}, letters[1].Params)
+	assert.False(t, letters[0].Time.IsZero())
+	assert.Equal(t, uint64(1), bus.DroppedDeadLetters())
+
+	received := make(chan Param, 2)
+	bus.RegisterFunc("late", testEvent, func(evt Event, params ...Param) error {
+		received <- params[0]
+		return nil
+	})
+	assert.Equal(t, 2, bus.RedispatchDeadLetters())
+	for _, expected := range []string{"b", "c"} {
+		select {
+		case val := <-received:
+			assert.Equal(t, expected, val)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for redispatched event")
+		}
+	}
+	assert.Empty(t, bus.DeadLetters())
+}
+
+func TestEventBus_DeadLetters_Disabled(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	assert.ErrorIs(t, bus.DispatchResult(testEvent).Await(time.Second), ErrNoHandler)
+	assert.Nil(t, bus.DeadLetters())
+	assert.Zero(t, bus.RedispatchDeadLetters())
+}
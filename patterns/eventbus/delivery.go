@@ -0,0 +1,39 @@
+package eventbus
+
+import (
+	"errors"
+	"sync"
+)
+
+// DeliveryHook is called by a worker when it receives a dispatch, and controls when the event is delivered to its handlers by calling deliver.
+// If the hook returns without calling deliver, then the event is delivered once it returns.
+// The params must not be modified, and the worker is blocked until the hook returns.
+type DeliveryHook func(evt Event, params []Param, deliver func())
+
+// OptDeliveryHook configures the [EventBus] to call the [DeliveryHook] for every dispatch, before it's handled.
+// This allows controlling when events are delivered, like delivering them one at a time in tests.
+func OptDeliveryHook(hook DeliveryHook) ConfigOption {
+	return func(conf *busConf) error {
+		if hook == nil {
+			return errors.New("nil delivery hook")
+		}
+		conf.deliveryHook = hook
+		return nil
+	}
+}
+
+// deliver processes the dispatch through the configured [DeliveryHook].
+func (b *EventBus) deliver(dispatch *busDispatch) []error {
+	var (
+		errs []error
+		once sync.Once
+	)
+	deliver := func() {
+		once.Do(func() {
+			errs = b.processDispatch(dispatch)
+		})
+	}
+	b.conf.deliveryHook(dispatch.event, dispatch.params, deliver)
+	deliver()
+	return errs
+}
@@ -0,0 +1,32 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestOptDeliveryHook(t *testing.T) {
+	var (
+		hooked    []Param
+		delivered []Param
+	)
+	bus := NewEventBus(OptDeliveryHook(func(evt Event, params []Param, deliver func()) {
+		hooked = append(hooked, params[0])
+		if params[0] == "deliver" {
+			deliver()
+			assert.Equal(t, []Param{"deliver"}, delivered, "The event should be handled when deliver is called")
+		}
+	})).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterFunc("test", testEvent, func(evt Event, params ...Param) error {
+		delivered = append(delivered, params[0])
+		return nil
+	})
+
+	assert.NoError(t, bus.DispatchResult(testEvent, "deliver").Await(time.Second))
+	assert.NoError(t, bus.DispatchResult(testEvent, "skip").Await(time.Second))
+	assert.Equal(t, []Param{"deliver", "skip"}, hooked)
+	assert.Equal(t, []Param{"deliver", "skip"}, delivered, "The event should be delivered after the hook returns if it didn't call deliver")
+}
@@ -15,6 +15,7 @@ Here are the design priorities of the implementation:
 
 Every [Event] is an integer representing something specific that happens in an application.
 It's recommended to create a global enum of [Event] that is accessible to all parts of the application to have a consistent, documented reference of events.
+In larger code bases where a single enum is hard to coordinate, a [TopicBus] can be used to publish hierarchical string topics like "user.created", and subscribe to patterns like "user.*".
 
 Note that there are two reserved event numbers: [EventNone] and [EventAsyncError] that are set to 0 and 1, respectively.
 These event numbers should not be used with different semantics, as they're used internally.
@@ -39,6 +40,10 @@ The [EventBus] will only dispatch an [Event] to a [Handler] if it has been previ
 To register a [Handler], use [EventBus.Register] with a string handler ID, the event that the [Handler] should handle, and the [Handler] implementation.
 To allow a [Handler] to handle multiple events, use [EventBus.AddHandledEvent] with the string handler ID, and the additional [Event] that should be dispatched to the [Handler].
 Note that - for simpler event handling cases - a [HandlerFunc] may be used when the function doesn't need to be aware of the [EventBus] stopping, and doesn't need to free resources.
+Consumers that would rather select on a channel can use [SubscribeChan], which sends the first [Param] of each dispatch to a typed channel.
+[EventBus.Subscribe] is similar, but sends each [Dispatch] with all of its params.
+To only receive events with params that match a predicate, like [MatchesSpec], use [EventBus.RegisterFiltered].
+To handle only the first successful event, like an initialization event, use [EventBus.RegisterOnce].
 
 To receive and handle errors that occur while handling events, use [EventBus.RegisterErrorHandler] to register a function that is called for each error.
 This can be useful for consolidating logging for errors that occur in a [Handler].
@@ -47,6 +52,7 @@ To start propagation of events, use [EventBus.Start] with a context.
 When the context is cancelled, all event processing will stop after the [EventBus] has worked through all dispatched events.
 To stop the [EventBus] and wait for processing to fully stop, use [EventBus.AwaitStop].
 This method will block until all processing goroutines have stopped, or the timeout has been reached.
+Use [EventBus.Drain] to stop and wait for queued events with a context, and report how many were processed, or [EventBus.Pending] to check how much work is queued.
 
 # Event Flow
 
@@ -55,13 +61,66 @@ This event will be dispatched to all currently registered handlers that indicate
 
 To also receive an error from a [Handler], use [EventBus.DispatchResult] that returns a [Future].
 The first error that occurs will be returned via the [Future], and all errors will still be dispatched to any registered error handlers.
+To receive the outcome of every [Handler], use [EventBus.DispatchAll], which runs all handlers in parallel and returns a [HandlerResult] for each.
+To get a value back from a handler, use [EventBus.Request], and reply from the handler with the [Replier] from [GetReplier].
+
+To trace a chain of events triggered by one request, use [EventBus.DispatchCtx] and implement [ContextHandler], like with [HandlerFuncCtx].
+The context carries a [CorrelationID] through each handler that dispatches with the context it received, and [OptTraceHook] can start a span around each handler call.
+
+Events are handled in the order they're dispatched by default.
+Use [EventBus.DispatchRanked] to queue urgent events, like alerts or shutdown signals, ahead of regular traffic.
+Use [EventBus.DispatchEvery] to dispatch an event on an interval, instead of managing a ticker goroutine.
+[OptDeliveryHook] can control when each event is delivered to its handlers.
+The queue grows without bound by default, so producers that need backpressure should use [EventBus.TryDispatch] with [OptMaxQueueDepth], which returns [ErrQueueFull] instead of queuing more events.
+
+With multiple workers, events may be handled concurrently and in any order.
+When events for the same entity must be handled in order, use [EventBus.DispatchKeyed] with an ordering key like the entity ID.
+Events with the same key are handled sequentially in dispatch order, while events with different keys are still handled in parallel.
 
 Note that using [EventBus.DispatchResult] in handlers can cause a deadlock/livelock.
 This happens when the [EventBus] processing goroutine(s) are trying to process events while handlers are blocking on receiving a result.
 
+Events dispatched with no registered [Handler] are reported with [ErrNoHandler].
+Use [OptDeadLetters] to also keep them for inspection with [EventBus.DeadLetters], or to dispatch them again with [EventBus.RedispatchDeadLetters] once a handler is registered.
+
 To dispatch an error outside a [Handler], use either the [EventBus.DispatchError] or [EventBus.DispatchErrorf] methods.
 To return an error from a [Handler], just return it from the processing method/function.
 
+A [Handler] that blocks forever will starve a worker goroutine.
+Use [OptHandlerTimeout] or [EventBus.SetHandlerTimeout] to report handlers that take too long with [ErrHandlerTimeout], and [EventBus.StuckHandlers] to monitor handlers that never returned.
+
+[EventBus.Stats] reports dispatch and handler counts, queue depth, and worker utilization.
+To export metrics to a monitoring system, use [OptMetricsHook] to observe every dispatch and handler call.
+
+# Testing
+
+The eventbustest package provides a RecordingBus that records every dispatch and error for assertions, and waits for events to be handled instead of requiring sleeps.
+A stepped RecordingBus delivers events one at a time, so each step of a chain of events can be checked.
+
+# Journaling
+
+Use [OptJournal] to record every dispatch to a [Journal], and [EventBus.Replay] to dispatch recorded events into a freshly constructed [EventBus].
+This can be used to recover state after a crash, or to reproduce a sequence of events in tests.
+[MemoryJournal] and [FileJournal] are provided, and other storage can be used by implementing [Journal].
+
+# Remote Bridging
+
+A [RemoteBridge] forwards selected events to an [EventBus] in another process over a connection, like TCP, and dispatches events received from it.
+This allows multi-process applications to share an event space without a message broker.
+
+# Middleware
+
+Cross-cutting concerns like tracing, timing, or panic recovery can be applied to every [Handler] with [EventBus.Use].
+A [Middleware] wraps a [Handler] just like HTTP middleware wraps an http.Handler.
+[RecoverMiddleware] is provided to turn handler panics into errors.
+
+# Dispatch Policies
+
+A noisy event source can flood handlers, so [EventBus.RegisterWithPolicy] allows controlling delivery per [Event] with a [DispatchPolicy].
+  - [PolicyMaxRate] limits the rate of delivery, dropping or queueing the excess.
+  - [PolicyDebounce] only delivers the last event in a burst.
+  - [PolicySample] only delivers every nth event.
+
 [Future]: github.com/saylorsolutions/x/syncx/future.go
 */
 package eventbus
@@ -0,0 +1,41 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventBus_Drain(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	release := make(chan struct{})
+	var handled atomic.Int32
+	bus.RegisterFunc("slow", testEvent, func(evt Event, params ...Param) error {
+		<-release
+		handled.Add(1)
+		return nil
+	})
+	assert.Equal(t, 0, NewEventBus().Pending())
+	for range 5 {
+		bus.Dispatch(testEvent)
+	}
+	assert.Eventually(t, func() bool {
+		return bus.Pending() >= 3
+	}, time.Second, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	n, err := bus.Drain(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, n)
+	assert.ErrorIs(t, bus.DispatchResult(testEvent).Await(time.Second), ErrShuttingDown, "New dispatches should be rejected while draining")
+
+	close(release)
+	n, err = bus.Drain(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, int32(5), handled.Load())
+	assert.Zero(t, bus.Pending())
+}
@@ -1,20 +1,27 @@
 package eventbus
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"github.com/saylorsolutions/x/structures/queue"
+	"github.com/saylorsolutions/x/structures/ring"
 	"github.com/saylorsolutions/x/structures/set"
 	"github.com/saylorsolutions/x/syncx"
+	"log/slog"
+	"maps"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var (
-	ErrNoHandler    = errors.New("no handler found")
-	ErrInvalidEvent = errors.New("event ID 0 cannot be dispatched")
-	ErrShuttingDown = errors.New("dispatch cannot be completed, event bus is shutting down")
+	ErrNoHandler      = errors.New("no handler found")
+	ErrInvalidEvent   = errors.New("event ID 0 cannot be dispatched")
+	ErrShuttingDown   = errors.New("dispatch cannot be completed, event bus is shutting down")
+	ErrHandlerTimeout = errors.New("handler timed out")
 )
 
 // Event is a unique ID for an event in a domain.
@@ -58,8 +65,16 @@ func Instance() *EventBus {
 }
 
 type busConf struct {
-	bufferSize int
-	numWorkers int
+	bufferSize     int
+	numWorkers     int
+	handlerTimeout time.Duration
+	journal        Journal
+	metricsHook    func(Metric)
+	logger         *slog.Logger
+	maxQueueDepth  int
+	deadLetters    int
+	traceHook      TraceHook
+	deliveryHook   DeliveryHook
 }
 
 type ConfigOption func(conf *busConf) error
@@ -86,6 +101,32 @@ func OptNumWorkers(num int) ConfigOption {
 	}
 }
 
+// OptHandlerTimeout configures the [EventBus] to stop waiting for a [Handler] after the given timeout, so a handler that blocks forever can't starve a worker goroutine.
+// A timed out handler is reported with an error wrapping [ErrHandlerTimeout], and continues running in the background until it returns.
+// This can be overridden for a specific handler with [EventBus.SetHandlerTimeout].
+func OptHandlerTimeout(timeout time.Duration) ConfigOption {
+	return func(conf *busConf) error {
+		if timeout <= 0 {
+			return fmt.Errorf("timeout '%s' is invalid, must be > 0", timeout)
+		}
+		conf.handlerTimeout = timeout
+		return nil
+	}
+}
+
+// OptLogger configures the [EventBus] to log its activity to the [slog.Logger].
+// Dispatches and handler calls are logged at [slog.LevelDebug], and handler errors are logged at [slog.LevelWarn].
+// Nothing is logged by default.
+func OptLogger(logger *slog.Logger) ConfigOption {
+	return func(conf *busConf) error {
+		if logger == nil {
+			return errors.New("nil logger")
+		}
+		conf.logger = logger
+		return nil
+	}
+}
+
 // NewEventBus will create a new [EventBus] with default settings.
 // ConfigFuncs may be used to specify different configuration parameters for the [EventBus].
 // If none are specified, then both the dispatch buffer size and the number of handler goroutines will be set to [DefaultBufferSize].
@@ -99,11 +140,16 @@ func NewEventBus(opts ...ConfigOption) *EventBus {
 			panic(err)
 		}
 	}
-	return &EventBus{
+	b := &EventBus{
 		handlers:      map[HandlerID]Handler{},
 		handledEvents: map[Event]set.Set[HandlerID]{},
+		timeouts:      map[HandlerID]time.Duration{},
 		conf:          conf,
 	}
+	if conf.deadLetters > 0 {
+		b.deadLetters = ring.New[DeadLetter](conf.deadLetters)
+	}
+	return b
 }
 
 type Param any
@@ -135,9 +181,22 @@ func (f HandlerFunc) HandleEvent(evt Event, params ...Param) error {
 func (f HandlerFunc) Stop() {}
 
 type busDispatch struct {
-	event  Event
-	params []Param
-	future syncx.Future[error]
+	event   Event
+	params  []Param
+	future  syncx.Future[error]
+	results syncx.Future[[]HandlerResult] // results is only set when requested with DispatchAll.
+	key     string                        // key is only set when dispatched with an ordering key.
+	seq     uint64                        // seq is the order of this dispatch within its key.
+	replay  bool                          // replay is set for dispatches from EventBus.Replay, so they aren't journaled again.
+	skip    HandlerID                     // skip is a handler that should not receive this dispatch, like the RemoteBridge that received it.
+	ctx     context.Context               // ctx is only set when dispatched with DispatchCtx.
+}
+
+// HandlerResult is the outcome of a single [Handler] handling a dispatched event.
+type HandlerResult struct {
+	HandlerID HandlerID
+	Err       error
+	Duration  time.Duration
 }
 
 type EventBus struct {
@@ -149,7 +208,20 @@ type EventBus struct {
 	events        *queue.ChannelQueue[*busDispatch]
 	handlers      map[HandlerID]Handler
 	handledEvents map[Event]set.Set[HandlerID]
+	timeouts      map[HandlerID]time.Duration
+	middleware    []Middleware
 	conf          busConf
+	stuck         atomic.Int64
+	busy          atomic.Int64
+	processed     atomic.Uint64
+	stats         busStats
+	replies       busReplies
+	deadLetters   *ring.Buffer[DeadLetter]
+
+	keyMux sync.Mutex
+	keys   map[string]*keyState
+
+	tryMux sync.Mutex // tryMux serializes TryDispatch, so concurrent producers can't exceed the queue limit together.
 }
 
 // Dispatch will submit an event to the [EventBus] for propagation.
@@ -162,14 +234,28 @@ func (b *EventBus) Dispatch(evt Event, params ...Param) {
 		b.DispatchError(ErrInvalidEvent)
 		return
 	}
-	dispatch := &busDispatch{
-		event:  evt,
-		params: params,
-		future: syncx.SymbolicFuture[error](),
-	}
+	dispatch := newDispatch(evt, params, symbolicFuture)
+	b.record(dispatch, 0)
 	b.events.Push(dispatch)
 }
 
+// DispatchRanked is the same as [EventBus.Dispatch], but the event is queued ahead of all queued events with a lower priority.
+// This allows urgent events like alerts or shutdown signals to preempt regular traffic.
+// Events with the same priority are handled in the order they were dispatched, and a priority of 0 is the same as [EventBus.Dispatch].
+//
+// Note that events that have already been handed to a worker can't be preempted.
+//
+// This can safely be called from within a [Handler].
+func (b *EventBus) DispatchRanked(evt Event, priority uint, params ...Param) {
+	if evt == EventNone {
+		b.DispatchError(ErrInvalidEvent)
+		return
+	}
+	dispatch := newDispatch(evt, params, symbolicFuture)
+	b.record(dispatch, priority)
+	b.events.PushRanked(dispatch, priority)
+}
+
 // DispatchResult will submit an event to the [EventBus] for propagation.
 // If the [EventBus] is shutting down, then
 // If an error is returned, then an [EventAsyncError] is still propagated to an appropriate handler, if registered.
@@ -180,15 +266,35 @@ func (b *EventBus) DispatchResult(evt Event, params ...Param) syncx.Future[error
 		b.DispatchError(ErrInvalidEvent)
 		return syncx.StaticFuture(ErrInvalidEvent)
 	}
-	dispatch := &busDispatch{
-		event:  evt,
-		params: params,
-		future: syncx.NewFuture[error](),
+	// The dispatch may be released once it's processed, so only the future may be referenced after pushing.
+	future := syncx.NewFuture[error]()
+	dispatch := newDispatch(evt, params, future)
+	b.record(dispatch, 0)
+	if !b.events.Push(dispatch) {
+		future.Resolve(ErrShuttingDown)
+	}
+	return future
+}
+
+// DispatchAll will submit an event to the [EventBus] for propagation, running all relevant handlers in parallel.
+// The returned [syncx.Future] resolves to a [HandlerResult] for every handler, sorted by [HandlerID], once they have all returned.
+// Errors that prevent handling altogether, like [ErrNoHandler] or [ErrShuttingDown], are reported as a single [HandlerResult] with an empty HandlerID.
+// Handler errors are still propagated as an [EventAsyncError] to an appropriate handler, if registered.
+//
+// NOTE: This should not be called from within a [Handler], for the same reasons as [EventBus.DispatchResult].
+func (b *EventBus) DispatchAll(evt Event, params ...Param) syncx.Future[[]HandlerResult] {
+	if evt == EventNone {
+		b.DispatchError(ErrInvalidEvent)
+		return syncx.StaticFuture([]HandlerResult{{Err: ErrInvalidEvent}})
 	}
+	results := syncx.NewFuture[[]HandlerResult]()
+	dispatch := newDispatch(evt, params, symbolicFuture)
+	dispatch.results = results
+	b.record(dispatch, 0)
 	if !b.events.Push(dispatch) {
-		dispatch.future.Resolve(ErrShuttingDown)
+		results.Resolve([]HandlerResult{{Err: ErrShuttingDown}})
 	}
-	return dispatch.future
+	return results
 }
 
 func (b *EventBus) DispatchErrorf(format string, args ...any) {
@@ -228,18 +334,25 @@ func (b *EventBus) RegisterErrorHandler(id HandlerID, handler func(error)) {
 
 func (b *EventBus) UnRegister(id HandlerID) {
 	syncx.LockFunc(&b.mux, func() {
-		handler, ok := b.handlers[id]
-		if !ok {
-			return
-		}
-		handler.Stop()
-		delete(b.handlers, id)
-		for _, handlerSet := range b.handledEvents {
-			handlerSet.Remove(id)
-		}
+		b.removeHandler(id)
 	})
 }
 
+// removeHandler stops and removes the handler.
+// This must be called with the write lock held.
+func (b *EventBus) removeHandler(id HandlerID) {
+	handler, ok := b.handlers[id]
+	if !ok {
+		return
+	}
+	handler.Stop()
+	delete(b.handlers, id)
+	delete(b.timeouts, id)
+	for _, handlerSet := range b.handledEvents {
+		handlerSet.Remove(id)
+	}
+}
+
 func (b *EventBus) AddHandledEvent(id HandlerID, evt Event) error {
 	return syncx.LockFuncT(&b.mux, func() error {
 		_, ok := b.handlers[id]
@@ -301,7 +414,11 @@ func (b *EventBus) Start(ctx context.Context) *EventBus {
 func (b *EventBus) start(ctx context.Context, events *queue.ChannelQueue[*busDispatch]) {
 	defer b.doneDispatching.Done()
 	defer func() {
-		for _, handler := range b.handlers {
+		// Handlers may be unregistered concurrently while stopping, so stop a snapshot.
+		handlers := syncx.RLockFuncT(&b.mux, func() []Handler {
+			return slices.Collect(maps.Values(b.handlers))
+		})
+		for _, handler := range handlers {
 			handler.Stop()
 		}
 	}()
@@ -321,7 +438,7 @@ func (b *EventBus) start(ctx context.Context, events *queue.ChannelQueue[*busDis
 				for _, err := range errs {
 					for id := range errHandlerIDs {
 						handler := b.handlers[id]
-						if handler == nil {
+						if handler == nil || !accepts(handler, []Param{err}) {
 							continue
 						}
 						// No recourse for error handler returning an error in this context.
@@ -339,42 +456,117 @@ func (b *EventBus) start(ctx context.Context, events *queue.ChannelQueue[*busDis
 			if !more {
 				return
 			}
-			syncx.RLockFunc(&b.mux, func() {
-				defer func() {
-					// If a result has already been returned or a result is not requested, then this does nothing
-					dispatch.future.Resolve(nil)
-				}()
-
-				// Locate relevant handlers
-				handlers := b.handledEvents[dispatch.event]
-				noHandlersMessage := fmt.Errorf("%w for event %d", ErrNoHandler, dispatch.event)
-
-				// None found
-				if len(handlers) == 0 {
-					// Check if this is already an EventAsyncError
-					if dispatch.event != EventAsyncError {
-						dispatch.future.Resolve(noHandlersMessage)
-						errs = append(errs, noHandlersMessage)
-					}
-					return
-				}
+			b.busy.Add(1)
+			if b.conf.deliveryHook != nil {
+				errs = append(errs, b.deliver(dispatch)...)
+			} else {
+				errs = append(errs, b.processDispatch(dispatch)...)
+			}
+			b.busy.Add(-1)
+			b.processed.Add(1)
+		}
+	}
+}
 
-				// Dispatch to all relevant handlers
-				for id := range handlers {
-					handler := b.handlers[id]
-					if handler == nil {
-						continue
-					}
-					err := handler.HandleEvent(dispatch.event, dispatch.params...)
-					if err != nil {
-						// Return first error
-						dispatch.future.Resolve(err)
-						errs = append(errs, fmt.Errorf("handler '%s' failed to handle event %d: %v", id, dispatch.event, err))
-					}
-				}
-			})
+// processDispatch handles a dispatch received by a worker, releasing it if it's not keyed.
+func (b *EventBus) processDispatch(dispatch *busDispatch) []error {
+	if len(dispatch.key) > 0 {
+		return b.processKeyed(dispatch)
+	}
+	errs := b.process(dispatch)
+	releaseDispatch(dispatch)
+	return errs
+}
+
+// process handles the dispatch with a read lock held, and resolves its future.
+func (b *EventBus) process(dispatch *busDispatch) []error {
+	return syncx.RLockFuncT(&b.mux, func() []error {
+		defer func() {
+			// If a result has already been returned or a result is not requested, then this does nothing
+			dispatch.future.Resolve(nil)
+		}()
+		return b.handle(dispatch)
+	})
+}
+
+// handle dispatches to all relevant handlers, and returns any errors to be propagated as an [EventAsyncError].
+// This must be called with at least a read lock held.
+func (b *EventBus) handle(dispatch *busDispatch) []error {
+	// Locate relevant handlers
+	handlers := b.handledEvents[dispatch.event]
+	noHandlersMessage := fmt.Errorf("%w for event %d", ErrNoHandler, dispatch.event)
+
+	// None found
+	if len(handlers) == 0 {
+		b.deadLetter(dispatch)
+		if dispatch.results != nil {
+			dispatch.results.Resolve([]HandlerResult{{Err: noHandlersMessage}})
+		}
+		// Check if this is already an EventAsyncError
+		if dispatch.event != EventAsyncError {
+			dispatch.future.Resolve(noHandlersMessage)
+			return []error{noHandlersMessage}
+		}
+		return nil
+	}
+
+	if dispatch.results != nil {
+		return b.handleAll(dispatch, handlers)
+	}
+
+	// Dispatch to all relevant handlers
+	var errs []error
+	for id := range handlers {
+		handler := b.handlers[id]
+		if handler == nil || id == dispatch.skip || !accepts(handler, dispatch.params) {
+			continue
+		}
+		err := b.callHandler(id, handler, dispatch)
+		if err != nil {
+			// Return first error
+			dispatch.future.Resolve(err)
+			errs = append(errs, fmt.Errorf("handler '%s' failed to handle event %d: %v", id, dispatch.event, err))
 		}
 	}
+	return errs
+}
+
+// handleAll runs all handlers in parallel and resolves the dispatch's results.
+func (b *EventBus) handleAll(dispatch *busDispatch, handlers set.Set[HandlerID]) []error {
+	var (
+		wg      sync.WaitGroup
+		results = make([]HandlerResult, 0, len(handlers))
+	)
+	for id := range handlers {
+		handler := b.handlers[id]
+		if handler == nil || id == dispatch.skip || !accepts(handler, dispatch.params) {
+			continue
+		}
+		results = append(results, HandlerResult{HandlerID: id})
+	}
+	slices.SortFunc(results, func(a, b HandlerResult) int {
+		return cmp.Compare(a.HandlerID, b.HandlerID)
+	})
+	wg.Add(len(results))
+	for i := range results {
+		handler := b.handlers[results[i].HandlerID]
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			results[i].Err = b.callHandler(results[i].HandlerID, handler, dispatch)
+			results[i].Duration = time.Since(start)
+		}()
+	}
+	wg.Wait()
+	dispatch.results.Resolve(results)
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("handler '%s' failed to handle event %d: %v", result.HandlerID, dispatch.event, result.Err))
+		}
+	}
+	return errs
 }
 
 // Stop will stop the [EventBus] and immediately return without waiting for processing to complete in the background.
@@ -386,6 +578,36 @@ func (b *EventBus) Stop() {
 	})
 }
 
+// Drain stops the [EventBus] from accepting new dispatches, and waits for everything already queued to be processed.
+// The number of dispatches processed while draining is returned, which gives visibility into how much work remained when shutting down.
+// If the context is done before draining completes, then the number processed so far is returned with the context's error, and processing continues in the background.
+func (b *EventBus) Drain(ctx context.Context) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if b.events == nil {
+		return 0, nil
+	}
+	before := b.processed.Load()
+	b.Stop()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.doneDispatching.Wait()
+	}()
+	select {
+	case <-done:
+		return int(b.processed.Load() - before), nil
+	case <-ctx.Done():
+		return int(b.processed.Load() - before), ctx.Err()
+	}
+}
+
+// Pending returns the approximate number of dispatches that are queued or being handled.
+func (b *EventBus) Pending() int {
+	return b.queueDepth() + int(b.busy.Load())
+}
+
 // AwaitStop will halt event processing for the [EventBus] if it's running, and wait for processing to stop.
 // The given timeout value will be used to set a deadline for stopping.
 // Calling this when the [EventBus] is already stopped will return immediately.
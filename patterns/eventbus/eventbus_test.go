@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -250,3 +251,83 @@ func testShouldNotFail(received *atomic.Bool) func(err error) {
 		received.Store(true)
 	}
 }
+
+func TestEventBus_DispatchAll(t *testing.T) {
+	var asyncErrs atomic.Int32
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterErrorHandler("errors", func(err error) {
+		asyncErrs.Add(1)
+	})
+
+	release := make(chan struct{})
+	bus.RegisterFunc("b-slow", testEvent, func(evt Event, params ...Param) error {
+		<-release
+		return nil
+	})
+	bus.RegisterFunc("a-failing", testEvent, func(evt Event, params ...Param) error {
+		// The slow handler can only finish if handlers run in parallel.
+		close(release)
+		return assert.AnError
+	})
+
+	results := bus.DispatchAll(testEvent, "A message").Await(testAwaitTimeout)
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, HandlerID("a-failing"), results[0].HandlerID)
+		assert.ErrorIs(t, results[0].Err, assert.AnError)
+		assert.Equal(t, HandlerID("b-slow"), results[1].HandlerID)
+		assert.NoError(t, results[1].Err)
+	}
+
+	results = bus.DispatchAll(testNotHandledEvent).Await(testAwaitTimeout)
+	if assert.Len(t, results, 1) {
+		assert.Empty(t, results[0].HandlerID)
+		assert.ErrorIs(t, results[0].Err, ErrNoHandler)
+	}
+	results = bus.DispatchAll(EventNone).Await(testAwaitTimeout)
+	if assert.Len(t, results, 1) {
+		assert.ErrorIs(t, results[0].Err, ErrInvalidEvent)
+	}
+
+	bus.AwaitStop(testShutdownTimeout)
+	assert.Equal(t, int32(3), asyncErrs.Load(), "Handler error, missing handler, and invalid event should be reported")
+	results = bus.DispatchAll(testEvent).Await(testAwaitTimeout)
+	if assert.Len(t, results, 1) {
+		assert.ErrorIs(t, results[0].Err, ErrShuttingDown)
+	}
+}
+
+func TestEventBus_DispatchRanked(t *testing.T) {
+	var (
+		mux     sync.Mutex
+		handled []string
+		release = make(chan struct{})
+	)
+	bus := NewEventBus().Start(context.Background())
+	bus.RegisterFunc("recorder", testEvent, func(evt Event, params ...Param) error {
+		name := params[0].(string)
+		if name == "blocker" {
+			<-release
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		handled = append(handled, name)
+		return nil
+	})
+
+	bus.Dispatch(testEvent, "blocker")
+	for i := 0; i < 5; i++ {
+		bus.Dispatch(testEvent, fmt.Sprintf("normal-%d", i))
+	}
+	bus.DispatchRanked(testEvent, 1, "low")
+	bus.DispatchRanked(testEvent, 10, "urgent")
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	bus.AwaitStop(testShutdownTimeout)
+
+	assert.Len(t, handled, 8)
+	urgent := slices.Index(handled, "urgent")
+	low := slices.Index(handled, "low")
+	assert.Less(t, urgent, low, "Higher priority should be handled first")
+	assert.Less(t, low, slices.Index(handled, "normal-4"), "Ranked events should preempt queued events")
+}
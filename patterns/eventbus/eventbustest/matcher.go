@@ -0,0 +1,46 @@
+package eventbustest
+
+import (
+	"github.com/saylorsolutions/x/patterns/eventbus"
+	"reflect"
+)
+
+// Matcher reports whether the params of a dispatch are expected.
+type Matcher func(params []eventbus.Param) bool
+
+// ParamsEqual matches dispatches with exactly the given params, compared with [reflect.DeepEqual].
+func ParamsEqual(params ...eventbus.Param) Matcher {
+	return func(actual []eventbus.Param) bool {
+		if len(actual) != len(params) {
+			return false
+		}
+		for i := range params {
+			if !reflect.DeepEqual(params[i], actual[i]) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// ParamsMatch matches dispatches with params that pass the [eventbus.ParamSpec], so the same assertions used by handlers can be used in tests.
+func ParamsMatch(minParams int, assertions ...eventbus.ParamAssertion) Matcher {
+	spec := eventbus.ParamSpec(minParams, assertions...)
+	return func(actual []eventbus.Param) bool {
+		return len(spec(actual)) == 0
+	}
+}
+
+// FirstParam matches dispatches with a first param of type T that passes the check.
+func FirstParam[T any](check func(T) bool) Matcher {
+	if check == nil {
+		panic("nil check")
+	}
+	return func(actual []eventbus.Param) bool {
+		if len(actual) == 0 {
+			return false
+		}
+		val, ok := eventbus.AssertParam[T](actual[0])
+		return ok && check(val)
+	}
+}
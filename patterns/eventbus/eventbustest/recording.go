@@ -0,0 +1,292 @@
+// Package eventbustest provides a [RecordingBus] for testing code built on an [eventbus.EventBus], without sleeps and atomics to wait for events to be handled.
+package eventbustest
+
+import (
+	"context"
+	"fmt"
+	"github.com/saylorsolutions/x/patterns/eventbus"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// DefaultTimeout is how long a [RecordingBus] waits for events before failing a test.
+var DefaultTimeout = 5 * time.Second
+
+const errorHandlerID eventbus.HandlerID = "eventbustest-errors"
+
+// RecordingBus is an [eventbus.EventBus] that records every dispatch and handler error, so tests can make assertions about them.
+// It's started when it's created, and stopped when the test completes.
+//
+// Dispatches are recorded with a [eventbus.Journal], so [eventbus.OptJournal] should not be given as an option.
+// [eventbus.EventAsyncError] is not recorded as a dispatch, and errors are available with [RecordingBus.Errors] instead.
+type RecordingBus struct {
+	*eventbus.EventBus
+	journal *eventbus.MemoryJournal
+	stepped bool
+	gates   chan chan struct{}
+	stopped chan struct{}
+
+	mux       sync.Mutex
+	changed   chan struct{} // changed is closed and replaced whenever a dispatch is recorded, delivered, or fails.
+	delivered int
+	errs      []error
+}
+
+// NewRecordingBus creates and starts a [RecordingBus] that delivers events as they're dispatched, like a regular [eventbus.EventBus].
+// Use [RecordingBus.Settle] to wait for dispatched events to be handled.
+func NewRecordingBus(t testing.TB, opts ...eventbus.ConfigOption) *RecordingBus {
+	t.Helper()
+	return newRecordingBus(t, false, opts)
+}
+
+// NewSteppedBus creates and starts a [RecordingBus] that only delivers events when [RecordingBus.Step] is called, one at a time in dispatch order.
+// This allows a test to make assertions between each step of a chain of events.
+// The bus always has a single worker, so [eventbus.OptNumWorkers] is ignored.
+//
+// Errors are not stepped, so they are delivered to error handlers as they occur.
+func NewSteppedBus(t testing.TB, opts ...eventbus.ConfigOption) *RecordingBus {
+	t.Helper()
+	return newRecordingBus(t, true, opts)
+}
+
+func newRecordingBus(t testing.TB, stepped bool, opts []eventbus.ConfigOption) *RecordingBus {
+	t.Helper()
+	b := &RecordingBus{
+		journal: eventbus.NewMemoryJournal(),
+		stepped: stepped,
+		gates:   make(chan chan struct{}),
+		stopped: make(chan struct{}),
+		changed: make(chan struct{}),
+	}
+	opts = append(opts[:len(opts):len(opts)],
+		eventbus.OptJournal(&recorder{MemoryJournal: b.journal, bus: b}),
+		eventbus.OptDeliveryHook(b.deliver),
+	)
+	if stepped {
+		opts = append(opts, eventbus.OptNumWorkers(1))
+	}
+	b.EventBus = eventbus.NewEventBus(opts...)
+	b.RegisterErrorHandler(errorHandlerID, b.recordError)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Start(ctx)
+	t.Cleanup(func() {
+		// Release a worker waiting for a step, so the bus can stop.
+		close(b.stopped)
+		cancel()
+		b.AwaitStop(DefaultTimeout)
+	})
+	return b
+}
+
+// recorder notifies the [RecordingBus] of each recorded dispatch.
+type recorder struct {
+	*eventbus.MemoryJournal
+	bus *RecordingBus
+}
+
+func (r *recorder) Append(entry eventbus.JournalEntry) (uint64, error) {
+	seq, err := r.MemoryJournal.Append(entry)
+	r.bus.update(func() {})
+	return seq, err
+}
+
+// update applies the change with the lock held, and wakes up anything waiting for a change.
+func (b *RecordingBus) update(change func()) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	change()
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func (b *RecordingBus) recordError(err error) {
+	b.update(func() {
+		b.errs = append(b.errs, err)
+	})
+}
+
+// deliver is the [eventbus.DeliveryHook] that counts delivered dispatches, and waits for a step if the bus is stepped.
+func (b *RecordingBus) deliver(evt eventbus.Event, _ []eventbus.Param, deliver func()) {
+	if evt <= eventbus.EventAsyncError {
+		// Errors and internal events aren't recorded.
+		return
+	}
+	if !b.stepped {
+		deliver()
+		b.update(func() {
+			b.delivered++
+		})
+		return
+	}
+	select {
+	case done := <-b.gates:
+		deliver()
+		b.update(func() {
+			b.delivered++
+		})
+		close(done)
+	case <-b.stopped:
+	}
+}
+
+// waitFor waits until cond returns true, or the timeout elapses.
+func (b *RecordingBus) waitFor(timeout time.Duration, cond func() bool) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		b.mux.Lock()
+		ok, changed := cond(), b.changed
+		b.mux.Unlock()
+		if ok {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return false
+		}
+	}
+}
+
+// Undelivered returns the number of recorded dispatches that have not been handled yet.
+func (b *RecordingBus) Undelivered() int {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.undelivered()
+}
+
+// undelivered must be called with the lock held.
+func (b *RecordingBus) undelivered() int {
+	return b.journal.Len() - b.delivered
+}
+
+// Step delivers the next dispatched event to its handlers, and waits for them to return.
+// False is returned if every dispatched event has already been delivered.
+//
+// Step panics if the bus was not created with [NewSteppedBus].
+func (b *RecordingBus) Step() bool {
+	if !b.stepped {
+		panic("eventbustest: Step requires a bus created with NewSteppedBus")
+	}
+	if b.Undelivered() == 0 {
+		return false
+	}
+	done := make(chan struct{})
+	select {
+	case b.gates <- done:
+	case <-b.stopped:
+		return false
+	}
+	<-done
+	return true
+}
+
+// Settle waits until every dispatched event has been handled, including events dispatched by handlers while settling, and fails the test if that takes longer than [DefaultTimeout].
+// A stepped bus is settled by calling [RecordingBus.Step] until no events remain.
+func (b *RecordingBus) Settle(t testing.TB) {
+	t.Helper()
+	if b.stepped {
+		for b.Step() {
+		}
+		return
+	}
+	settled := b.waitFor(DefaultTimeout, func() bool {
+		return b.undelivered() == 0
+	})
+	if !settled {
+		t.Fatalf("Bus did not settle within %s, %d events were not handled", DefaultTimeout, b.Undelivered())
+	}
+}
+
+// Dispatches returns every recorded dispatch, in dispatch order.
+func (b *RecordingBus) Dispatches() []eventbus.JournalEntry {
+	var entries []eventbus.JournalEntry
+	for entry := range b.journal.Entries(0) {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// DispatchesOf returns every recorded dispatch of the event that matches, in dispatch order.
+// A nil [Matcher] matches any params.
+func (b *RecordingBus) DispatchesOf(evt eventbus.Event, matcher Matcher) []eventbus.JournalEntry {
+	return slices.DeleteFunc(b.Dispatches(), func(entry eventbus.JournalEntry) bool {
+		return entry.Event != evt || (matcher != nil && !matcher(entry.Params))
+	})
+}
+
+// Errors returns every error reported by handlers or dispatched with [eventbus.EventBus.DispatchError], in the order they were received.
+func (b *RecordingBus) Errors() []error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return slices.Clone(b.errs)
+}
+
+// AssertDispatched fails the test if the event has not been dispatched with params that match.
+// A nil [Matcher] matches any params.
+func (b *RecordingBus) AssertDispatched(t testing.TB, evt eventbus.Event, matcher Matcher) bool {
+	t.Helper()
+	if len(b.DispatchesOf(evt, matcher)) > 0 {
+		return true
+	}
+	t.Errorf("Event %d was not dispatched with matching params\n%s", evt, b.describe())
+	return false
+}
+
+// AssertNotDispatched fails the test if the event has been dispatched with params that match.
+// A nil [Matcher] matches any params.
+func (b *RecordingBus) AssertNotDispatched(t testing.TB, evt eventbus.Event, matcher Matcher) bool {
+	t.Helper()
+	if matched := b.DispatchesOf(evt, matcher); len(matched) > 0 {
+		t.Errorf("Event %d was unexpectedly dispatched %d time(s) with matching params\n%s", evt, len(matched), b.describe())
+		return false
+	}
+	return true
+}
+
+// AwaitDispatched waits until the event is dispatched with params that match, and fails the test if that doesn't happen within [DefaultTimeout].
+// This is useful for events dispatched asynchronously by handlers.
+// A nil [Matcher] matches any params.
+func (b *RecordingBus) AwaitDispatched(t testing.TB, evt eventbus.Event, matcher Matcher) eventbus.JournalEntry {
+	t.Helper()
+	var found eventbus.JournalEntry
+	ok := b.waitFor(DefaultTimeout, func() bool {
+		matched := b.DispatchesOf(evt, matcher)
+		if len(matched) == 0 {
+			return false
+		}
+		found = matched[0]
+		return true
+	})
+	if !ok {
+		t.Fatalf("Event %d was not dispatched with matching params within %s\n%s", evt, DefaultTimeout, b.describe())
+	}
+	return found
+}
+
+// AssertNoErrors fails the test if any errors have been reported to the bus.
+func (b *RecordingBus) AssertNoErrors(t testing.TB) bool {
+	t.Helper()
+	errs := b.Errors()
+	if len(errs) == 0 {
+		return true
+	}
+	t.Errorf("Expected no errors, but %d were reported: %v", len(errs), errs)
+	return false
+}
+
+// describe lists the recorded dispatches for failure messages.
+func (b *RecordingBus) describe() string {
+	dispatches := b.Dispatches()
+	if len(dispatches) == 0 {
+		return "No events were dispatched"
+	}
+	msg := "Dispatched events:"
+	for _, entry := range dispatches {
+		msg += fmt.Sprintf("\n  %d: event %d %v", entry.Seq, entry.Event, entry.Params)
+	}
+	return msg
+}
@@ -0,0 +1,87 @@
+package eventbustest
+
+import (
+	"errors"
+	"github.com/saylorsolutions/x/patterns/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	eventOrderPlaced eventbus.Event = iota + 2
+	eventOrderShipped
+	eventOrderFailed
+)
+
+func registerOrderHandlers(bus *RecordingBus, shipped *[]string) {
+	bus.RegisterFunc("orders", eventOrderPlaced, func(_ eventbus.Event, params ...eventbus.Param) error {
+		id, ok := eventbus.AssertParam[string](params[0])
+		if !ok {
+			return errors.New("invalid order id")
+		}
+		bus.Dispatch(eventOrderShipped, id)
+		return nil
+	})
+	bus.RegisterFunc("shipping", eventOrderShipped, func(_ eventbus.Event, params ...eventbus.Param) error {
+		*shipped = append(*shipped, params[0].(string))
+		return nil
+	})
+}
+
+func TestRecordingBus(t *testing.T) {
+	bus := NewRecordingBus(t)
+	var shipped []string
+	registerOrderHandlers(bus, &shipped)
+
+	bus.Dispatch(eventOrderPlaced, "order-1")
+	bus.Settle(t)
+	assert.Equal(t, []string{"order-1"}, shipped)
+	assert.True(t, bus.AssertDispatched(t, eventOrderShipped, ParamsEqual("order-1")))
+	assert.True(t, bus.AssertNotDispatched(t, eventOrderFailed, nil))
+	assert.Len(t, bus.DispatchesOf(eventOrderShipped, FirstParam(func(id string) bool {
+		return id == "order-1"
+	})), 1)
+	bus.AssertNoErrors(t)
+
+	bus.Dispatch(eventOrderPlaced, 5)
+	bus.Settle(t)
+	errs := bus.Errors()
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "invalid order id")
+
+	bus.Dispatch(eventOrderFailed, "order-2")
+	entry := bus.AwaitDispatched(t, eventOrderFailed, ParamsMatch(1, eventbus.IsType[string]()))
+	assert.Equal(t, []eventbus.Param{"order-2"}, entry.Params)
+}
+
+func TestSteppedBus(t *testing.T) {
+	bus := NewSteppedBus(t)
+	var shipped []string
+	registerOrderHandlers(bus, &shipped)
+
+	bus.Dispatch(eventOrderPlaced, "order-1")
+	assert.Equal(t, 1, bus.Undelivered())
+	assert.Len(t, bus.Dispatches(), 1)
+
+	require.True(t, bus.Step())
+	assert.True(t, bus.AssertDispatched(t, eventOrderShipped, nil), "Placing the order should dispatch the shipment")
+	assert.Empty(t, shipped, "Shipment should not be handled until the next step")
+
+	require.True(t, bus.Step())
+	assert.Equal(t, []string{"order-1"}, shipped)
+	assert.False(t, bus.Step(), "No events should remain")
+
+	bus.Dispatch(eventOrderPlaced, "order-2")
+	bus.Dispatch(eventOrderPlaced, "order-3")
+	bus.Settle(t)
+	assert.Equal(t, []string{"order-1", "order-2", "order-3"}, shipped)
+	assert.Zero(t, bus.Undelivered())
+}
+
+func TestRecordingBus_StepPanics(t *testing.T) {
+	bus := NewRecordingBus(t)
+	assert.Panics(t, func() {
+		bus.Step()
+	})
+}
@@ -0,0 +1,65 @@
+package eventbus
+
+import (
+	"context"
+)
+
+// filteredHandler marks a handler registered with a predicate, which the bus checks with accepts before calling it.
+type filteredHandler struct {
+	predicate func(params ...Param) bool
+	handler   Handler
+}
+
+func (h *filteredHandler) HandleEvent(evt Event, params ...Param) error {
+	return h.HandleEventCtx(context.Background(), evt, params...)
+}
+
+func (h *filteredHandler) HandleEventCtx(ctx context.Context, evt Event, params ...Param) error {
+	return withContext(ctx, h.handler).HandleEvent(evt, params...)
+}
+
+func (h *filteredHandler) Stop() {
+	h.handler.Stop()
+}
+
+// accepts reports whether the handler should receive a dispatch with the params.
+// Only handlers registered with [EventBus.RegisterFiltered] may reject params.
+func accepts(handler Handler, params []Param) bool {
+	filtered, ok := handler.(*filteredHandler)
+	return !ok || filtered.predicate(params...)
+}
+
+// RegisterFiltered registers a [Handler] that only receives events with params that match the predicate.
+// This moves guard logic out of the handler, and the bus skips the handler entirely for other events, so middleware, timeouts, and metrics don't apply to them.
+// [MatchesSpec] can be used to create a predicate from a [ParamSpec].
+//
+// The predicate is called from worker goroutines for every dispatch of the event, so it must be safe for concurrent use and should return quickly.
+// An event skipped by every filtered handler is not reported with [ErrNoHandler], and [EventBus.DispatchAll] only includes results from handlers that received it.
+func (b *EventBus) RegisterFiltered(id HandlerID, handledEvent Event, predicate func(params ...Param) bool, handler Handler) {
+	if predicate == nil {
+		panic("nil predicate")
+	}
+	if handler == nil {
+		panic("nil handler")
+	}
+	b.Register(id, handledEvent, &filteredHandler{
+		predicate: predicate,
+		handler:   handler,
+	})
+}
+
+// RegisterFilteredFunc is the same as [EventBus.RegisterFiltered], but accepts a [HandlerFunc].
+func (b *EventBus) RegisterFilteredFunc(id HandlerID, handledEvent Event, predicate func(params ...Param) bool, handler HandlerFunc) {
+	if handler == nil {
+		panic("nil handler")
+	}
+	b.RegisterFiltered(id, handledEvent, predicate, handler)
+}
+
+// MatchesSpec returns a predicate for [EventBus.RegisterFiltered] that matches params passing the [ParamSpec].
+func MatchesSpec(minParams int, assertions ...ParamAssertion) func(params ...Param) bool {
+	spec := ParamSpec(minParams, assertions...)
+	return func(params ...Param) bool {
+		return len(spec(params)) == 0
+	}
+}
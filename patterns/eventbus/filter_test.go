@@ -0,0 +1,62 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBus_RegisterFiltered(t *testing.T) {
+	var (
+		mux      sync.Mutex
+		received []Param
+		handled  []HandlerID
+	)
+	bus := NewEventBus(OptMetricsHook(func(metric Metric) {
+		if metric.Kind == MetricHandled {
+			mux.Lock()
+			handled = append(handled, metric.HandlerID)
+			mux.Unlock()
+		}
+	})).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterFilteredFunc("admins", testEvent, func(params ...Param) bool {
+		return len(params) > 0 && params[0] == "admin"
+	}, func(evt Event, params ...Param) error {
+		mux.Lock()
+		defer mux.Unlock()
+		received = append(received, params[0])
+		return nil
+	})
+	bus.RegisterFunc("all", testEvent, func(evt Event, params ...Param) error {
+		return nil
+	})
+
+	require.NoError(t, bus.DispatchResult(testEvent, "user").Await(time.Second))
+	require.NoError(t, bus.DispatchResult(testEvent, "admin").Await(time.Second))
+	mux.Lock()
+	defer mux.Unlock()
+	assert.Equal(t, []Param{"admin"}, received)
+	assert.ElementsMatch(t, []HandlerID{"all", "all", "admins"}, handled, "Filtered handlers should be skipped without being called")
+}
+
+func TestEventBus_RegisterFiltered_DispatchAll(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterFilteredFunc("ints", testEvent, MatchesSpec(1, IsType[int]()), func(evt Event, params ...Param) error {
+		return nil
+	})
+	bus.RegisterFunc("all", testEvent, func(evt Event, params ...Param) error {
+		return nil
+	})
+
+	results := bus.DispatchAll(testEvent, "not an int").Await(testAwaitTimeout)
+	require.Len(t, results, 1)
+	assert.Equal(t, HandlerID("all"), results[0].HandlerID)
+
+	results = bus.DispatchAll(testEvent, 5).Await(testAwaitTimeout)
+	assert.Len(t, results, 2)
+}
@@ -0,0 +1,286 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/syncx"
+	"io"
+	"iter"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	ErrJournal   = errors.New("journal error")
+	ErrNoJournal = errors.New("no journal configured")
+)
+
+// JournalEntry is a record of a single dispatch.
+type JournalEntry struct {
+	Seq      uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	Event    Event     `json:"event"`
+	Key      string    `json:"key,omitempty"`
+	Priority uint      `json:"priority,omitempty"`
+	Params   []Param   `json:"params,omitempty"`
+}
+
+// Journal records every dispatch to an [EventBus], so events can be replayed with [EventBus.Replay].
+// This is useful for recovering from a crash, or for reproducing a sequence of events in tests.
+type Journal interface {
+	// Append records the entry and returns its sequence number.
+	// The entry's Seq field is ignored, since the Journal assigns sequence numbers starting at 1.
+	Append(entry JournalEntry) (uint64, error)
+	// Entries iterates over recorded entries with a sequence number >= from, in order.
+	Entries(from uint64) iter.Seq2[JournalEntry, error]
+}
+
+// OptJournal configures the [EventBus] to record every dispatch to the [Journal], except for [EventAsyncError].
+// Entries are recorded as they are dispatched, before they're handled.
+// Failing to record an entry is reported as an [EventAsyncError] wrapping [ErrJournal], and doesn't prevent the dispatch.
+func OptJournal(journal Journal) ConfigOption {
+	return func(conf *busConf) error {
+		if journal == nil {
+			return errors.New("nil journal")
+		}
+		conf.journal = journal
+		return nil
+	}
+}
+
+// record counts the dispatch, and appends it to the configured journal, if any.
+func (b *EventBus) record(dispatch *busDispatch, priority uint) {
+	b.observeDispatched(dispatch.event)
+	if b.conf.journal == nil || dispatch.replay || dispatch.event == EventAsyncError || dispatch.event == eventReply {
+		return
+	}
+	_, err := b.conf.journal.Append(JournalEntry{
+		Time:     time.Now(),
+		Event:    dispatch.event,
+		Key:      dispatch.key,
+		Priority: priority,
+		Params:   dispatch.params,
+	})
+	if err != nil {
+		b.DispatchError(fmt.Errorf("%w: failed to record event %d: %v", ErrJournal, dispatch.event, err))
+	}
+}
+
+// Replay dispatches every entry in the configured [Journal] with a sequence number >= from, in order.
+// This is intended to be used with a freshly constructed [EventBus] to recover state after a crash.
+// Replayed dispatches are not recorded in the [Journal] again, and are dispatched with their original ordering key and priority.
+//
+// Replay returns [ErrNoJournal] if no [Journal] is configured with [OptJournal], or [ErrShuttingDown] if the [EventBus] stops during replay.
+// Replay stops early if the context is cancelled, returning the context's error.
+func (b *EventBus) Replay(ctx context.Context, from uint64) error {
+	if b.conf.journal == nil {
+		return ErrNoJournal
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for entry, err := range b.conf.journal.Entries(from) {
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrJournal, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.Event == EventNone {
+			continue
+		}
+		dispatch := &busDispatch{
+			event:  entry.Event,
+			params: entry.Params,
+			future: syncx.SymbolicFuture[error](),
+			replay: true,
+		}
+		var ok bool
+		if len(entry.Key) > 0 {
+			ok = b.pushKeyed(entry.Key, dispatch)
+		} else {
+			ok = b.events.PushRanked(dispatch, entry.Priority)
+		}
+		if !ok {
+			return ErrShuttingDown
+		}
+	}
+	return nil
+}
+
+// MemoryJournal is a [Journal] that keeps entries in memory.
+// It's useful for testing, and for replaying events into another [EventBus] in the same process.
+type MemoryJournal struct {
+	mux     sync.RWMutex
+	entries []JournalEntry
+}
+
+func NewMemoryJournal() *MemoryJournal {
+	return &MemoryJournal{}
+}
+
+func (j *MemoryJournal) Append(entry JournalEntry) (uint64, error) {
+	return syncx.LockFuncT(&j.mux, func() uint64 {
+		entry.Seq = uint64(len(j.entries)) + 1
+		j.entries = append(j.entries, entry)
+		return entry.Seq
+	}), nil
+}
+
+func (j *MemoryJournal) Entries(from uint64) iter.Seq2[JournalEntry, error] {
+	return func(yield func(JournalEntry, error) bool) {
+		// Copy under lock, so a consumer that dispatches while iterating doesn't deadlock.
+		entries := syncx.RLockFuncT(&j.mux, func() []JournalEntry {
+			start := from
+			if start > 0 {
+				start--
+			}
+			if start >= uint64(len(j.entries)) {
+				return nil
+			}
+			return append([]JournalEntry(nil), j.entries[start:]...)
+		})
+		for _, entry := range entries {
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of entries in the [MemoryJournal].
+func (j *MemoryJournal) Len() int {
+	return syncx.RLockFuncT(&j.mux, func() int {
+		return len(j.entries)
+	})
+}
+
+// FileJournal is a [Journal] that appends entries to a file as JSON lines.
+//
+// Params are encoded as JSON, so they must be JSON serializable, and are decoded as generic JSON values during replay.
+// For example, an int Param is replayed as a float64, and a struct Param is replayed as a map[string]any.
+// Handlers of journaled events should tolerate these types, or use simple Param types like strings.
+type FileJournal struct {
+	mux  sync.Mutex
+	path string
+	file *os.File
+	size int64
+	seq  uint64
+}
+
+// NewFileJournal opens or creates the journal file at the path.
+// Sequence numbers continue from the last entry in an existing file.
+func NewFileJournal(path string) (*FileJournal, error) {
+	j := &FileJournal{path: path}
+	for entry, err := range j.readEntries(0, -1) {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				break
+			}
+			return nil, err
+		}
+		j.seq = entry.Seq
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	j.file = f
+	j.size = info.Size()
+	return j, nil
+}
+
+func (j *FileJournal) Append(entry JournalEntry) (uint64, error) {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	if j.file == nil {
+		return 0, os.ErrClosed
+	}
+	entry.Seq = j.seq + 1
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return 0, err
+	}
+	data = append(data, '\n')
+	n, err := j.file.Write(data)
+	j.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	j.seq = entry.Seq
+	return entry.Seq, nil
+}
+
+func (j *FileJournal) Entries(from uint64) iter.Seq2[JournalEntry, error] {
+	return func(yield func(JournalEntry, error) bool) {
+		// Only read what has been completely written, so a concurrent Append isn't read partially.
+		size := syncx.LockFuncT(&j.mux, func() int64 {
+			return j.size
+		})
+		for entry, err := range j.readEntries(from, size) {
+			if !yield(entry, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readEntries reads up to limit bytes of the journal file, or the whole file if limit is < 0.
+func (j *FileJournal) readEntries(from uint64, limit int64) iter.Seq2[JournalEntry, error] {
+	return func(yield func(JournalEntry, error) bool) {
+		f, err := os.Open(j.path)
+		if err != nil {
+			yield(JournalEntry{}, err)
+			return
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		var r io.Reader = f
+		if limit >= 0 {
+			r = io.LimitReader(f, limit)
+		}
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+		for scanner.Scan() {
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+			var entry JournalEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				yield(JournalEntry{}, fmt.Errorf("malformed journal entry: %w", err))
+				return
+			}
+			if entry.Seq < from {
+				continue
+			}
+			if !yield(entry, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(JournalEntry{}, err)
+		}
+	}
+}
+
+// Close closes the journal file. Entries may still be read after closing, but not appended.
+func (j *FileJournal) Close() error {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
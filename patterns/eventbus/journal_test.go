@@ -0,0 +1,91 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBus_Journal(t *testing.T) {
+	journal := NewMemoryJournal()
+	bus := NewEventBus(OptJournal(journal)).Start(context.Background())
+	bus.RegisterFunc("handler", testEvent, func(evt Event, params ...Param) error {
+		return nil
+	})
+	bus.Dispatch(testEvent, "a")
+	bus.DispatchKeyed("key", testEvent, "b")
+	assert.NoError(t, bus.DispatchResult(testEvent, "c").Await(time.Second))
+	bus.DispatchError(assert.AnError)
+	bus.AwaitStop(testShutdownTimeout)
+	require.Equal(t, 3, journal.Len(), "Async errors should not be journaled")
+
+	var (
+		mux      sync.Mutex
+		replayed []Param
+	)
+	fresh := NewEventBus(OptJournal(journal)).Start(context.Background())
+	defer fresh.AwaitStop(testShutdownTimeout)
+	fresh.RegisterFunc("handler", testEvent, func(evt Event, params ...Param) error {
+		mux.Lock()
+		defer mux.Unlock()
+		replayed = append(replayed, params[0])
+		return nil
+	})
+	require.NoError(t, fresh.Replay(context.Background(), 2))
+	assert.Eventually(t, func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		return len(replayed) == 2
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []Param{"b", "c"}, replayed)
+	assert.Equal(t, 3, journal.Len(), "Replayed events should not be journaled again")
+
+	assert.ErrorIs(t, NewEventBus().Replay(context.Background(), 0), ErrNoJournal)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, fresh.Replay(ctx, 0), context.Canceled)
+}
+
+func TestFileJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	journal, err := NewFileJournal(path)
+	require.NoError(t, err)
+	seq, err := journal.Append(JournalEntry{Event: testEvent, Params: []Param{"a", 1}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), seq)
+	seq, err = journal.Append(JournalEntry{Event: testEvent, Key: "key"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), seq)
+	require.NoError(t, journal.Close())
+	_, err = journal.Append(JournalEntry{Event: testEvent})
+	assert.Error(t, err)
+
+	reopened, err := NewFileJournal(path)
+	require.NoError(t, err)
+	defer func() {
+		_ = reopened.Close()
+	}()
+	seq, err = reopened.Append(JournalEntry{Event: testEvent})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), seq, "Sequence should continue from the existing file")
+
+	var entries []JournalEntry
+	for entry, err := range reopened.Entries(1) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 3)
+	assert.Equal(t, []Param{"a", float64(1)}, entries[0].Params, "Params are round-tripped as JSON")
+	assert.Equal(t, "key", entries[1].Key)
+
+	entries = nil
+	for entry, err := range reopened.Entries(3) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	assert.Len(t, entries, 1)
+}
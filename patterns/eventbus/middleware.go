@@ -0,0 +1,56 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/syncx"
+	"runtime/debug"
+)
+
+var (
+	ErrHandlerPanic = errors.New("handler panicked")
+)
+
+// Middleware wraps a [Handler] to layer cross-cutting concerns like tracing, timing, panic recovery, or parameter validation onto every handler.
+type Middleware func(next Handler) Handler
+
+// Use adds middleware that wraps every [Handler] call, including handlers registered before Use is called.
+// Middleware is executed in the order given, so the first middleware is the outermost, and sees each event first.
+// Middleware added in later calls to Use is nested inside earlier middleware.
+//
+// Middleware is applied each time an event is handled, so state that should persist across events must be held outside the wrapping [Handler].
+// [Handler.Stop] is called on the registered handler, not the middleware.
+func (b *EventBus) Use(middleware ...Middleware) {
+	for _, mw := range middleware {
+		if mw == nil {
+			panic("nil middleware")
+		}
+	}
+	syncx.LockFunc(&b.mux, func() {
+		b.middleware = append(b.middleware, middleware...)
+	})
+}
+
+// applyMiddleware wraps the handler with all middleware.
+// This must be called with at least a read lock held.
+func (b *EventBus) applyMiddleware(handler Handler) Handler {
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		handler = b.middleware[i](handler)
+	}
+	return handler
+}
+
+// RecoverMiddleware returns a [Middleware] that recovers a panicking [Handler], and returns an error wrapping [ErrHandlerPanic] instead.
+// Without this, a panicking handler crashes the program.
+func RecoverMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(evt Event, params ...Param) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%w while handling event %d: %v\n%s", ErrHandlerPanic, evt, r, debug.Stack())
+				}
+			}()
+			return next.HandleEvent(evt, params...)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBus_Use(t *testing.T) {
+	var (
+		mux   sync.Mutex
+		calls []string
+	)
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(evt Event, params ...Param) error {
+				mux.Lock()
+				calls = append(calls, name)
+				mux.Unlock()
+				return next.HandleEvent(evt, params...)
+			})
+		}
+	}
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterFunc("handler", testEvent, func(evt Event, params ...Param) error {
+		mux.Lock()
+		defer mux.Unlock()
+		calls = append(calls, "handler")
+		return nil
+	})
+	bus.Use(record("first"), record("second"))
+	bus.Use(record("third"))
+
+	assert.NoError(t, bus.DispatchResult(testEvent).Await(time.Second))
+	assert.Equal(t, []string{"first", "second", "third", "handler"}, calls)
+	assert.Panics(t, func() {
+		bus.Use(nil)
+	})
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.Use(RecoverMiddleware())
+	bus.RegisterFunc("panics", testEvent, func(evt Event, params ...Param) error {
+		panic("oops")
+	})
+	err := bus.DispatchResult(testEvent).Await(time.Second)
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+	assert.ErrorContains(t, err, "oops")
+
+	results := bus.DispatchAll(testEvent).Await(time.Second)
+	assert.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, ErrHandlerPanic)
+}
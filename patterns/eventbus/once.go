@@ -0,0 +1,74 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/saylorsolutions/x/syncx"
+	"sync"
+)
+
+type onceHandler struct {
+	bus     *EventBus
+	id      HandlerID
+	handler Handler
+	mux     sync.Mutex
+	done    bool
+}
+
+func (h *onceHandler) HandleEvent(evt Event, params ...Param) error {
+	return h.HandleEventCtx(context.Background(), evt, params...)
+}
+
+func (h *onceHandler) HandleEventCtx(ctx context.Context, evt Event, params ...Param) error {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	if h.done {
+		// The handler is being unregistered.
+		return nil
+	}
+	if err := withContext(ctx, h.handler).HandleEvent(evt, params...); err != nil {
+		return err
+	}
+	h.done = true
+	// Handlers are called with a read lock held, so unregistering must happen in another goroutine.
+	go h.bus.unRegisterHandler(h.id, h)
+	return nil
+}
+
+func (h *onceHandler) Stop() {
+	h.handler.Stop()
+}
+
+// RegisterOnce registers a [Handler] that is automatically unregistered after it successfully handles its first event.
+// If the handler returns an error, then it stays registered to handle the next event.
+// This is useful for awaiting an event like initialization being completed, without manually calling [EventBus.UnRegister].
+//
+// The handler is unregistered asynchronously, so events dispatched concurrently with the first successful call are ignored rather than handled again.
+// The handler's Stop method is called when it's unregistered, like any other [Handler].
+func (b *EventBus) RegisterOnce(id HandlerID, handledEvent Event, handler Handler) {
+	if handler == nil {
+		panic("nil handler")
+	}
+	b.Register(id, handledEvent, &onceHandler{
+		bus:     b,
+		id:      id,
+		handler: handler,
+	})
+}
+
+// RegisterOnceFunc is the same as [EventBus.RegisterOnce], but accepts a [HandlerFunc].
+func (b *EventBus) RegisterOnceFunc(id HandlerID, handledEvent Event, handler HandlerFunc) {
+	if handler == nil {
+		panic("nil handler")
+	}
+	b.RegisterOnce(id, handledEvent, handler)
+}
+
+// unRegisterHandler unregisters the handler only if it's still registered with the ID, so a replacement isn't removed.
+func (b *EventBus) unRegisterHandler(id HandlerID, handler Handler) {
+	syncx.LockFunc(&b.mux, func() {
+		// The handler is always a *onceHandler, so this comparison can't panic with an uncomparable HandlerFunc.
+		if b.handlers[id] == handler {
+			b.removeHandler(id)
+		}
+	})
+}
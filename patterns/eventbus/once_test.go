@@ -0,0 +1,66 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEventBus_RegisterOnce(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	var calls int
+	bus.RegisterOnceFunc("once", testEvent, func(evt Event, params ...Param) error {
+		calls++
+		if calls == 1 {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+	assert.Error(t, bus.DispatchResult(testEvent).Await(time.Second), "Failed calls should keep the handler registered")
+	require.NoError(t, bus.DispatchResult(testEvent).Await(time.Second))
+	assert.Eventually(t, func() bool {
+		return errors.Is(bus.DispatchResult(testEvent).Await(time.Second), ErrNoHandler)
+	}, time.Second, 10*time.Millisecond, "Handler should be unregistered after success")
+	assert.Equal(t, 2, calls)
+}
+
+func TestEventBus_RegisterOnce_Stop(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	stopped := make(chan struct{})
+	bus.RegisterOnce("once", testEvent, &testStopHandler{stopped: stopped})
+	require.NoError(t, bus.DispatchResult(testEvent).Await(time.Second))
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Handler should be stopped when unregistered")
+	}
+
+	// The ID may be reused once the handler is unregistered.
+	assert.Eventually(t, func() bool {
+		return errors.Is(bus.DispatchResult(testEvent).Await(time.Second), ErrNoHandler)
+	}, time.Second, 10*time.Millisecond)
+	bus.RegisterFunc("once", testEvent, func(evt Event, params ...Param) error {
+		return nil
+	})
+	require.NoError(t, bus.DispatchResult(testEvent).Await(time.Second))
+	require.NoError(t, bus.DispatchResult(testEvent).Await(time.Second))
+}
+
+type testStopHandler struct {
+	stopped chan struct{}
+}
+
+func (h *testStopHandler) HandleEvent(Event, ...Param) error {
+	return nil
+}
+
+func (h *testStopHandler) Stop() {
+	close(h.stopped)
+}
@@ -0,0 +1,112 @@
+package eventbus
+
+import (
+	"github.com/saylorsolutions/x/syncx"
+)
+
+// keyState tracks the order of dispatches sharing an ordering key.
+type keyState struct {
+	assigned uint64                  // assigned is the number of dispatches given a sequence number.
+	next     uint64                  // next is the sequence number that should be handled next.
+	running  bool                    // running is true while a worker is handling dispatches for this key.
+	pending  map[uint64]*busDispatch // pending holds dispatches received out of order or while running.
+}
+
+// DispatchKeyed is the same as [EventBus.Dispatch], but events with the same ordering key are handled sequentially, in the order they were dispatched.
+// This is useful when events for the same entity must not be handled concurrently or out of order, like updates to a single record, while events for different entities are still handled in parallel by multiple workers.
+//
+// An empty key has no ordering guarantee, so it's the same as calling [EventBus.Dispatch].
+// Note that a slow handler delays all later events with the same key.
+//
+// This can safely be called from within a [Handler].
+func (b *EventBus) DispatchKeyed(key string, evt Event, params ...Param) {
+	if evt == EventNone {
+		b.DispatchError(ErrInvalidEvent)
+		return
+	}
+	dispatch := &busDispatch{
+		event:  evt,
+		params: params,
+		future: syncx.SymbolicFuture[error](),
+	}
+	b.pushKeyed(key, dispatch)
+}
+
+// DispatchKeyedResult is the same as [EventBus.DispatchResult], with the ordering guarantees of [EventBus.DispatchKeyed].
+//
+// NOTE: This should not be called from within a [Handler], for the same reasons as [EventBus.DispatchResult].
+func (b *EventBus) DispatchKeyedResult(key string, evt Event, params ...Param) syncx.Future[error] {
+	if evt == EventNone {
+		b.DispatchError(ErrInvalidEvent)
+		return syncx.StaticFuture(ErrInvalidEvent)
+	}
+	dispatch := &busDispatch{
+		event:  evt,
+		params: params,
+		future: syncx.NewFuture[error](),
+	}
+	if !b.pushKeyed(key, dispatch) {
+		dispatch.future.Resolve(ErrShuttingDown)
+	}
+	return dispatch.future
+}
+
+// pushKeyed assigns the dispatch a sequence number within its key, and pushes it to the event queue.
+// The sequence number is assigned with the key lock held while pushing, so sequence order matches queue order.
+func (b *EventBus) pushKeyed(key string, dispatch *busDispatch) bool {
+	dispatch.key = key
+	b.record(dispatch, 0)
+	if len(key) == 0 {
+		return b.events.Push(dispatch)
+	}
+	return syncx.LockFuncT(&b.keyMux, func() bool {
+		if b.keys == nil {
+			b.keys = map[string]*keyState{}
+		}
+		state, ok := b.keys[key]
+		if !ok {
+			state = &keyState{pending: map[uint64]*busDispatch{}}
+			b.keys[key] = state
+		}
+		dispatch.seq = state.assigned
+		if !b.events.Push(dispatch) {
+			if state.assigned == state.next && !state.running {
+				delete(b.keys, key)
+			}
+			return false
+		}
+		state.assigned++
+		return true
+	})
+}
+
+// processKeyed handles the dispatch once all earlier dispatches with the same key have been handled.
+// Workers may receive dispatches with the same key concurrently or out of order, so a dispatch that can't be handled yet is left pending.
+// The worker that is handling a key continues with pending dispatches in sequence order until the next one hasn't been received yet.
+func (b *EventBus) processKeyed(dispatch *busDispatch) []error {
+	b.keyMux.Lock()
+	state := b.keys[dispatch.key]
+	state.pending[dispatch.seq] = dispatch
+	if state.running {
+		b.keyMux.Unlock()
+		return nil
+	}
+	state.running = true
+	var errs []error
+	for {
+		next, ok := state.pending[state.next]
+		if !ok {
+			state.running = false
+			if state.next == state.assigned {
+				delete(b.keys, dispatch.key)
+			}
+			b.keyMux.Unlock()
+			return errs
+		}
+		delete(state.pending, state.next)
+		state.next++
+		b.keyMux.Unlock()
+		errs = append(errs, b.process(next)...)
+		b.keyMux.Lock()
+	}
+}
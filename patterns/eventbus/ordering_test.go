@@ -0,0 +1,87 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventBus_DispatchKeyed(t *testing.T) {
+	const (
+		numKeys   = 4
+		numEvents = 50
+	)
+	var (
+		mux        sync.Mutex
+		seen       = map[string][]int{}
+		active     = map[string]bool{}
+		concurrent atomic.Bool
+		maxActive  atomic.Int32
+		curActive  atomic.Int32
+	)
+	bus := NewEventBus(OptNumWorkers(8), OptBufferSize(16)).Start(context.Background())
+	bus.RegisterFunc("ordered", testEvent, func(evt Event, params ...Param) error {
+		key, num := params[0].(string), params[1].(int)
+		mux.Lock()
+		if active[key] {
+			concurrent.Store(true)
+		}
+		active[key] = true
+		mux.Unlock()
+		if n := curActive.Add(1); n > maxActive.Load() {
+			maxActive.Store(n)
+		}
+
+		time.Sleep(time.Duration(num%3) * time.Millisecond)
+
+		curActive.Add(-1)
+		mux.Lock()
+		active[key] = false
+		seen[key] = append(seen[key], num)
+		mux.Unlock()
+		return nil
+	})
+
+	for i := 0; i < numEvents; i++ {
+		for k := 0; k < numKeys; k++ {
+			key := fmt.Sprintf("key-%d", k)
+			bus.DispatchKeyed(key, testEvent, key, i)
+		}
+	}
+	bus.AwaitStop(testShutdownTimeout)
+
+	assert.False(t, concurrent.Load(), "Events with the same key should not be handled concurrently")
+	assert.Greater(t, maxActive.Load(), int32(1), "Events with different keys should be handled in parallel")
+	for k := 0; k < numKeys; k++ {
+		nums := seen[fmt.Sprintf("key-%d", k)]
+		assert.Len(t, nums, numEvents)
+		assert.IsIncreasing(t, nums, "Events should be handled in dispatch order")
+	}
+	assert.Empty(t, bus.keys, "Key state should be cleaned up when idle")
+}
+
+func TestEventBus_DispatchKeyedResult(t *testing.T) {
+	errFailed := errors.New("failed")
+	bus := NewEventBus(OptNumWorkers(2)).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterFunc("fails", testEvent, func(evt Event, params ...Param) error {
+		if params[0] == "fail" {
+			return errFailed
+		}
+		return nil
+	})
+
+	first := bus.DispatchKeyedResult("a", testEvent, "ok")
+	second := bus.DispatchKeyedResult("a", testEvent, "fail")
+	assert.NoError(t, first.Await(testAwaitTimeout))
+	assert.ErrorIs(t, second.Await(testAwaitTimeout), errFailed)
+	assert.ErrorIs(t, bus.DispatchKeyedResult("a", EventNone).Await(testAwaitTimeout), ErrInvalidEvent)
+
+	bus.AwaitStop(testShutdownTimeout)
+	assert.ErrorIs(t, bus.DispatchKeyedResult("a", testEvent, "ok").Await(testAwaitTimeout), ErrShuttingDown)
+}
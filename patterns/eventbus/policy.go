@@ -0,0 +1,277 @@
+package eventbus
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DispatchPolicy wraps a [Handler] to control how events are delivered to it.
+// Policies are applied with [EventBus.RegisterWithPolicy], and state is tracked separately for each [Event] that the handler handles.
+type DispatchPolicy func(bus *EventBus, next Handler) Handler
+
+// RegisterWithPolicy registers the handler like [EventBus.Register], with the given policies applied in order.
+// The first policy sees events first, so PolicySample then PolicyMaxRate will rate limit the sampled events.
+//
+// Policies that defer delivery, like [PolicyDebounce] and [PolicyMaxRate] with [RateQueue], call the handler from a separate goroutine.
+// Errors from deferred deliveries are dispatched as an [EventAsyncError], and deferred events are discarded when the handler is stopped.
+func (b *EventBus) RegisterWithPolicy(id HandlerID, handledEvent Event, handler Handler, policies ...DispatchPolicy) {
+	for i := len(policies) - 1; i >= 0; i-- {
+		handler = policies[i](b, handler)
+	}
+	b.Register(id, handledEvent, handler)
+}
+
+func (b *EventBus) deliverDeferred(next Handler, evt Event, params []Param) {
+	if err := next.HandleEvent(evt, params...); err != nil {
+		b.DispatchError(fmt.Errorf("deferred handling of event %d failed: %w", evt, err))
+	}
+}
+
+// RateExcess determines what [PolicyMaxRate] does with events over the limit.
+type RateExcess int
+
+const (
+	RateDrop  RateExcess = iota // RateDrop discards events over the limit.
+	RateQueue                   // RateQueue delivers events over the limit in order, as the rate allows.
+)
+
+type rateState struct {
+	tokens float64
+	last   time.Time
+	queue  [][]Param
+	timer  *time.Timer
+}
+
+type rateHandler struct {
+	bus    *EventBus
+	next   Handler
+	limit  float64
+	per    time.Duration
+	excess RateExcess
+	now    func() time.Time
+
+	mux     sync.Mutex
+	stopped bool
+	states  map[Event]*rateState
+}
+
+// PolicyMaxRate limits delivery to the given number of events per duration, for each [Event].
+// Bursts up to the limit are allowed, and the excess is either dropped or queued depending on [RateExcess].
+// Queued events are held in memory, so [RateQueue] should only be used when bursts are bounded.
+func PolicyMaxRate(limit int, per time.Duration, excess RateExcess) DispatchPolicy {
+	if limit < 1 {
+		panic("rate limit must be >= 1")
+	}
+	if per <= 0 {
+		panic("rate period must be > 0")
+	}
+	return func(bus *EventBus, next Handler) Handler {
+		return &rateHandler{
+			bus:    bus,
+			next:   next,
+			limit:  float64(limit),
+			per:    per,
+			excess: excess,
+			now:    time.Now,
+			states: map[Event]*rateState{},
+		}
+	}
+}
+
+func (h *rateHandler) state(evt Event) *rateState {
+	st, ok := h.states[evt]
+	if !ok {
+		st = &rateState{tokens: h.limit, last: h.now()}
+		h.states[evt] = st
+	}
+	now := h.now()
+	st.tokens = min(h.limit, st.tokens+h.limit*float64(now.Sub(st.last))/float64(h.per))
+	st.last = now
+	return st
+}
+
+// untilToken returns how long until the next token is available.
+func (h *rateHandler) untilToken(st *rateState) time.Duration {
+	return time.Duration((1 - st.tokens) * float64(h.per) / h.limit)
+}
+
+func (h *rateHandler) HandleEvent(evt Event, params ...Param) error {
+	h.mux.Lock()
+	if h.stopped {
+		h.mux.Unlock()
+		return nil
+	}
+	st := h.state(evt)
+	if len(st.queue) == 0 && st.tokens >= 1 {
+		st.tokens--
+		h.mux.Unlock()
+		return h.next.HandleEvent(evt, params...)
+	}
+	if h.excess == RateQueue {
+		st.queue = append(st.queue, params)
+		if st.timer == nil {
+			st.timer = time.AfterFunc(h.untilToken(st), func() {
+				h.drain(evt)
+			})
+		}
+	}
+	h.mux.Unlock()
+	return nil
+}
+
+func (h *rateHandler) drain(evt Event) {
+	h.mux.Lock()
+	if h.stopped {
+		h.mux.Unlock()
+		return
+	}
+	st := h.state(evt)
+	var ready [][]Param
+	for len(st.queue) > 0 && st.tokens >= 1 {
+		st.tokens--
+		ready = append(ready, st.queue[0])
+		st.queue = st.queue[1:]
+	}
+	if len(st.queue) > 0 {
+		st.timer = time.AfterFunc(h.untilToken(st), func() {
+			h.drain(evt)
+		})
+	} else {
+		st.timer = nil
+	}
+	h.mux.Unlock()
+	for _, params := range ready {
+		h.bus.deliverDeferred(h.next, evt, params)
+	}
+}
+
+func (h *rateHandler) Stop() {
+	h.mux.Lock()
+	h.stopped = true
+	for _, st := range h.states {
+		if st.timer != nil {
+			st.timer.Stop()
+		}
+	}
+	h.states = map[Event]*rateState{}
+	h.mux.Unlock()
+	h.next.Stop()
+}
+
+type debounceState struct {
+	gen    int
+	params []Param
+	timer  *time.Timer
+}
+
+type debounceHandler struct {
+	bus    *EventBus
+	next   Handler
+	window time.Duration
+
+	mux     sync.Mutex
+	stopped bool
+	states  map[Event]*debounceState
+}
+
+// PolicyDebounce only delivers the last event of a burst, once no more of the same [Event] have been dispatched for the window duration.
+// This is useful for noisy sources like file watchers, where only the final state matters.
+func PolicyDebounce(window time.Duration) DispatchPolicy {
+	if window <= 0 {
+		panic("debounce window must be > 0")
+	}
+	return func(bus *EventBus, next Handler) Handler {
+		return &debounceHandler{
+			bus:    bus,
+			next:   next,
+			window: window,
+			states: map[Event]*debounceState{},
+		}
+	}
+}
+
+func (h *debounceHandler) HandleEvent(evt Event, params ...Param) error {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	if h.stopped {
+		return nil
+	}
+	st, ok := h.states[evt]
+	if !ok {
+		st = &debounceState{}
+		h.states[evt] = st
+	}
+	if st.timer != nil {
+		st.timer.Stop()
+	}
+	// The generation prevents a timer that already fired from delivering after being superseded.
+	st.gen++
+	st.params = params
+	gen := st.gen
+	st.timer = time.AfterFunc(h.window, func() {
+		h.fire(evt, gen)
+	})
+	return nil
+}
+
+func (h *debounceHandler) fire(evt Event, gen int) {
+	h.mux.Lock()
+	st, ok := h.states[evt]
+	if h.stopped || !ok || st.gen != gen {
+		h.mux.Unlock()
+		return
+	}
+	delete(h.states, evt)
+	h.mux.Unlock()
+	h.bus.deliverDeferred(h.next, evt, st.params)
+}
+
+func (h *debounceHandler) Stop() {
+	h.mux.Lock()
+	h.stopped = true
+	for _, st := range h.states {
+		st.timer.Stop()
+	}
+	h.states = map[Event]*debounceState{}
+	h.mux.Unlock()
+	h.next.Stop()
+}
+
+type sampleHandler struct {
+	next  Handler
+	every uint64
+
+	mux    sync.Mutex
+	counts map[Event]uint64
+}
+
+// PolicySample only delivers every nth occurrence of each [Event], starting with the first.
+// Sampling is deterministic, rather than random, to keep event handling predictable.
+func PolicySample(every int) DispatchPolicy {
+	if every < 1 {
+		panic("sample rate must be >= 1")
+	}
+	return func(_ *EventBus, next Handler) Handler {
+		return &sampleHandler{
+			next:   next,
+			every:  uint64(every),
+			counts: map[Event]uint64{},
+		}
+	}
+}
+
+func (h *sampleHandler) HandleEvent(evt Event, params ...Param) error {
+	h.mux.Lock()
+	count := h.counts[evt]
+	h.counts[evt]++
+	h.mux.Unlock()
+	if count%h.every != 0 {
+		return nil
+	}
+	return h.next.HandleEvent(evt, params...)
+}
+
+func (h *sampleHandler) Stop() {
+	h.next.Stop()
+}
@@ -0,0 +1,132 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testRecorder struct {
+	mux      sync.Mutex
+	received []Param
+	stopped  atomic.Bool
+	ch       chan struct{}
+}
+
+func newTestRecorder() *testRecorder {
+	return &testRecorder{ch: make(chan struct{}, 100)}
+}
+
+func (r *testRecorder) HandleEvent(_ Event, params ...Param) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.received = append(r.received, params[0])
+	r.ch <- struct{}{}
+	return nil
+}
+
+func (r *testRecorder) Stop() {
+	r.stopped.Store(true)
+}
+
+func (r *testRecorder) Received() []Param {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return append([]Param(nil), r.received...)
+}
+
+func (r *testRecorder) await(t *testing.T, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-r.ch:
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for event %d", i+1)
+		}
+	}
+}
+
+func TestPolicyMaxRate_Drop(t *testing.T) {
+	rec := newTestRecorder()
+	h := PolicyMaxRate(2, time.Hour, RateDrop)(NewEventBus(), rec)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, h.HandleEvent(testEvent, i))
+	}
+	assert.Equal(t, []Param{0, 1}, rec.Received(), "Events over the limit should be dropped")
+	assert.NoError(t, h.HandleEvent(testNotHandledEvent, 10))
+	assert.Equal(t, []Param{0, 1, 10}, rec.Received(), "Limits should be per event")
+	h.Stop()
+	assert.True(t, rec.stopped.Load())
+}
+
+func TestPolicyMaxRate_Queue(t *testing.T) {
+	rec := newTestRecorder()
+	h := PolicyMaxRate(1, 20*time.Millisecond, RateQueue)(NewEventBus(), rec)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, h.HandleEvent(testEvent, i))
+	}
+	rec.await(t, 3)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond, "Queued events should be delivered at the limited rate")
+	assert.Equal(t, []Param{0, 1, 2}, rec.Received(), "Queued events should be delivered in order")
+}
+
+func TestPolicyDebounce(t *testing.T) {
+	rec := newTestRecorder()
+	h := PolicyDebounce(30*time.Millisecond)(NewEventBus(), rec)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, h.HandleEvent(testEvent, i))
+	}
+	rec.await(t, 1)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []Param{4}, rec.Received(), "Only the last event should be delivered")
+
+	assert.NoError(t, h.HandleEvent(testEvent, 5))
+	h.Stop()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []Param{4}, rec.Received(), "Pending events should be discarded on stop")
+}
+
+func TestPolicySample(t *testing.T) {
+	rec := newTestRecorder()
+	h := PolicySample(3)(NewEventBus(), rec)
+	for i := 0; i < 7; i++ {
+		assert.NoError(t, h.HandleEvent(testEvent, i))
+	}
+	assert.Equal(t, []Param{0, 3, 6}, rec.Received())
+}
+
+func TestEventBus_RegisterWithPolicy(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	rec := newTestRecorder()
+	bus.RegisterWithPolicy("sampled", testEvent, rec, PolicySample(2), PolicyMaxRate(1, time.Hour, RateDrop))
+	for i := 0; i < 4; i++ {
+		bus.Dispatch(testEvent, i)
+	}
+	bus.AwaitStop(testShutdownTimeout)
+	assert.Equal(t, []Param{0}, rec.Received(), "Event 2 is sampled but over the rate limit")
+	assert.True(t, rec.stopped.Load())
+
+}
+
+func TestPolicyDebounce_Error(t *testing.T) {
+	errs := make(chan error, 1)
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterErrorHandler("errors", func(err error) {
+		errs <- err
+	})
+	failing := PolicyDebounce(time.Millisecond)(bus, HandlerFunc(func(evt Event, params ...Param) error {
+		return assert.AnError
+	}))
+	assert.NoError(t, failing.HandleEvent(testEvent, 1))
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, assert.AnError)
+	case <-time.After(time.Second):
+		t.Fatal("Deferred error should be dispatched")
+	}
+}
@@ -0,0 +1,33 @@
+package eventbus
+
+import (
+	"github.com/saylorsolutions/x/syncx"
+	"sync"
+)
+
+var (
+	// symbolicFuture is shared by all dispatches that don't return a result, since it has no state.
+	symbolicFuture = syncx.SymbolicFuture[error]()
+
+	dispatchPool = sync.Pool{
+		New: func() any {
+			return new(busDispatch)
+		},
+	}
+)
+
+// newDispatch gets a [busDispatch] from the pool to reduce per-event allocations.
+func newDispatch(evt Event, params []Param, future syncx.Future[error]) *busDispatch {
+	dispatch := dispatchPool.Get().(*busDispatch)
+	dispatch.event = evt
+	dispatch.params = params
+	dispatch.future = future
+	return dispatch
+}
+
+// releaseDispatch resets the dispatch and returns it to the pool.
+// This must only be called once nothing references the dispatch, so params are not reused since handlers may retain them.
+func releaseDispatch(dispatch *busDispatch) {
+	*dispatch = busDispatch{}
+	dispatchPool.Put(dispatch)
+}
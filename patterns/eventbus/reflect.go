@@ -0,0 +1,101 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrInvalidHandlerMethod = errors.New("invalid handler method")
+)
+
+// HandlerMapping maps method names to the [Event] they handle, for use with [RegisterHandlers].
+type HandlerMapping map[string]Event
+
+type methodHandler struct {
+	obj     any
+	methods map[Event][]HandlerFunc
+}
+
+func (h *methodHandler) HandleEvent(evt Event, params ...Param) error {
+	var errs []error
+	for _, method := range h.methods[evt] {
+		if err := method(evt, params...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *methodHandler) Stop() {
+	if stopper, ok := h.obj.(interface{ Stop() }); ok {
+		stopper.Stop()
+	}
+}
+
+// RegisterHandlers registers methods of obj as a single [Handler] with the given ID, according to the mapping.
+// This reduces boilerplate when a component handles many events.
+//
+// Each mapped method must have one of these signatures:
+//
+//	func(params ...Param) error
+//	func(evt Event, params ...Param) error
+//
+// For example:
+//
+//	err := RegisterHandlers(bus, "orders", orderService, HandlerMapping{
+//		"HandleOrderCreated":   EventOrderCreated,
+//		"HandleOrderCancelled": EventOrderCancelled,
+//	})
+//
+// If multiple methods are mapped to the same event, then they're called in an unspecified order and their errors are joined.
+// If obj has a Stop method with no parameters or results, then it's called when the [Handler] is stopped.
+// All methods are validated before anything is registered, so an error leaves the [EventBus] unchanged.
+func RegisterHandlers(bus *EventBus, id HandlerID, obj any, mapping HandlerMapping) error {
+	if bus == nil {
+		panic("nil event bus")
+	}
+	if obj == nil {
+		return fmt.Errorf("%w: nil handler object", ErrInvalidHandlerMethod)
+	}
+	if len(mapping) == 0 {
+		return fmt.Errorf("%w: no methods mapped", ErrInvalidHandlerMethod)
+	}
+	val := reflect.ValueOf(obj)
+	handler := &methodHandler{
+		obj:     obj,
+		methods: map[Event][]HandlerFunc{},
+	}
+	for name, evt := range mapping {
+		if evt == EventNone {
+			return fmt.Errorf("%w: method '%s' is mapped to %w", ErrInvalidHandlerMethod, name, ErrInvalidEvent)
+		}
+		method := val.MethodByName(name)
+		if !method.IsValid() {
+			return fmt.Errorf("%w: %T has no exported method '%s'", ErrInvalidHandlerMethod, obj, name)
+		}
+		switch fn := method.Interface().(type) {
+		case func(...Param) error:
+			handler.methods[evt] = append(handler.methods[evt], func(_ Event, params ...Param) error {
+				return fn(params...)
+			})
+		case func(Event, ...Param) error:
+			handler.methods[evt] = append(handler.methods[evt], fn)
+		default:
+			return fmt.Errorf("%w: method '%s' has unsupported signature %s", ErrInvalidHandlerMethod, name, method.Type())
+		}
+	}
+	first := true
+	for evt := range handler.methods {
+		if first {
+			bus.Register(id, evt, handler)
+			first = false
+			continue
+		}
+		if err := bus.AddHandledEvent(id, evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
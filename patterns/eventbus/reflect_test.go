@@ -0,0 +1,83 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	testCreatedEvent Event = 2
+	testDeletedEvent Event = 3
+)
+
+type testOrderService struct {
+	created atomic.Int32
+	deleted atomic.Int32
+	stopped atomic.Bool
+}
+
+func (s *testOrderService) HandleCreated(params ...Param) error {
+	s.created.Add(1)
+	return nil
+}
+
+func (s *testOrderService) HandleDeleted(evt Event, params ...Param) error {
+	s.deleted.Add(1)
+	if len(params) > 0 {
+		return errors.New("unexpected params")
+	}
+	return nil
+}
+
+func (s *testOrderService) Other(string) error {
+	return nil
+}
+
+func (s *testOrderService) Stop() {
+	s.stopped.Store(true)
+}
+
+func TestRegisterHandlers(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	svc := new(testOrderService)
+	require.NoError(t, RegisterHandlers(bus, "orders", svc, HandlerMapping{
+		"HandleCreated": testCreatedEvent,
+		"HandleDeleted": testDeletedEvent,
+	}))
+
+	assert.NoError(t, bus.DispatchResult(testCreatedEvent).Await(testAwaitTimeout))
+	assert.NoError(t, bus.DispatchResult(testDeletedEvent).Await(testAwaitTimeout))
+	assert.Error(t, bus.DispatchResult(testDeletedEvent, "param").Await(testAwaitTimeout))
+	assert.Equal(t, int32(1), svc.created.Load())
+	assert.Equal(t, int32(2), svc.deleted.Load())
+
+	bus.UnRegister("orders")
+	assert.True(t, svc.stopped.Load(), "Stop should be called on the object")
+	assert.ErrorIs(t, bus.DispatchResult(testCreatedEvent).Await(testAwaitTimeout), ErrNoHandler)
+}
+
+func TestRegisterHandlers_Invalid(t *testing.T) {
+	bus := NewEventBus()
+	svc := new(testOrderService)
+	tests := map[string]HandlerMapping{
+		"Empty mapping":     {},
+		"Missing method":    {"HandleUpdated": testCreatedEvent},
+		"Invalid signature": {"Other": testCreatedEvent},
+		"Reserved event":    {"HandleCreated": EventNone},
+		"Partially invalid": {"HandleCreated": testCreatedEvent, "Other": testDeletedEvent},
+		"Unexported method": {"handleCreated": testCreatedEvent},
+	}
+	for name, mapping := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, RegisterHandlers(bus, "orders", svc, mapping), ErrInvalidHandlerMethod)
+			assert.Empty(t, bus.handlers, "Nothing should be registered on error")
+		})
+	}
+	assert.ErrorIs(t, RegisterHandlers(bus, "orders", nil, HandlerMapping{"HandleCreated": testCreatedEvent}), ErrInvalidHandlerMethod)
+}
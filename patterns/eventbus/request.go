@@ -0,0 +1,124 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	ErrNoReplier = errors.New("params do not include a replier")
+)
+
+// eventReply is an internal event used to deliver replies to [EventBus.Request].
+// It's negative so it can't collide with application events.
+const eventReply Event = -1
+
+const replyHandlerID HandlerID = "eventbus-reply"
+
+type busReplies struct {
+	register sync.Once
+	seq      atomic.Uint64
+	pending  sync.Map // pending maps a correlation ID to a chan replyResult.
+}
+
+type replyResult struct {
+	val Param
+	err error
+}
+
+// Replier is appended as the last [Param] of an event dispatched with [EventBus.Request], and is used by a handler to reply to the requester.
+// Use [GetReplier] to get it from a handler's params.
+type Replier struct {
+	bus  *EventBus
+	id   uint64
+	once sync.Once
+}
+
+// Reply sends the value to the requester.
+// Only the first reply to a request is delivered, so multiple handlers may safely attempt to reply.
+func (r *Replier) Reply(val Param) {
+	r.reply(val, nil)
+}
+
+// ReplyError sends an error to the requester, which will be returned from [EventBus.Request].
+func (r *Replier) ReplyError(err error) {
+	if err == nil {
+		panic("nil reply error")
+	}
+	r.reply(nil, err)
+}
+
+func (r *Replier) reply(val Param, err error) {
+	r.once.Do(func() {
+		r.bus.Dispatch(eventReply, r.id, replyResult{val: val, err: err})
+	})
+}
+
+// GetReplier returns the [Replier] from the params of an event dispatched with [EventBus.Request].
+// An error wrapping [ErrNoReplier] is returned if the event was dispatched another way.
+func GetReplier(params []Param) (*Replier, error) {
+	if len(params) > 0 {
+		if replier, ok := params[len(params)-1].(*Replier); ok {
+			return replier, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: expected the last param to be a *Replier", ErrNoReplier)
+}
+
+// Request dispatches the event with a [Replier] appended to the params, and waits for a handler to reply with [Replier.Reply].
+// Handlers get the [Replier] with [GetReplier], and may reply before or after returning.
+// Replies are correlated with the request by an ID, and delivered with an internal event.
+//
+// If a handler returns an error, or there's no handler for the event, then the error is returned immediately.
+// Otherwise, Request waits until a reply is received or the context is done, so a context with a deadline should be used in case no handler replies.
+//
+// NOTE: This should not be called from within a [Handler], for the same reasons as [EventBus.DispatchResult].
+func (b *EventBus) Request(ctx context.Context, evt Event, params ...Param) (Param, error) {
+	b.replies.register.Do(func() {
+		b.RegisterFunc(replyHandlerID, eventReply, b.handleReply)
+	})
+	id := b.replies.seq.Add(1)
+	ch := make(chan replyResult, 2)
+	b.replies.pending.Store(id, ch)
+	defer b.replies.pending.Delete(id)
+
+	replier := &Replier{bus: b, id: id}
+	future := b.DispatchResult(evt, append(params[:len(params):len(params)], replier)...)
+	go func() {
+		if err := future.Await(); err != nil {
+			select {
+			case ch <- replyResult{err: err}:
+			default:
+			}
+		}
+	}()
+	select {
+	case result := <-ch:
+		return result.val, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *EventBus) handleReply(_ Event, params ...Param) error {
+	var (
+		id     uint64
+		result replyResult
+	)
+	if errs := ParamSpec(2, AssertAndStore(&id), AssertAndStore(&result))(params); len(errs) > 0 {
+		return fmt.Errorf("invalid reply: %w", errors.Join(errs...))
+	}
+	ch, ok := b.replies.pending.Load(id)
+	if !ok {
+		// The requester stopped waiting.
+		return nil
+	}
+	select {
+	case ch.(chan replyResult) <- result:
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEventBus_Request(t *testing.T) {
+	bus := NewEventBus(OptNumWorkers(2)).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	bus.RegisterFunc("doubler", testEvent, func(evt Event, params ...Param) error {
+		replier, err := GetReplier(params)
+		if err != nil {
+			return err
+		}
+		var n int
+		if errs := ParamSpec(1, AssertAndStore(&n))(params); len(errs) > 0 {
+			replier.ReplyError(errs[0])
+			return nil
+		}
+		replier.Reply(n * 2)
+		replier.Reply(0)
+		return nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	val, err := bus.Request(ctx, testEvent, 21)
+	require.NoError(t, err)
+	assert.Equal(t, 42, val, "Only the first reply should be delivered")
+
+	_, err = bus.Request(ctx, testEvent, "not a number")
+	assert.ErrorIs(t, err, ErrUnexpectedTypeParam, "Reply errors should be returned")
+
+	_, err = bus.Request(ctx, testEvent+1)
+	assert.ErrorIs(t, err, ErrNoHandler)
+
+	assert.ErrorIs(t, bus.DispatchResult(testEvent, 1).Await(time.Second), ErrNoReplier)
+}
+
+func TestEventBus_Request_HandlerErrorOrNoReply(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	errFailed := errors.New("failed")
+	bus.RegisterFunc("failing", testEvent, func(evt Event, params ...Param) error {
+		return errFailed
+	})
+	bus.RegisterFunc("silent", testEvent+1, func(evt Event, params ...Param) error {
+		return nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := bus.Request(ctx, testEvent)
+	assert.ErrorIs(t, err, errFailed)
+	_, err = bus.Request(ctx, testEvent+1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
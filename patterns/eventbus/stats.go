@@ -0,0 +1,138 @@
+package eventbus
+
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+)
+
+// MetricKind identifies what a [Metric] measures.
+type MetricKind int
+
+const (
+	MetricDispatched MetricKind = iota // MetricDispatched is reported when an event is dispatched.
+	MetricHandled                      // MetricHandled is reported when a Handler returns, and includes its Duration and Err.
+)
+
+func (k MetricKind) String() string {
+	switch k {
+	case MetricDispatched:
+		return "dispatched"
+	case MetricHandled:
+		return "handled"
+	default:
+		return "unknown"
+	}
+}
+
+// Metric is reported to the hook set with [OptMetricsHook].
+type Metric struct {
+	Kind      MetricKind
+	Event     Event
+	HandlerID HandlerID     // HandlerID is only set for MetricHandled.
+	Duration  time.Duration // Duration is only set for MetricHandled.
+	Err       error         // Err is only set for MetricHandled, when the Handler returned an error.
+}
+
+// OptMetricsHook configures the [EventBus] to call the hook for every dispatch and handler call, so metrics can be exported to a system like Prometheus or expvar.
+// The hook is called synchronously from dispatching and worker goroutines, so it must be safe for concurrent use and should return quickly.
+func OptMetricsHook(hook func(Metric)) ConfigOption {
+	return func(conf *busConf) error {
+		if hook == nil {
+			return errors.New("nil metrics hook")
+		}
+		conf.metricsHook = hook
+		return nil
+	}
+}
+
+// Stats is a snapshot of [EventBus] activity.
+type Stats struct {
+	Dispatched    map[Event]uint64     // Dispatched is the number of dispatches per event.
+	Handled       map[HandlerID]uint64 // Handled is the number of calls per handler.
+	HandlerErrors map[HandlerID]uint64 // HandlerErrors is the number of errors returned per handler.
+	QueueDepth    int                  // QueueDepth is the number of dispatches waiting for a worker.
+	Workers       int                  // Workers is the number of worker goroutines.
+	BusyWorkers   int                  // BusyWorkers is the number of workers currently handling a dispatch.
+	StuckHandlers int                  // StuckHandlers is the same as [EventBus.StuckHandlers].
+}
+
+// Utilization returns the fraction of workers that are currently busy, from 0 to 1.
+func (s Stats) Utilization() float64 {
+	if s.Workers == 0 {
+		return 0
+	}
+	return float64(s.BusyWorkers) / float64(s.Workers)
+}
+
+type busStats struct {
+	mux        sync.Mutex
+	dispatched map[Event]uint64
+	handled    map[HandlerID]uint64
+	errors     map[HandlerID]uint64
+}
+
+func (b *EventBus) observeDispatched(evt Event) {
+	b.stats.mux.Lock()
+	if b.stats.dispatched == nil {
+		b.stats.dispatched = map[Event]uint64{}
+	}
+	b.stats.dispatched[evt]++
+	b.stats.mux.Unlock()
+	if b.conf.logger != nil {
+		b.conf.logger.Debug("Event dispatched", slog.Int("event", int(evt)))
+	}
+	if b.conf.metricsHook != nil {
+		b.conf.metricsHook(Metric{Kind: MetricDispatched, Event: evt})
+	}
+}
+
+func (b *EventBus) observeHandled(id HandlerID, evt Event, duration time.Duration, err error) {
+	b.stats.mux.Lock()
+	if b.stats.handled == nil {
+		b.stats.handled = map[HandlerID]uint64{}
+		b.stats.errors = map[HandlerID]uint64{}
+	}
+	b.stats.handled[id]++
+	if err != nil {
+		b.stats.errors[id]++
+	}
+	b.stats.mux.Unlock()
+	if b.conf.logger != nil {
+		if err != nil {
+			b.conf.logger.Warn("Handler failed", slog.Int("event", int(evt)), slog.String("handler", string(id)), slog.Duration("duration", duration), slog.Any("error", err))
+		} else {
+			b.conf.logger.Debug("Event handled", slog.Int("event", int(evt)), slog.String("handler", string(id)), slog.Duration("duration", duration))
+		}
+	}
+	if b.conf.metricsHook != nil {
+		b.conf.metricsHook(Metric{Kind: MetricHandled, Event: evt, HandlerID: id, Duration: duration, Err: err})
+	}
+}
+
+// Stats returns a snapshot of the [EventBus] activity since it was created.
+func (b *EventBus) Stats() Stats {
+	b.stats.mux.Lock()
+	stats := Stats{
+		Dispatched:    maps.Clone(b.stats.dispatched),
+		Handled:       maps.Clone(b.stats.handled),
+		HandlerErrors: maps.Clone(b.stats.errors),
+	}
+	b.stats.mux.Unlock()
+	if stats.Dispatched == nil {
+		stats.Dispatched = map[Event]uint64{}
+	}
+	if stats.Handled == nil {
+		stats.Handled = map[HandlerID]uint64{}
+		stats.HandlerErrors = map[HandlerID]uint64{}
+	}
+	if b.events != nil {
+		stats.QueueDepth = b.queueDepth()
+		stats.Workers = b.conf.numWorkers
+	}
+	stats.BusyWorkers = int(b.busy.Load())
+	stats.StuckHandlers = b.StuckHandlers()
+	return stats
+}
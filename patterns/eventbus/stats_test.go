@@ -0,0 +1,101 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBus_Stats(t *testing.T) {
+	var (
+		mux     sync.Mutex
+		metrics []Metric
+	)
+	bus := NewEventBus(OptNumWorkers(2), OptMetricsHook(func(m Metric) {
+		mux.Lock()
+		defer mux.Unlock()
+		metrics = append(metrics, m)
+	}))
+	assert.Zero(t, bus.Stats().Workers, "Workers should not be reported before starting")
+	bus.Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	bus.RegisterFunc("ok", testEvent, func(evt Event, params ...Param) error {
+		return nil
+	})
+	bus.RegisterFunc("fails", testEvent, func(evt Event, params ...Param) error {
+		return assert.AnError
+	})
+	for range 3 {
+		bus.DispatchAll(testEvent).Await(time.Second)
+	}
+
+	stats := bus.Stats()
+	assert.Equal(t, uint64(3), stats.Dispatched[testEvent])
+	assert.Equal(t, uint64(3), stats.Handled["ok"])
+	assert.Equal(t, uint64(3), stats.Handled["fails"])
+	assert.Zero(t, stats.HandlerErrors["ok"])
+	assert.Equal(t, uint64(3), stats.HandlerErrors["fails"])
+	assert.Equal(t, 2, stats.Workers)
+	assert.GreaterOrEqual(t, stats.Utilization(), float64(0))
+
+	mux.Lock()
+	defer mux.Unlock()
+	var dispatched, handled, failed int
+	for _, m := range metrics {
+		switch m.Kind {
+		case MetricDispatched:
+			if m.Event == testEvent {
+				dispatched++
+			}
+		case MetricHandled:
+			handled++
+			if m.Err != nil {
+				assert.Equal(t, HandlerID("fails"), m.HandlerID)
+				failed++
+			}
+		}
+	}
+	assert.Equal(t, 3, dispatched)
+	assert.Equal(t, 6, handled)
+	assert.Equal(t, 3, failed)
+}
+
+func TestOptLogger(t *testing.T) {
+	var buf syncBuffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	bus := NewEventBus(OptLogger(logger)).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterFunc("fails", testEvent, func(evt Event, params ...Param) error {
+		return assert.AnError
+	})
+	assert.Error(t, bus.DispatchResult(testEvent).Await(time.Second))
+
+	out := buf.String()
+	assert.Contains(t, out, `msg="Event dispatched" event=5`)
+	assert.Contains(t, out, `level=WARN msg="Handler failed" event=5 handler=fails`)
+	assert.Panics(t, func() {
+		NewEventBus(OptLogger(nil))
+	})
+}
+
+type syncBuffer struct {
+	mux sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.buf.String()
+}
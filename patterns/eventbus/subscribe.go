@@ -0,0 +1,114 @@
+package eventbus
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var subscriptionCounter atomic.Uint64
+
+// Dispatch is an event and its parameters, as received from [EventBus.Subscribe].
+type Dispatch struct {
+	Event  Event
+	Params []Param
+}
+
+type chanSubscription[T any] struct {
+	mux       sync.RWMutex
+	convert   func(evt Event, params []Param) (T, error)
+	ch        chan T
+	done      chan struct{}
+	doneOnce  sync.Once
+	closeOnce sync.Once
+}
+
+func (s *chanSubscription[T]) HandleEvent(evt Event, params ...Param) error {
+	val, err := s.convert(evt, params)
+	if err != nil {
+		return err
+	}
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	select {
+	case s.ch <- val:
+	case <-s.done:
+	}
+	return nil
+}
+
+// cancel unblocks any pending send, so the handler can be unregistered without waiting for the consumer.
+func (s *chanSubscription[T]) cancel() {
+	s.doneOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+func (s *chanSubscription[T]) Stop() {
+	s.cancel()
+	s.closeOnce.Do(func() {
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		close(s.ch)
+	})
+}
+
+// SubscribeChan registers an internal [Handler] for the event that sends the first [Param] of each dispatch to the returned channel.
+// This allows select-based consumers to participate in the [EventBus] without implementing [Handler].
+//
+// If the first [Param] is missing or is not a T, then the handler returns an error wrapping [ErrNotEnoughParams] or [ErrUnexpectedTypeParam].
+// The buffer sets the channel's capacity. When the channel is full, the handler will block a worker goroutine until the value is received, so consumers should keep up or use a larger buffer.
+//
+// The returned cancel function unregisters the handler and closes the channel.
+// The channel is also closed when the [EventBus] stops.
+func SubscribeChan[T any](bus *EventBus, evt Event, buffer int) (<-chan T, func()) {
+	if bus == nil {
+		panic("nil event bus")
+	}
+	return subscribe(bus, evt, buffer, func(evt Event, params []Param) (T, error) {
+		var mt T
+		if len(params) == 0 {
+			return mt, fmt.Errorf("%w: subscription for event %d expected 1 parameter", ErrNotEnoughParams, evt)
+		}
+		val, ok := AssertParam[T](params[0])
+		if !ok {
+			return mt, fmt.Errorf("%w: subscription for event %d expected %T, got %T", ErrUnexpectedTypeParam, evt, mt, params[0])
+		}
+		return val, nil
+	})
+}
+
+// Subscribe registers an internal [Handler] for the event that sends each [Dispatch] to the returned channel.
+// This is like [SubscribeChan], but receives all params of the event without asserting their type.
+//
+// The buffer sets the channel's capacity, with the same blocking behavior as [SubscribeChan].
+// The returned cancel function unregisters the handler and closes the channel.
+// The channel is also closed when the [EventBus] stops.
+func (b *EventBus) Subscribe(evt Event, buffer int) (<-chan Dispatch, func()) {
+	return subscribe(b, evt, buffer, func(evt Event, params []Param) (Dispatch, error) {
+		return Dispatch{Event: evt, Params: params}, nil
+	})
+}
+
+func subscribe[T any](bus *EventBus, evt Event, buffer int, convert func(evt Event, params []Param) (T, error)) (<-chan T, func()) {
+	if buffer < 0 {
+		buffer = 0
+	}
+	sub := &chanSubscription[T]{
+		convert: convert,
+		ch:      make(chan T, buffer),
+		done:    make(chan struct{}),
+	}
+	id := HandlerID(fmt.Sprintf("subscription-%d", subscriptionCounter.Add(1)))
+	bus.Register(id, evt, sub)
+	return sub.ch, func() {
+		sub.cancel()
+		bus.UnRegister(id)
+		sub.Stop()
+	}
+}
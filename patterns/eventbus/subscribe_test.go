@@ -0,0 +1,84 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSubscribeChan(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	ch, cancel := SubscribeChan[string](bus, testEvent, 2)
+	bus.Dispatch(testEvent, "a")
+	bus.Dispatch(testEvent, "b")
+
+	for _, expected := range []string{"a", "b"} {
+		select {
+		case val := <-ch:
+			assert.Equal(t, expected, val)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for value")
+		}
+	}
+
+	err := bus.DispatchResult(testEvent, 5).Await(time.Second)
+	assert.ErrorIs(t, err, ErrUnexpectedTypeParam)
+	err = bus.DispatchResult(testEvent).Await(time.Second)
+	assert.ErrorIs(t, err, ErrNotEnoughParams)
+
+	cancel()
+	cancel()
+	_, more := <-ch
+	assert.False(t, more, "Channel should be closed after cancel")
+	assert.ErrorIs(t, bus.DispatchResult(testEvent, "c").Await(time.Second), ErrNoHandler)
+}
+
+func TestSubscribeChan_CancelWhileBlocked(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	ch, cancel := SubscribeChan[int](bus, testEvent, 0)
+	result := bus.DispatchResult(testEvent, 1)
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cancel()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cancel blocked on an unreceived value")
+	}
+	require.NoError(t, result.Await(time.Second))
+	_, more := <-ch
+	assert.False(t, more)
+}
+
+func TestEventBus_Subscribe(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	ch, cancel := bus.Subscribe(testEvent, 1)
+	bus.Dispatch(testEvent, "a", 5)
+	select {
+	case dispatch := <-ch:
+		assert.Equal(t, testEvent, dispatch.Event)
+		assert.Equal(t, []Param{"a", 5}, dispatch.Params)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for dispatch")
+	}
+
+	require.NoError(t, bus.DispatchResult(testEvent).Await(time.Second), "Dispatches without params should be accepted")
+	dispatch := <-ch
+	assert.Empty(t, dispatch.Params)
+
+	cancel()
+	_, more := <-ch
+	assert.False(t, more, "Channel should be closed after cancel")
+}
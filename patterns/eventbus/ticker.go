@@ -0,0 +1,56 @@
+package eventbus
+
+import (
+	"github.com/saylorsolutions/x/syncx"
+	"sync"
+	"time"
+)
+
+// DispatchEvery dispatches the event with the given params on the interval, until the returned stop function is called or the [EventBus] stops.
+// When the [EventBus] stops, the ticker goroutine exits on its next tick.
+// This replaces the boilerplate of a ticker goroutine that calls [EventBus.Dispatch].
+// The first dispatch happens after the first interval elapses, like a [time.Ticker].
+//
+// The [EventBus] must be started before calling this method, and this will panic if the interval is <= 0.
+// The stop function is safe to call multiple times.
+func (b *EventBus) DispatchEvery(interval time.Duration, evt Event, params ...Param) (stop func()) {
+	if interval <= 0 {
+		panic("non-positive interval for DispatchEvery")
+	}
+	if evt == EventNone {
+		b.DispatchError(ErrInvalidEvent)
+		return func() {}
+	}
+	var (
+		done     = make(chan struct{})
+		stopOnce sync.Once
+		events   = b.events
+	)
+	stop = func() {
+		stopOnce.Do(func() {
+			close(done)
+		})
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				dispatch := &busDispatch{
+					event:  evt,
+					params: params,
+					future: syncx.SymbolicFuture[error](),
+				}
+				b.record(dispatch, 0)
+				if !events.Push(dispatch) {
+					stop()
+					return
+				}
+			}
+		}
+	}()
+	return stop
+}
@@ -0,0 +1,47 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventBus_DispatchEvery(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+
+	var count atomic.Int32
+	bus.RegisterFunc("ticks", testEvent, func(evt Event, params ...Param) error {
+		assert.Equal(t, []Param{"tick"}, params)
+		count.Add(1)
+		return nil
+	})
+	stop := bus.DispatchEvery(10*time.Millisecond, testEvent, "tick")
+	assert.Eventually(t, func() bool {
+		return count.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+	stop()
+	stop()
+	time.Sleep(20 * time.Millisecond)
+	stopped := count.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped, count.Load(), "No events should be dispatched after stop")
+
+	assert.Panics(t, func() {
+		bus.DispatchEvery(0, testEvent)
+	})
+}
+
+func TestEventBus_DispatchEvery_BusStopped(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	bus.RegisterFunc("ticks", testEvent, func(evt Event, params ...Param) error {
+		return nil
+	})
+	stop := bus.DispatchEvery(5*time.Millisecond, testEvent)
+	defer stop()
+	bus.AwaitStop(testShutdownTimeout)
+	// The ticker goroutine should observe the stopped bus without panicking.
+	time.Sleep(20 * time.Millisecond)
+}
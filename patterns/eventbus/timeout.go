@@ -0,0 +1,92 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"github.com/saylorsolutions/x/syncx"
+	"sync"
+	"time"
+)
+
+// SetHandlerTimeout sets the timeout for a specific registered [Handler], overriding [OptHandlerTimeout].
+// A timeout <= 0 means that the bus waits for the handler to return, no matter how long it takes.
+func (b *EventBus) SetHandlerTimeout(id HandlerID, timeout time.Duration) error {
+	return syncx.LockFuncT(&b.mux, func() error {
+		if _, ok := b.handlers[id]; !ok {
+			return fmt.Errorf("no registered handler with id '%s'", id)
+		}
+		b.timeouts[id] = timeout
+		return nil
+	})
+}
+
+// StuckHandlers returns the number of handler calls that timed out and still haven't returned.
+// A number that keeps growing indicates a handler that is blocking forever.
+func (b *EventBus) StuckHandlers() int {
+	return int(b.stuck.Load())
+}
+
+// callHandler calls the handler wrapped with middleware, applying its timeout if one is configured, and records metrics for the call.
+// This must be called with at least a read lock held.
+func (b *EventBus) callHandler(id HandlerID, handler Handler, dispatch *busDispatch) error {
+	ctx := dispatch.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var end func(err error)
+	if b.conf.traceHook != nil {
+		ctx, end = b.conf.traceHook(ctx, dispatch.event, id)
+	}
+	start := time.Now()
+	err := b.callWithTimeout(ctx, id, handler, dispatch)
+	b.observeHandled(id, dispatch.event, time.Since(start), err)
+	if end != nil {
+		end(err)
+	}
+	return err
+}
+
+func (b *EventBus) callWithTimeout(ctx context.Context, id HandlerID, handler Handler, dispatch *busDispatch) error {
+	handler = b.applyMiddleware(withContext(ctx, handler))
+	timeout, ok := b.timeouts[id]
+	if !ok {
+		timeout = b.conf.handlerTimeout
+	}
+	if timeout <= 0 {
+		return handler.HandleEvent(dispatch.event, dispatch.params...)
+	}
+	var (
+		result        = make(chan error, 1)
+		mux           sync.Mutex
+		done, expired bool
+		// The dispatch may be released while a timed out handler is still running, so it must not be referenced in the goroutine.
+		evt, params = dispatch.event, dispatch.params
+	)
+	go func() {
+		err := handler.HandleEvent(evt, params...)
+		syncx.LockFunc(&mux, func() {
+			if expired {
+				b.stuck.Add(-1)
+			}
+			done = true
+		})
+		result <- err
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+		mux.Lock()
+		if done {
+			// The handler returned right as the timer fired.
+			mux.Unlock()
+			return <-result
+		}
+		expired = true
+		b.stuck.Add(1)
+		mux.Unlock()
+		return fmt.Errorf("%w: handler '%s' did not finish handling event %d within %s", ErrHandlerTimeout, id, dispatch.event, timeout)
+	}
+}
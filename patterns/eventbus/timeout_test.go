@@ -0,0 +1,64 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOptHandlerTimeout(t *testing.T) {
+	assert.Error(t, OptHandlerTimeout(0)(&busConf{}))
+
+	var (
+		asyncErr atomic.Value
+		release  = make(chan struct{})
+	)
+	bus := NewEventBus(OptHandlerTimeout(20 * time.Millisecond)).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterFunc("stuck", testEvent, func(evt Event, params ...Param) error {
+		<-release
+		return nil
+	})
+	bus.RegisterErrorHandler("errors", func(err error) {
+		asyncErr.Store(err)
+	})
+
+	err := bus.DispatchResult(testEvent).Await(time.Second)
+	assert.ErrorIs(t, err, ErrHandlerTimeout)
+	assert.ErrorContains(t, err, "handler 'stuck'")
+	assert.ErrorContains(t, err, "event 5")
+	assert.Equal(t, 1, bus.StuckHandlers())
+
+	assert.Eventually(t, func() bool {
+		return asyncErr.Load() != nil
+	}, time.Second, 5*time.Millisecond, "Timeout should be reported as an async error")
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return bus.StuckHandlers() == 0
+	}, time.Second, 5*time.Millisecond, "Stuck count should drop when the handler returns")
+}
+
+func TestEventBus_SetHandlerTimeout(t *testing.T) {
+	bus := NewEventBus(OptHandlerTimeout(10 * time.Millisecond)).Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	bus.RegisterFunc("slow", testEvent, func(evt Event, params ...Param) error {
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	})
+	assert.Error(t, bus.SetHandlerTimeout("missing", time.Second))
+	require.NoError(t, bus.SetHandlerTimeout("slow", 0))
+	assert.NoError(t, bus.DispatchResult(testEvent).Await(time.Second), "Per-handler override should disable the timeout")
+
+	results := bus.DispatchAll(testEvent).Await(time.Second)
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+
+	require.NoError(t, bus.SetHandlerTimeout("slow", 5*time.Millisecond))
+	results = bus.DispatchAll(testEvent).Await(time.Second)
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, ErrHandlerTimeout)
+}
@@ -0,0 +1,168 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/syncx"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidTopic = errors.New("invalid topic")
+)
+
+// TopicHandlerFunc handles an event published to a topic with [TopicBus.Publish].
+type TopicHandlerFunc func(topic string, params ...Param) error
+
+// TopicBus layers hierarchical string topics over an [EventBus], for domains where a shared enum of [Event] would be unmanageable.
+//
+// Topics are dot separated segments, like "user.created".
+// Subscription patterns may use "*" to match exactly one segment, like "user.*", or "**" as the last segment to match one or more trailing segments, like "user.**".
+//
+// Every topic is published as the single [Event] given to [NewTopicBus], with the topic as the first [Param], and each subscription is a filtered handler that matches its pattern against the topic when the event is handled.
+// This means that all [EventBus] features, like ordering, middleware, and metrics, apply to topics as well, and publishing a new topic doesn't allocate anything that needs to be cleaned up.
+type TopicBus struct {
+	bus   *EventBus
+	event Event
+	mux   sync.RWMutex
+	subs  map[HandlerID]string
+}
+
+// NewTopicBus creates a [TopicBus] over the [EventBus].
+// Topics are published as the event, so it must not be used for anything else on the same [EventBus].
+func NewTopicBus(bus *EventBus, event Event) *TopicBus {
+	if bus == nil {
+		panic("nil event bus")
+	}
+	if event <= EventAsyncError {
+		panic(fmt.Sprintf("topic event %d overlaps reserved events", event))
+	}
+	return &TopicBus{
+		bus:   bus,
+		event: event,
+		subs:  map[HandlerID]string{},
+	}
+}
+
+// Event returns the [Event] that topics are published as.
+// This allows using [EventBus] methods like [EventBus.DispatchAll] with a topic, by passing the topic as the first [Param].
+func (t *TopicBus) Event() Event {
+	return t.event
+}
+
+// Subscribe registers the handler for all topics matching the pattern.
+// An error wrapping [ErrInvalidTopic] is returned if the pattern is invalid.
+// The handler may be removed with [TopicBus.Unsubscribe], or [EventBus.UnRegister].
+func (t *TopicBus) Subscribe(id HandlerID, pattern string, handler TopicHandlerFunc) error {
+	if handler == nil {
+		panic("nil topic handler")
+	}
+	if err := validateTopic(pattern, true); err != nil {
+		return err
+	}
+	syncx.LockFunc(&t.mux, func() {
+		t.subs[id] = pattern
+	})
+	matches := func(params ...Param) bool {
+		if len(params) == 0 {
+			return false
+		}
+		topic, ok := params[0].(string)
+		return ok && MatchTopic(pattern, topic)
+	}
+	t.bus.RegisterFilteredFunc(id, t.event, matches, func(_ Event, params ...Param) error {
+		return handler(params[0].(string), params[1:]...)
+	})
+	return nil
+}
+
+// Unsubscribe removes the handler registered with [TopicBus.Subscribe].
+func (t *TopicBus) Unsubscribe(id HandlerID) {
+	syncx.LockFunc(&t.mux, func() {
+		delete(t.subs, id)
+	})
+	t.bus.UnRegister(id)
+}
+
+// Publish dispatches the params to all handlers subscribed to a pattern matching the topic, like [EventBus.Dispatch].
+// Wildcards are not allowed in a published topic.
+// If no subscription matches the topic, then an error wrapping [ErrNoHandler] is dispatched as an [EventAsyncError].
+//
+// This can safely be called from within a [Handler].
+func (t *TopicBus) Publish(topic string, params ...Param) error {
+	if err := validateTopic(topic, false); err != nil {
+		return err
+	}
+	if !t.subscribed(topic) {
+		t.bus.DispatchError(fmt.Errorf("%w for topic '%s'", ErrNoHandler, topic))
+		return nil
+	}
+	t.bus.Dispatch(t.event, append([]Param{topic}, params...)...)
+	return nil
+}
+
+// PublishResult is like [TopicBus.Publish], but returns a [syncx.Future] like [EventBus.DispatchResult].
+// The future resolves to an error wrapping [ErrNoHandler] if no subscription matches the topic.
+func (t *TopicBus) PublishResult(topic string, params ...Param) syncx.Future[error] {
+	if err := validateTopic(topic, false); err != nil {
+		return syncx.StaticFuture(err)
+	}
+	if !t.subscribed(topic) {
+		return syncx.StaticFuture(fmt.Errorf("%w for topic '%s'", ErrNoHandler, topic))
+	}
+	return t.bus.DispatchResult(t.event, append([]Param{topic}, params...)...)
+}
+
+// subscribed reports whether any subscription matches the topic.
+func (t *TopicBus) subscribed(topic string) bool {
+	return syncx.RLockFuncT(&t.mux, func() bool {
+		for _, pattern := range t.subs {
+			if MatchTopic(pattern, topic) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// MatchTopic reports whether the topic matches the subscription pattern, as described in [TopicBus].
+func MatchTopic(pattern, topic string) bool {
+	patSegs := strings.Split(pattern, ".")
+	topicSegs := strings.Split(topic, ".")
+	for i, seg := range patSegs {
+		if seg == "**" {
+			return len(topicSegs) > i
+		}
+		if i >= len(topicSegs) {
+			return false
+		}
+		if seg != "*" && seg != topicSegs[i] {
+			return false
+		}
+	}
+	return len(patSegs) == len(topicSegs)
+}
+
+func validateTopic(topic string, allowWildcards bool) error {
+	if len(topic) == 0 {
+		return fmt.Errorf("%w: empty topic", ErrInvalidTopic)
+	}
+	segs := strings.Split(topic, ".")
+	for i, seg := range segs {
+		switch {
+		case len(seg) == 0:
+			return fmt.Errorf("%w: '%s' has an empty segment", ErrInvalidTopic, topic)
+		case seg == "*" || seg == "**":
+			if !allowWildcards {
+				return fmt.Errorf("%w: '%s' cannot contain wildcards", ErrInvalidTopic, topic)
+			}
+			if seg == "**" && i != len(segs)-1 {
+				return fmt.Errorf("%w: '**' must be the last segment of '%s'", ErrInvalidTopic, topic)
+			}
+		case strings.Contains(seg, "*"):
+			return fmt.Errorf("%w: wildcards must be a whole segment in '%s'", ErrInvalidTopic, topic)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package eventbus
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := map[string]struct {
+		pattern, topic string
+		expected       bool
+	}{
+		"Exact":                 {"user.created", "user.created", true},
+		"Different":             {"user.created", "user.deleted", false},
+		"Single wildcard":       {"user.*", "user.created", true},
+		"Single wildcard depth": {"user.*", "user.created.admin", false},
+		"Middle wildcard":       {"user.*.admin", "user.created.admin", true},
+		"Trailing wildcard":     {"user.**", "user.created.admin", true},
+		"Trailing needs one":    {"user.**", "user", false},
+		"Shorter topic":         {"user.created", "user", false},
+		"Longer topic":          {"user", "user.created", false},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MatchTopic(tc.pattern, tc.topic))
+		})
+	}
+}
+
+func TestTopicBus(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	topics := NewTopicBus(bus, 100)
+
+	var (
+		mux      sync.Mutex
+		received = map[HandlerID][]string{}
+	)
+	record := func(id HandlerID) TopicHandlerFunc {
+		return func(topic string, params ...Param) error {
+			mux.Lock()
+			defer mux.Unlock()
+			received[id] = append(received[id], topic)
+			return nil
+		}
+	}
+	assert.ErrorIs(t, topics.PublishResult("user.created").Await(time.Second), ErrNoHandler, "Publishing before subscribing should not be handled")
+	require.NoError(t, topics.Subscribe("all-users", "user.*", record("all-users")))
+	require.NoError(t, topics.Subscribe("deleted", "user.deleted", record("deleted")))
+	require.NoError(t, topics.Subscribe("nested", "user.**", record("nested")))
+
+	require.NoError(t, topics.PublishResult("user.created").Await(time.Second))
+	require.NoError(t, topics.PublishResult("user.deleted").Await(time.Second))
+	require.NoError(t, topics.PublishResult("user.created.admin").Await(time.Second))
+	assert.ErrorIs(t, topics.PublishResult("order.created").Await(time.Second), ErrNoHandler)
+
+	mux.Lock()
+	assert.ElementsMatch(t, []string{"user.created", "user.deleted"}, received["all-users"])
+	assert.Equal(t, []string{"user.deleted"}, received["deleted"])
+	assert.ElementsMatch(t, []string{"user.created", "user.deleted", "user.created.admin"}, received["nested"])
+	mux.Unlock()
+
+	results := bus.DispatchAll(topics.Event(), "user.deleted").Await(time.Second)
+	assert.Len(t, results, 3, "Only matching subscriptions should receive the event")
+
+	topics.Unsubscribe("all-users")
+	topics.Unsubscribe("nested")
+	assert.ErrorIs(t, topics.PublishResult("user.created").Await(time.Second), ErrNoHandler)
+}
+
+func TestTopicBus_PublishFromHandler(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	topics := NewTopicBus(bus, 100)
+
+	received := make(chan string, 1)
+	require.NoError(t, topics.Subscribe("orders", "order.*", func(topic string, params ...Param) error {
+		received <- topic
+		return nil
+	}))
+	require.NoError(t, topics.Subscribe("users", "user.deleted", func(string, ...Param) error {
+		// The topic has never been published, so this must not need the bus lock held by the worker.
+		return topics.Publish("order.new")
+	}))
+	require.NoError(t, topics.PublishResult("user.deleted").Await(time.Second))
+	select {
+	case topic := <-received:
+		assert.Equal(t, "order.new", topic)
+	case <-time.After(time.Second):
+		t.Fatal("Topic published from a handler was not handled")
+	}
+}
+
+func TestTopicBus_PublishNoHandler(t *testing.T) {
+	bus := NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(testShutdownTimeout)
+	topics := NewTopicBus(bus, 100)
+
+	errs := make(chan error, 1)
+	bus.RegisterErrorHandler("errors", func(err error) {
+		errs <- err
+	})
+	require.NoError(t, topics.Subscribe("users", "user.*", func(string, ...Param) error { return nil }))
+	require.NoError(t, topics.Publish("order.created"))
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, ErrNoHandler)
+		assert.ErrorContains(t, err, "order.created")
+	case <-time.After(time.Second):
+		t.Fatal("Expected an error for an unhandled topic")
+	}
+}
+
+func TestTopicBus_InvalidTopics(t *testing.T) {
+	bus := NewEventBus()
+	topics := NewTopicBus(bus, 100)
+	noop := func(string, ...Param) error { return nil }
+	assert.ErrorIs(t, topics.Publish(""), ErrInvalidTopic)
+	assert.ErrorIs(t, topics.Publish("user..created"), ErrInvalidTopic)
+	assert.ErrorIs(t, topics.Publish("user.*"), ErrInvalidTopic, "Wildcards can't be published")
+	assert.ErrorIs(t, topics.Subscribe("a", "user.**.created", noop), ErrInvalidTopic)
+	assert.ErrorIs(t, topics.Subscribe("a", "user.cre*", noop), ErrInvalidTopic)
+	assert.Panics(t, func() {
+		NewTopicBus(bus, EventAsyncError)
+	})
+}
@@ -0,0 +1,13 @@
+/*
+Package jobs provides a [Runner] for background jobs with priorities, tags, and bounded concurrency.
+
+Jobs are submitted with [Runner.Submit] and queued by priority, using the ranked [queue.Queue].
+Each idle worker takes the highest priority job that is allowed to run, so a job held back by a tag limit doesn't block lower priority jobs behind it.
+
+A tag is an arbitrary string attached to a job, like the name of a downstream service.
+[OptTagLimit] bounds how many jobs with a given tag may run at the same time, regardless of the number of workers.
+
+Failed jobs may be retried with [retry.Settings], either for all jobs with [OptRetry], or for a specific job with [JobRetry].
+A job may be cancelled at any time with [Runner.Cancel], and its status may be inspected with [Runner.Info] and [Runner.Jobs].
+*/
+package jobs
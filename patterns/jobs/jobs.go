@@ -0,0 +1,523 @@
+package jobs
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/contextx"
+	"github.com/saylorsolutions/x/idx"
+	"github.com/saylorsolutions/x/patterns/retry"
+	"github.com/saylorsolutions/x/structures/queue"
+	"github.com/saylorsolutions/x/syncx"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRunnerStopped = errors.New("job runner is stopped")
+	ErrJobNotFound   = errors.New("job not found")
+	ErrJobCancelled  = errors.New("job cancelled")
+)
+
+// JobFunc is the work done by a job.
+// The context is cancelled if the job is cancelled or the [Runner] is stopped.
+type JobFunc func(ctx context.Context) error
+
+// JobID uniquely identifies a submitted job.
+type JobID string
+
+// Status is the lifecycle state of a job.
+type Status int
+
+const (
+	StatusPending   Status = iota // StatusPending means the job is queued and waiting to run.
+	StatusRunning                 // StatusRunning means the job is currently running, possibly after retries.
+	StatusSucceeded               // StatusSucceeded means the job completed without an error.
+	StatusFailed                  // StatusFailed means the job returned an error, and any retries have been exhausted.
+	StatusCancelled               // StatusCancelled means the job was cancelled before it could complete.
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusRunning:
+		return "running"
+	case StatusSucceeded:
+		return "succeeded"
+	case StatusFailed:
+		return "failed"
+	case StatusCancelled:
+		return "cancelled"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Done returns true if the job has reached a final state.
+func (s Status) Done() bool {
+	return s >= StatusSucceeded
+}
+
+// Info is a snapshot of the state of a job.
+type Info struct {
+	ID        JobID
+	Name      string
+	Priority  uint
+	Tags      []string
+	Status    Status
+	Attempts  int
+	Err       error
+	Submitted time.Time
+	Started   time.Time // Started is zero if the job has not started.
+	Finished  time.Time // Finished is zero if the job has not finished.
+}
+
+type job struct {
+	id        JobID
+	name      string
+	priority  uint
+	tags      []string
+	fn        JobFunc
+	settings  *retry.Settings
+	status    Status
+	attempts  int
+	err       error
+	submitted time.Time
+	started   time.Time
+	finished  time.Time
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+func (j *job) info() Info {
+	return Info{
+		ID:        j.id,
+		Name:      j.name,
+		Priority:  j.priority,
+		Tags:      slices.Clone(j.tags),
+		Status:    j.status,
+		Attempts:  j.attempts,
+		Err:       j.err,
+		Submitted: j.submitted,
+		Started:   j.started,
+		Finished:  j.finished,
+	}
+}
+
+type runnerConf struct {
+	numWorkers int
+	tagLimits  map[string]int
+	settings   *retry.Settings
+}
+
+type RunnerOption func(conf *runnerConf) error
+
+// OptWorkers sets the maximum number of jobs that may run at the same time.
+// Defaults to 1.
+func OptWorkers(num int) RunnerOption {
+	return func(conf *runnerConf) error {
+		if num < 1 {
+			return fmt.Errorf("num '%d' is invalid, must be >= 1", num)
+		}
+		conf.numWorkers = num
+		return nil
+	}
+}
+
+// OptTagLimit sets the maximum number of jobs with the given tag that may run at the same time.
+// Tags without a limit are only bounded by the number of workers.
+func OptTagLimit(tag string, limit int) RunnerOption {
+	return func(conf *runnerConf) error {
+		if limit < 1 {
+			return fmt.Errorf("limit '%d' for tag '%s' is invalid, must be >= 1", limit, tag)
+		}
+		conf.tagLimits[tag] = limit
+		return nil
+	}
+}
+
+// OptRetry sets the default [retry.Settings] used for jobs that fail.
+// The Context in the settings is ignored in favor of the job's context.
+// By default, jobs are not retried.
+func OptRetry(settings retry.Settings) RunnerOption {
+	return func(conf *runnerConf) error {
+		settings = settings.Copy()
+		conf.settings = &settings
+		return nil
+	}
+}
+
+type JobOption func(j *job)
+
+// JobName sets a descriptive name for the job, which is reported in its [Info].
+func JobName(name string) JobOption {
+	return func(j *job) {
+		j.name = name
+	}
+}
+
+// JobPriority sets the priority of the job. Higher priority jobs are run first, and jobs with the same priority are run in submission order.
+func JobPriority(priority uint) JobOption {
+	return func(j *job) {
+		j.priority = priority
+	}
+}
+
+// JobTags adds tags to the job, which are used to apply limits set with [OptTagLimit].
+func JobTags(tags ...string) JobOption {
+	return func(j *job) {
+		j.tags = append(j.tags, tags...)
+	}
+}
+
+// JobRetry overrides the [retry.Settings] for this job.
+// The Context in the settings is ignored in favor of the job's context.
+func JobRetry(settings retry.Settings) JobOption {
+	return func(j *job) {
+		settings = settings.Copy()
+		j.settings = &settings
+	}
+}
+
+// Runner runs submitted jobs in priority order, with bounded concurrency overall and per tag.
+// Jobs may be submitted before [Runner.Start] is called, and will be queued until then.
+type Runner struct {
+	conf      runnerConf
+	ctx       context.Context
+	cancel    context.CancelFunc
+	startOnce sync.Once
+	workers   sync.WaitGroup
+	wake      chan struct{}
+
+	mux      sync.Mutex
+	stopped  bool
+	pending  *queue.Queue[*job]
+	jobs     map[JobID]*job
+	runCount map[string]int
+}
+
+// NewRunner creates a new [Runner] with the given options.
+func NewRunner(opts ...RunnerOption) (*Runner, error) {
+	conf := runnerConf{
+		numWorkers: 1,
+		tagLimits:  map[string]int{},
+	}
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			return nil, err
+		}
+	}
+	return &Runner{
+		conf:     conf,
+		wake:     make(chan struct{}, 1),
+		pending:  queue.NewQueue[*job](),
+		jobs:     map[JobID]*job{},
+		runCount: map[string]int{},
+	}, nil
+}
+
+// Start starts the worker goroutines if they're not started already.
+// The [Runner] is stopped when the context is cancelled, and cannot be restarted.
+func (r *Runner) Start(ctx context.Context) *Runner {
+	r.startOnce.Do(func() {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		r.ctx, r.cancel = contextx.WithCancel(ctx)
+		r.workers.Add(r.conf.numWorkers)
+		for i := 0; i < r.conf.numWorkers; i++ {
+			go r.worker()
+		}
+		go func() {
+			<-r.ctx.Done()
+			r.Stop()
+		}()
+		r.notify()
+	})
+	return r
+}
+
+// Stop stops the [Runner] without waiting for running jobs to return.
+// Running jobs have their context cancelled, and pending jobs are cancelled with [ErrRunnerStopped].
+func (r *Runner) Stop() {
+	pending := syncx.LockFuncT(&r.mux, func() []*job {
+		if r.stopped {
+			return nil
+		}
+		r.stopped = true
+		var pending []*job
+		for {
+			j, ok := r.pending.Pop()
+			if !ok {
+				break
+			}
+			pending = append(pending, j)
+		}
+		return pending
+	})
+	// Ensures that Await will return even if the Runner wasn't started.
+	r.startOnce.Do(func() {})
+	if r.cancel != nil {
+		r.cancel()
+	}
+	for _, j := range pending {
+		r.finish(j, StatusCancelled, ErrRunnerStopped)
+	}
+}
+
+// Await waits for all workers to return after the [Runner] is stopped.
+func (r *Runner) Await() {
+	r.workers.Wait()
+}
+
+// AwaitStop calls [Runner.Stop] and waits for all workers to return.
+func (r *Runner) AwaitStop() {
+	r.Stop()
+	r.Await()
+}
+
+// Submit queues a job to be run, returning its [JobID].
+func (r *Runner) Submit(fn JobFunc, opts ...JobOption) (JobID, error) {
+	if fn == nil {
+		panic("nil job func")
+	}
+	j := &job{
+		id:        JobID(idx.NewULID().String()),
+		fn:        fn,
+		settings:  r.conf.settings,
+		status:    StatusPending,
+		submitted: time.Now(),
+		done:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	if err := syncx.LockFuncT(&r.mux, func() error {
+		if r.stopped {
+			return ErrRunnerStopped
+		}
+		r.jobs[j.id] = j
+		r.pending.PushRanked(j, j.priority)
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	r.notify()
+	return j.id, nil
+}
+
+// Cancel cancels a pending or running job, returning false if it's not found or is already done.
+// A running job is marked cancelled once its [JobFunc] returns.
+func (r *Runner) Cancel(id JobID) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	j, ok := r.jobs[id]
+	if !ok || j.status.Done() {
+		return false
+	}
+	if j.status == StatusRunning {
+		j.cancel()
+		return true
+	}
+	// Pending jobs are skipped when popped from the queue.
+	r.finishLocked(j, StatusCancelled, ErrJobCancelled)
+	return true
+}
+
+// Info returns a snapshot of the job's state.
+func (r *Runner) Info(id JobID) (Info, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	j, ok := r.jobs[id]
+	if !ok {
+		return Info{}, false
+	}
+	return j.info(), true
+}
+
+// Jobs returns a snapshot of all known jobs in submission order.
+// If any statuses are given, then only jobs with one of those statuses are returned.
+func (r *Runner) Jobs(statuses ...Status) []Info {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	infos := make([]Info, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		if len(statuses) > 0 && !slices.Contains(statuses, j.status) {
+			continue
+		}
+		infos = append(infos, j.info())
+	}
+	// IDs are ULIDs, so they sort in submission order.
+	slices.SortFunc(infos, func(a, b Info) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return infos
+}
+
+// Prune forgets jobs that finished more than the given duration ago, returning the number of jobs removed.
+func (r *Runner) Prune(olderThan time.Duration) int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	cutoff := time.Now().Add(-olderThan)
+	var removed int
+	for id, j := range r.jobs {
+		if j.status.Done() && j.finished.Before(cutoff) {
+			delete(r.jobs, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Wait blocks until the job is done or the context is cancelled, and returns the job's final [Info].
+// The job's own error is reported in [Info.Err], not the returned error.
+func (r *Runner) Wait(ctx context.Context, id JobID) (Info, error) {
+	r.mux.Lock()
+	j, ok := r.jobs[id]
+	r.mux.Unlock()
+	if !ok {
+		return Info{}, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	select {
+	case <-ctx.Done():
+		return Info{}, ctx.Err()
+	case <-j.done:
+		return syncx.LockFuncT(&r.mux, j.info), nil
+	}
+}
+
+func (r *Runner) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Runner) worker() {
+	defer r.workers.Done()
+	for {
+		j := r.next()
+		if j == nil {
+			select {
+			case <-r.ctx.Done():
+				return
+			case <-r.wake:
+				continue
+			}
+		}
+		r.run(j)
+	}
+}
+
+// next takes the highest priority job that is allowed to run, or returns nil if there isn't one.
+func (r *Runner) next() *job {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.stopped {
+		return nil
+	}
+	var (
+		found   *job
+		waiting []*job
+	)
+	for {
+		j, ok := r.pending.Pop()
+		if !ok {
+			break
+		}
+		if j.status != StatusPending {
+			// Cancelled while queued.
+			continue
+		}
+		if found == nil && r.canRun(j) {
+			found = j
+			continue
+		}
+		waiting = append(waiting, j)
+	}
+	// Re-queue in order so jobs with the same priority keep their submission order.
+	for _, j := range waiting {
+		r.pending.PushRanked(j, j.priority)
+	}
+	if found == nil {
+		return nil
+	}
+	for _, tag := range found.tags {
+		r.runCount[tag]++
+	}
+	found.ctx, found.cancel = contextx.WithCancel(r.ctx)
+	found.status = StatusRunning
+	found.started = time.Now()
+	if len(waiting) > 0 {
+		// Let another idle worker check for runnable jobs.
+		r.notify()
+	}
+	return found
+}
+
+func (r *Runner) canRun(j *job) bool {
+	for _, tag := range j.tags {
+		limit, ok := r.conf.tagLimits[tag]
+		if ok && r.runCount[tag] >= limit {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Runner) run(j *job) {
+	attempt := func() error {
+		syncx.LockFunc(&r.mux, func() {
+			j.attempts++
+		})
+		return j.fn(j.ctx)
+	}
+	var err error
+	if j.settings == nil {
+		err = attempt()
+	} else {
+		settings := j.settings.Copy()
+		settings.Context = j.ctx
+		err = retry.WithSettings(settings, func() (bool, error) {
+			err := attempt()
+			return err != nil, err
+		})
+	}
+	syncx.LockFunc(&r.mux, func() {
+		for _, tag := range j.tags {
+			r.runCount[tag]--
+		}
+	})
+	r.notify()
+	switch {
+	case err == nil:
+		r.finish(j, StatusSucceeded, nil)
+	case j.ctx.Err() != nil:
+		r.finish(j, StatusCancelled, fmt.Errorf("%w: %w", ErrJobCancelled, err))
+	default:
+		r.finish(j, StatusFailed, err)
+	}
+}
+
+func (r *Runner) finish(j *job, status Status, err error) {
+	syncx.LockFunc(&r.mux, func() {
+		r.finishLocked(j, status, err)
+	})
+}
+
+func (r *Runner) finishLocked(j *job, status Status, err error) {
+	if j.status.Done() {
+		return
+	}
+	j.status = status
+	j.err = err
+	j.finished = time.Now()
+	if j.cancel != nil {
+		j.cancel()
+	}
+	close(j.done)
+}
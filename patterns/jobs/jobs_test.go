@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"github.com/saylorsolutions/x/patterns/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testWait(t *testing.T, r *Runner, id JobID) Info {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	info, err := r.Wait(ctx, id)
+	require.NoError(t, err)
+	return info
+}
+
+func TestRunner_Priority(t *testing.T) {
+	r, err := NewRunner()
+	require.NoError(t, err)
+	var (
+		mux   sync.Mutex
+		order []string
+	)
+	record := func(name string) JobFunc {
+		return func(ctx context.Context) error {
+			mux.Lock()
+			defer mux.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+	// Submitted before start, so priority decides the order.
+	_, err = r.Submit(record("low-1"))
+	require.NoError(t, err)
+	_, err = r.Submit(record("high"), JobPriority(10))
+	require.NoError(t, err)
+	_, err = r.Submit(record("mid"), JobPriority(5))
+	require.NoError(t, err)
+	last, err := r.Submit(record("low-2"))
+	require.NoError(t, err)
+
+	r.Start(context.Background())
+	defer r.AwaitStop()
+	info := testWait(t, r, last)
+	assert.Equal(t, StatusSucceeded, info.Status)
+	assert.Equal(t, 1, info.Attempts)
+	mux.Lock()
+	defer mux.Unlock()
+	assert.Equal(t, []string{"high", "mid", "low-1", "low-2"}, order)
+}
+
+func TestRunner_TagLimit(t *testing.T) {
+	r, err := NewRunner(OptWorkers(4), OptTagLimit("db", 1))
+	require.NoError(t, err)
+	r.Start(context.Background())
+	defer r.AwaitStop()
+
+	var (
+		running, maxRunning atomic.Int32
+		ids                 []JobID
+	)
+	for i := 0; i < 4; i++ {
+		id, err := r.Submit(func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				cur := maxRunning.Load()
+				if n <= cur || maxRunning.CompareAndSwap(cur, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}, JobTags("db"))
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	untagged, err := r.Submit(func(ctx context.Context) error {
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusSucceeded, testWait(t, r, untagged).Status)
+	for _, id := range ids {
+		assert.Equal(t, StatusSucceeded, testWait(t, r, id).Status)
+	}
+	assert.Equal(t, int32(1), maxRunning.Load(), "Only one db job should run at a time")
+}
+
+func TestRunner_Retry(t *testing.T) {
+	r, err := NewRunner(OptRetry(retry.Settings{BackoffFactor: 1, MaxTries: 3}))
+	require.NoError(t, err)
+	r.Start(context.Background())
+	defer r.AwaitStop()
+
+	var calls atomic.Int32
+	id, err := r.Submit(func(ctx context.Context) error {
+		if calls.Add(1) < 2 {
+			return errors.New("flaky")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	info := testWait(t, r, id)
+	assert.Equal(t, StatusSucceeded, info.Status)
+	assert.Equal(t, 2, info.Attempts)
+
+	id, err = r.Submit(func(ctx context.Context) error {
+		return assert.AnError
+	}, JobName("always fails"), JobRetry(retry.Settings{BackoffFactor: 1, MaxTries: 2}))
+	require.NoError(t, err)
+	info = testWait(t, r, id)
+	assert.Equal(t, StatusFailed, info.Status)
+	assert.Equal(t, "always fails", info.Name)
+	assert.Equal(t, 2, info.Attempts)
+	assert.ErrorIs(t, info.Err, assert.AnError)
+	assert.ErrorIs(t, info.Err, retry.ErrMaxRetries)
+}
+
+func TestRunner_Cancel(t *testing.T) {
+	r, err := NewRunner()
+	require.NoError(t, err)
+	r.Start(context.Background())
+	defer r.AwaitStop()
+
+	started := make(chan struct{})
+	running, err := r.Submit(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+	pending, err := r.Submit(func(ctx context.Context) error {
+		t.Error("Cancelled job should not run")
+		return nil
+	})
+	require.NoError(t, err)
+	<-started
+
+	assert.Len(t, r.Jobs(StatusPending), 1)
+	assert.True(t, r.Cancel(pending))
+	assert.False(t, r.Cancel(pending), "Should not cancel twice")
+	info := testWait(t, r, pending)
+	assert.Equal(t, StatusCancelled, info.Status)
+	assert.Equal(t, 0, info.Attempts)
+
+	assert.True(t, r.Cancel(running))
+	info = testWait(t, r, running)
+	assert.Equal(t, StatusCancelled, info.Status)
+	assert.ErrorIs(t, info.Err, ErrJobCancelled)
+	assert.ErrorIs(t, info.Err, context.Canceled)
+
+	assert.False(t, r.Cancel("missing"))
+	_, err = r.Wait(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestRunner_Stop(t *testing.T) {
+	r, err := NewRunner()
+	require.NoError(t, err)
+	id, err := r.Submit(func(ctx context.Context) error {
+		return nil
+	})
+	require.NoError(t, err)
+	r.AwaitStop()
+
+	info := testWait(t, r, id)
+	assert.Equal(t, StatusCancelled, info.Status)
+	assert.ErrorIs(t, info.Err, ErrRunnerStopped)
+	_, err = r.Submit(func(ctx context.Context) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrRunnerStopped)
+
+	jobs := r.Jobs()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, 1, r.Prune(0))
+	assert.Empty(t, r.Jobs())
+}
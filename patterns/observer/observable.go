@@ -0,0 +1,138 @@
+package observer
+
+import (
+	"github.com/saylorsolutions/x/patterns/eventbus"
+	"github.com/saylorsolutions/x/syncx"
+	"sync"
+)
+
+// ChangeFunc is called with the previous and current value when an [Observable] changes.
+type ChangeFunc[T any] func(oldVal, newVal T)
+
+// Observable is a value that notifies subscribers synchronously when it changes.
+// Unlike [Subject], changes are applied and propagated before [Observable.Set] returns, and subscribers receive both the old and new value.
+//
+// Changes are propagated one at a time in the order they were made, so a [ChangeFunc] must not call [Observable.Set] on the same [Observable].
+type Observable[T any] struct {
+	equal func(a, b T) bool
+
+	setMux sync.Mutex // setMux serializes changes and their propagation.
+
+	mux         sync.RWMutex
+	value       T
+	nextID      int
+	subscribers []observableSub[T]
+}
+
+type observableSub[T any] struct {
+	id int
+	fn ChangeFunc[T]
+}
+
+// NewObservable creates an [Observable] for a comparable type, where setting an equal value doesn't notify subscribers.
+func NewObservable[T comparable](val T) *Observable[T] {
+	return NewObservableFunc(val, func(a, b T) bool {
+		return a == b
+	})
+}
+
+// NewObservableFunc creates an [Observable] that uses the given function to determine if a new value is a change.
+// If equal is nil, then every call to [Observable.Set] is treated as a change.
+func NewObservableFunc[T any](val T, equal func(a, b T) bool) *Observable[T] {
+	if equal == nil {
+		equal = func(a, b T) bool {
+			return false
+		}
+	}
+	return &Observable[T]{
+		equal: equal,
+		value: val,
+	}
+}
+
+// Get returns the current value.
+func (o *Observable[T]) Get() T {
+	return syncx.RLockFuncT(&o.mux, func() T {
+		return o.value
+	})
+}
+
+// Set changes the value and notifies subscribers, returning false if the new value is equal to the current value.
+func (o *Observable[T]) Set(newVal T) bool {
+	return o.Update(func(T) T {
+		return newVal
+	})
+}
+
+// Update sets the value to the result of fn, which is given the current value.
+// This allows read-modify-write changes without racing with other calls to Set or Update.
+func (o *Observable[T]) Update(fn func(cur T) T) bool {
+	o.setMux.Lock()
+	defer o.setMux.Unlock()
+	var (
+		oldVal, newVal T
+		subs           []observableSub[T]
+	)
+	changed := syncx.LockFuncT(&o.mux, func() bool {
+		oldVal = o.value
+		newVal = fn(oldVal)
+		if o.equal(oldVal, newVal) {
+			return false
+		}
+		o.value = newVal
+		subs = o.subscribers
+		return true
+	})
+	if !changed {
+		return false
+	}
+	for _, sub := range subs {
+		sub.fn(oldVal, newVal)
+	}
+	return true
+}
+
+// Subscribe adds a [ChangeFunc] that is called for each change, and returns a function to remove it.
+func (o *Observable[T]) Subscribe(fn ChangeFunc[T]) (unsubscribe func()) {
+	if fn == nil {
+		panic("nil change func")
+	}
+	id := syncx.LockFuncT(&o.mux, func() int {
+		o.nextID++
+		// Copy on write so Update can iterate without holding the lock.
+		subs := make([]observableSub[T], len(o.subscribers), len(o.subscribers)+1)
+		copy(subs, o.subscribers)
+		o.subscribers = append(subs, observableSub[T]{id: o.nextID, fn: fn})
+		return o.nextID
+	})
+	return sync.OnceFunc(func() {
+		syncx.LockFunc(&o.mux, func() {
+			subs := make([]observableSub[T], 0, len(o.subscribers))
+			for _, sub := range o.subscribers {
+				if sub.id != id {
+					subs = append(subs, sub)
+				}
+			}
+			o.subscribers = subs
+		})
+	})
+}
+
+// Observe adds an [Observer] that only receives the new value, so an [Observable] may be used where a [Subject] was.
+func (o *Observable[T]) Observe(obs Observer[T]) {
+	o.Subscribe(func(_, newVal T) {
+		obs(newVal)
+	})
+}
+
+// DispatchChanges bridges an [Observable] onto an [eventbus.EventBus].
+// Each change is dispatched as the given event, with the old and new value as parameters in that order.
+// The returned function stops dispatching changes.
+func DispatchChanges[T any](obs *Observable[T], bus *eventbus.EventBus, evt eventbus.Event) (unsubscribe func()) {
+	if bus == nil {
+		panic("nil event bus")
+	}
+	return obs.Subscribe(func(oldVal, newVal T) {
+		bus.Dispatch(evt, oldVal, newVal)
+	})
+}
@@ -0,0 +1,79 @@
+package observer
+
+import (
+	"context"
+	"github.com/saylorsolutions/x/patterns/eventbus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestObservable_Subscribe(t *testing.T) {
+	obs := NewObservable(5)
+	assert.Equal(t, 5, obs.Get())
+
+	var changes [][2]int
+	unsubscribe := obs.Subscribe(func(oldVal, newVal int) {
+		changes = append(changes, [2]int{oldVal, newVal})
+	})
+	var observed []int
+	obs.Observe(func(newVal int) {
+		observed = append(observed, newVal)
+	})
+
+	assert.True(t, obs.Set(10))
+	assert.False(t, obs.Set(10), "Setting an equal value is not a change")
+	assert.True(t, obs.Update(func(cur int) int {
+		return cur + 1
+	}))
+	assert.Equal(t, 11, obs.Get())
+	assert.Equal(t, [][2]int{{5, 10}, {10, 11}}, changes)
+	assert.Equal(t, []int{10, 11}, observed)
+
+	unsubscribe()
+	unsubscribe()
+	obs.Set(12)
+	assert.Len(t, changes, 2, "Should not be notified after unsubscribing")
+	assert.Equal(t, []int{10, 11, 12}, observed)
+}
+
+func TestObservableFunc(t *testing.T) {
+	obs := NewObservableFunc([]string{"a"}, nil)
+	var calls int
+	obs.Subscribe(func(oldVal, newVal []string) {
+		calls++
+	})
+	obs.Set([]string{"a"})
+	assert.Equal(t, 1, calls, "Every set is a change without an equal func")
+	assert.Panics(t, func() {
+		obs.Subscribe(nil)
+	})
+}
+
+func TestDispatchChanges(t *testing.T) {
+	const evtChanged eventbus.Event = 2
+	bus := eventbus.NewEventBus().Start(context.Background())
+	defer bus.AwaitStop(time.Second)
+
+	received := make(chan [2]string, 1)
+	bus.RegisterFunc("changes", evtChanged, func(evt eventbus.Event, params ...eventbus.Param) error {
+		received <- [2]string{params[0].(string), params[1].(string)}
+		return nil
+	})
+	obs := NewObservable("old")
+	stop := DispatchChanges(obs, bus, evtChanged)
+	obs.Set("new")
+	select {
+	case change := <-received:
+		assert.Equal(t, [2]string{"old", "new"}, change)
+	case <-time.After(time.Second):
+		t.Fatal("Change was not dispatched")
+	}
+	stop()
+	obs.Set("newer")
+	select {
+	case <-received:
+		t.Fatal("Should not dispatch after stopping")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
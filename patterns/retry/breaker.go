@@ -0,0 +1,128 @@
+package retry
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrBreakerOpen = errors.New("circuit breaker is open")
+)
+
+// Breaker is consulted by [WithSettings] before each attempt, and is told the outcome of each attempt.
+// This allows a retry loop to stop once a dependency is known to be unavailable, rather than using all of its tries against it.
+//
+// A single Breaker should be shared by all [Settings] that call the same dependency.
+type Breaker interface {
+	// Allow returns true if an attempt may be made.
+	Allow() bool
+	// RecordSuccess records an attempt that succeeded, or failed with an error that can't be retried.
+	// Non-retryable errors are considered a success, since they usually mean the dependency responded, but the request was at fault.
+	RecordSuccess()
+	// RecordFailure records an attempt that failed with an error that can be retried.
+	RecordFailure()
+}
+
+// BreakerState is the state of a [CircuitBreaker].
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // BreakerClosed allows all attempts.
+	BreakerOpen                         // BreakerOpen rejects all attempts until the cooldown elapses.
+	BreakerHalfOpen                     // BreakerHalfOpen allows a single probe attempt to determine if the dependency has recovered.
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker is a simple [Breaker] that opens after a number of consecutive failures.
+// Once the cooldown elapses, a single probe attempt is allowed.
+// If the probe succeeds then the breaker closes, otherwise it opens for another cooldown.
+type CircuitBreaker struct {
+	mux       sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// NewCircuitBreaker creates a [CircuitBreaker] that opens after threshold consecutive failures, and stays open for the cooldown.
+// This panics if threshold < 1 or cooldown <= 0, since those are programming errors.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		panic("circuit breaker threshold must be >= 1")
+	}
+	if cooldown <= 0 {
+		panic("circuit breaker cooldown must be > 0")
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// State returns the current [BreakerState].
+func (b *CircuitBreaker) State() BreakerState {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+func (b *CircuitBreaker) Allow() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *CircuitBreaker) RecordFailure() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+	b.probing = false
+}
@@ -0,0 +1,63 @@
+package retry
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(2, time.Second)
+	breaker.now = func() time.Time { return now }
+
+	assert.True(t, breaker.Allow())
+	breaker.RecordFailure()
+	assert.Equal(t, BreakerClosed, breaker.State())
+	breaker.RecordFailure()
+	assert.Equal(t, BreakerOpen, breaker.State())
+	assert.False(t, breaker.Allow())
+
+	now = now.Add(time.Second)
+	assert.Equal(t, BreakerHalfOpen, breaker.State())
+	assert.True(t, breaker.Allow(), "A probe should be allowed after the cooldown")
+	assert.False(t, breaker.Allow(), "Only one probe should be allowed")
+	breaker.RecordFailure()
+	assert.Equal(t, BreakerOpen, breaker.State(), "A failed probe should reopen the breaker")
+
+	now = now.Add(time.Second)
+	assert.True(t, breaker.Allow())
+	breaker.RecordSuccess()
+	assert.Equal(t, BreakerClosed, breaker.State())
+	assert.Equal(t, "closed", breaker.State().String())
+
+	assert.Panics(t, func() {
+		NewCircuitBreaker(0, time.Second)
+	})
+}
+
+func TestWithSettings_Breaker(t *testing.T) {
+	breaker := NewCircuitBreaker(2, time.Minute)
+	settings := Settings{BackoffFactor: 1, MaxTries: 5, Breaker: breaker}
+
+	var calls int
+	err := WithSettings(settings.Copy(), func() (bool, error) {
+		calls++
+		return true, testErrIntentional
+	})
+	assert.Equal(t, 2, calls, "Attempts should stop once the breaker opens")
+	assert.ErrorIs(t, err, ErrBreakerOpen)
+	assert.ErrorIs(t, err, testErrIntentional)
+
+	calls = 0
+	err = WithSettings(settings.Copy(), testPassingIterator)
+	assert.ErrorIs(t, err, ErrBreakerOpen)
+	assert.NotErrorIs(t, err, testErrIntentional)
+
+	breaker = NewCircuitBreaker(1, time.Minute)
+	settings.Breaker = breaker
+	assert.ErrorIs(t, WithSettings(settings.Copy(), func() (bool, error) {
+		return false, testErrIntentional
+	}), testErrIntentional)
+	assert.Equal(t, BreakerClosed, breaker.State(), "Non-retryable errors should not open the breaker")
+}
@@ -0,0 +1,82 @@
+package retry
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrBudgetExhausted = errors.New("retry budget exhausted")
+)
+
+// Budget limits the number of retries across many call sites, relative to the number of initial attempts.
+// This prevents retries from amplifying load during an outage, since every caller would otherwise retry at the same time.
+//
+// Within each window, retries are allowed up to MinRetries plus Ratio times the number of initial attempts.
+// For example, a ratio of 0.1 allows roughly one retry for every 10 calls, in addition to the minimum.
+// This mirrors the retry budgets used by gRPC and Finagle.
+//
+// A single Budget should be shared by all [Settings] that call the same dependency.
+type Budget struct {
+	mux         sync.Mutex
+	ratio       float64
+	minRetries  int
+	window      time.Duration
+	windowStart time.Time
+	attempts    int
+	retries     int
+	now         func() time.Time
+}
+
+// NewBudget creates a [Budget] that allows minRetries plus ratio times initial attempts to be retried per window.
+// This panics if ratio < 0, minRetries < 0, or window <= 0, since those are programming errors.
+func NewBudget(ratio float64, minRetries int, window time.Duration) *Budget {
+	if ratio < 0 {
+		panic("retry budget ratio must be >= 0")
+	}
+	if minRetries < 0 {
+		panic("retry budget min retries must be >= 0")
+	}
+	if window <= 0 {
+		panic("retry budget window must be > 0")
+	}
+	return &Budget{
+		ratio:      ratio,
+		minRetries: minRetries,
+		window:     window,
+		now:        time.Now,
+	}
+}
+
+func (b *Budget) rollWindow() {
+	now := b.now()
+	if now.Sub(b.windowStart) >= b.window {
+		b.windowStart = now
+		b.attempts = 0
+		b.retries = 0
+	}
+}
+
+// RecordAttempt records an initial (non-retry) attempt, which increases the number of allowed retries.
+// This is called automatically by [WithSettings].
+func (b *Budget) RecordAttempt() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.rollWindow()
+	b.attempts++
+}
+
+// TryRetry returns true and records a retry if the budget allows it.
+// This is called automatically by [WithSettings].
+func (b *Budget) TryRetry() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.rollWindow()
+	allowed := b.minRetries + int(b.ratio*float64(b.attempts))
+	if b.retries >= allowed {
+		return false
+	}
+	b.retries++
+	return true
+}
@@ -0,0 +1,58 @@
+package retry
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBudget(t *testing.T) {
+	now := time.Now()
+	budget := NewBudget(0.5, 1, time.Second)
+	budget.now = func() time.Time { return now }
+
+	assert.True(t, budget.TryRetry(), "Min retries should be allowed without attempts")
+	assert.False(t, budget.TryRetry())
+
+	budget.RecordAttempt()
+	budget.RecordAttempt()
+	assert.True(t, budget.TryRetry(), "Ratio should allow another retry")
+	assert.False(t, budget.TryRetry())
+
+	now = now.Add(time.Second)
+	assert.True(t, budget.TryRetry(), "New window should reset the budget")
+
+	assert.Panics(t, func() {
+		NewBudget(-1, 0, time.Second)
+	})
+	assert.Panics(t, func() {
+		NewBudget(0, 0, 0)
+	})
+}
+
+func TestWithSettings_Budget(t *testing.T) {
+	budget := NewBudget(0, 2, time.Minute)
+	settings := Settings{BackoffFactor: 1, MaxTries: 5, Budget: budget}
+
+	var calls int
+	err := WithSettings(settings.Copy(), func() (bool, error) {
+		calls++
+		return true, testErrIntentional
+	})
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.ErrorIs(t, err, testErrIntentional)
+	assert.Equal(t, 3, calls, "Should stop retrying when the budget is exhausted")
+
+	calls = 0
+	err = WithSettings(settings.Copy(), func() (bool, error) {
+		calls++
+		if calls == 1 {
+			return true, testErrIntentional
+		}
+		return false, nil
+	})
+	assert.ErrorIs(t, err, ErrBudgetExhausted, "Budget is shared between calls")
+	assert.Equal(t, 1, calls)
+
+	assert.NoError(t, WithSettings(settings.Copy(), testPassingIterator), "Initial attempts are never limited")
+}
@@ -0,0 +1,85 @@
+package retry
+
+import (
+	"fmt"
+	"time"
+)
+
+// Forever calls the [Iteration] indefinitely, which is useful for background reconciliation loops that should never give up.
+// Unlike [WithSettings], a successful iteration doesn't end the loop.
+//
+// TimeBetweenRetries is the delay after a successful iteration, and the initial delay after a failure.
+// Consecutive failures multiply the delay by BackoffFactor, up to MaxBackoff if it's set.
+// The backoff is only reset to TimeBetweenRetries after ResetAfter consecutive successes, so a flapping dependency isn't hammered.
+// If a [Breaker] is set, then attempts it doesn't allow are treated as failures without calling the [Iteration].
+// MaxTries and Budget are not used, since Forever never runs out of tries.
+//
+// Forever only returns when the Context is done, or the [Iteration] returns a non-retryable error.
+// An error wrapping [ErrInvalidSettings] is returned if TimeBetweenRetries is <= 0, since the loop would busy-spin.
+func Forever(settings Settings, iteration Iteration) error {
+	if settings.TimeBetweenRetries <= 0 {
+		return fmt.Errorf("%w: time between retries should be > 0", ErrInvalidSettings)
+	}
+	if settings.BackoffFactor < 1 {
+		return fmt.Errorf("%w: backoff factor should be >= 1", ErrInvalidSettings)
+	}
+	if settings.MaxBackoff < 0 {
+		return fmt.Errorf("%w: max backoff should be >= 0", ErrInvalidSettings)
+	}
+	if settings.ResetAfter < 1 {
+		settings.ResetAfter = 1
+	}
+	var (
+		backoff = settings.TimeBetweenRetries
+		streak  int
+	)
+	for {
+		if settings.Context != nil {
+			if err := settings.Context.Err(); err != nil {
+				return err
+			}
+		}
+		var (
+			shouldRetry = true
+			iterErr     = ErrBreakerOpen
+		)
+		if settings.Breaker == nil || settings.Breaker.Allow() {
+			shouldRetry, iterErr = iteration()
+			if settings.Breaker != nil {
+				if iterErr != nil && shouldRetry {
+					settings.Breaker.RecordFailure()
+				} else {
+					settings.Breaker.RecordSuccess()
+				}
+			}
+		}
+
+		delay := settings.TimeBetweenRetries
+		switch {
+		case iterErr == nil:
+			streak++
+			if streak >= settings.ResetAfter {
+				backoff = settings.TimeBetweenRetries
+			}
+		case !shouldRetry:
+			return iterErr
+		default:
+			streak = 0
+			delay = backoff
+			backoff = time.Duration(float64(backoff) * settings.BackoffFactor)
+			if settings.MaxBackoff > 0 && backoff > settings.MaxBackoff {
+				backoff = settings.MaxBackoff
+			}
+		}
+
+		if settings.Context != nil {
+			select {
+			case <-settings.Context.Done():
+				return settings.Context.Err()
+			case <-time.After(delay):
+			}
+		} else {
+			time.Sleep(delay)
+		}
+	}
+}
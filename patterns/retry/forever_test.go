@@ -0,0 +1,63 @@
+package retry
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestForever(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var (
+		calls int
+		last  time.Time
+		gaps  []time.Duration
+	)
+	settings := Settings{
+		Context:            ctx,
+		TimeBetweenRetries: 5 * time.Millisecond,
+		BackoffFactor:      4,
+		MaxBackoff:         40 * time.Millisecond,
+		ResetAfter:         2,
+	}
+	err := Forever(settings, func() (bool, error) {
+		calls++
+		if !last.IsZero() {
+			gaps = append(gaps, time.Since(last))
+		}
+		last = time.Now()
+		switch calls {
+		case 1, 2, 3, 4:
+			return true, testErrIntentional
+		case 5, 6:
+			return false, nil
+		default:
+			cancel()
+			return true, testErrIntentional
+		}
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 7, calls)
+	// Failure delays: 5ms, 20ms, 40ms (capped), 40ms, then success delays of 5ms.
+	assert.Len(t, gaps, 6)
+	assert.GreaterOrEqual(t, gaps[2], 40*time.Millisecond)
+	assert.Less(t, gaps[3], 80*time.Millisecond, "Backoff should be capped")
+	assert.Less(t, gaps[5], 30*time.Millisecond, "Successes should use the base delay")
+}
+
+func TestForever_NonRetryable(t *testing.T) {
+	var calls int
+	err := Forever(Settings{TimeBetweenRetries: time.Millisecond, BackoffFactor: 1}, func() (bool, error) {
+		calls++
+		if calls < 3 {
+			return false, nil
+		}
+		return false, testErrIntentional
+	})
+	assert.ErrorIs(t, err, testErrIntentional)
+	assert.Equal(t, 3, calls)
+
+	assert.ErrorIs(t, Forever(Settings{BackoffFactor: 1}, testPassingIterator), ErrInvalidSettings)
+}
@@ -0,0 +1,93 @@
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+type hedgeResult[T any] struct {
+	val T
+	err error
+}
+
+// Hedge runs fn, and starts additional concurrent attempts if it hasn't completed after a delay.
+// The first successful result is returned, and the context passed to the other attempts is cancelled.
+// This trades extra load for lower tail latency, so it's best used for idempotent reads.
+//
+// The [Settings] are interpreted as follows:
+//   - Context is the parent of the context passed to each attempt.
+//   - TimeBetweenRetries is the delay before starting the next attempt, multiplied by BackoffFactor after each one.
+//   - MaxTries is the maximum number of attempts, including the first.
+//   - Budget, if set, must allow each additional attempt.
+//
+// If an attempt fails before the delay elapses, then the next attempt is started immediately.
+// If all attempts fail, then the last error is returned, wrapped with [ErrMaxRetries].
+func Hedge[T any](settings Settings, fn func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if err := settings.validate(); err != nil {
+		return zero, err
+	}
+	parent := settings.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	if err := parent.Err(); err != nil {
+		return zero, err
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	// Buffered so attempts that finish after the result is returned don't block.
+	results := make(chan hedgeResult[T], settings.MaxTries)
+	launch := func() {
+		go func() {
+			val, err := fn(ctx)
+			results <- hedgeResult[T]{val, err}
+		}()
+	}
+	if settings.Budget != nil {
+		settings.Budget.RecordAttempt()
+	}
+	launch()
+	var (
+		started  = 1
+		finished int
+		lastErr  error
+		delay    = settings.TimeBetweenRetries
+		timer    = time.NewTimer(delay)
+	)
+	defer timer.Stop()
+	tryLaunch := func() {
+		if started >= settings.MaxTries {
+			return
+		}
+		if settings.Budget != nil && !settings.Budget.TryRetry() {
+			return
+		}
+		launch()
+		started++
+		delay = time.Duration(float64(delay) * settings.BackoffFactor)
+		timer.Reset(delay)
+	}
+	for {
+		select {
+		case <-parent.Done():
+			return zero, parent.Err()
+		case <-timer.C:
+			tryLaunch()
+		case result := <-results:
+			finished++
+			if result.err == nil {
+				return result.val, nil
+			}
+			lastErr = result.err
+			if finished == started {
+				tryLaunch()
+				if finished == started {
+					// Nothing else is running, and no more attempts may be launched.
+					return zero, &maxRetriesError{lastErr}
+				}
+			}
+		}
+	}
+}
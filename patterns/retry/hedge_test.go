@@ -0,0 +1,79 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedge(t *testing.T) {
+	settings := Settings{
+		TimeBetweenRetries: 20 * time.Millisecond,
+		BackoffFactor:      1,
+		MaxTries:           3,
+	}
+	t.Run("First attempt succeeds", func(t *testing.T) {
+		var calls atomic.Int32
+		val, err := Hedge(settings, func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			return 5, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 5, val)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+	t.Run("Slow attempt is hedged and cancelled", func(t *testing.T) {
+		var (
+			calls     atomic.Int32
+			cancelled = make(chan struct{})
+		)
+		val, err := Hedge(settings, func(ctx context.Context) (int, error) {
+			n := calls.Add(1)
+			if n == 1 {
+				<-ctx.Done()
+				close(cancelled)
+				return 0, ctx.Err()
+			}
+			return int(n), nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, val)
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("Slow attempt should have been cancelled")
+		}
+	})
+	t.Run("Failure starts next attempt immediately", func(t *testing.T) {
+		var calls atomic.Int32
+		start := time.Now()
+		_, err := Hedge(settings, func(ctx context.Context) (int, error) {
+			calls.Add(1)
+			return 0, testErrIntentional
+		})
+		assert.ErrorIs(t, err, ErrMaxRetries)
+		assert.ErrorIs(t, err, testErrIntentional)
+		assert.Equal(t, int32(3), calls.Load())
+		assert.Less(t, time.Since(start), settings.TimeBetweenRetries)
+	})
+	t.Run("Cancelled parent", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s := settings.Copy()
+		s.Context = ctx
+		_, err := Hedge(s, func(ctx context.Context) (int, error) {
+			t.Fatal("Should not be called")
+			return 0, nil
+		})
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+	t.Run("Invalid settings", func(t *testing.T) {
+		_, err := Hedge(Settings{MaxTries: 1, BackoffFactor: 1}, func(ctx context.Context) (int, error) {
+			return 0, nil
+		})
+		assert.ErrorIs(t, err, ErrInvalidSettings)
+	})
+}
@@ -21,6 +21,10 @@ type Settings struct {
 	TimeBetweenRetries time.Duration // This sets the initial delay between retries.
 	BackoffFactor      float64       // This value multiplies TimeBetweenRetries between loop iterations, and should be >= 1.
 	MaxTries           int           // This defines the maximum number of retries, and should be > 1.
+	Budget             *Budget       // This optionally limits retries across all Settings sharing the same Budget.
+	Breaker            Breaker       // This optionally stops attempts while a dependency is known to be unavailable.
+	MaxBackoff         time.Duration // This optionally caps the delay between retries for [Forever].
+	ResetAfter         int           // This sets the number of consecutive successes before [Forever] resets the backoff, and defaults to 1.
 }
 
 func (s Settings) Copy() Settings {
@@ -29,9 +33,26 @@ func (s Settings) Copy() Settings {
 		TimeBetweenRetries: s.TimeBetweenRetries,
 		BackoffFactor:      s.BackoffFactor,
 		MaxTries:           s.MaxTries,
+		Budget:             s.Budget,
+		Breaker:            s.Breaker,
+		MaxBackoff:         s.MaxBackoff,
+		ResetAfter:         s.ResetAfter,
 	}
 }
 
+func (s Settings) validate() error {
+	if s.MaxTries <= 1 {
+		return fmt.Errorf("%w: max tries should be > 1", ErrInvalidSettings)
+	}
+	if s.BackoffFactor < 1 {
+		return fmt.Errorf("%w: backoff factor should be >= 1", ErrInvalidSettings)
+	}
+	if s.TimeBetweenRetries < 0 {
+		return fmt.Errorf("%w: time between retries should be >= 0", ErrInvalidSettings)
+	}
+	return nil
+}
+
 var (
 	ErrInvalidSettings = errors.New("invalid settings")
 	ErrMaxRetries      = errors.New("max tries exceeded")
@@ -56,21 +77,25 @@ func Do(maxTries int, iteration Iteration) error {
 }
 
 // WithSettings allows passing [Settings] to the retry loop to tune the operation.
+//
+// If a [Breaker] is set, then it's consulted before each attempt and the outcome of each attempt is recorded in it.
+// An error wrapping [ErrBreakerOpen] (and the last iteration error, if any) is returned when the [Breaker] doesn't allow an attempt.
 func WithSettings(settings Settings, iteration Iteration) error {
-	if settings.MaxTries <= 1 {
-		return fmt.Errorf("%w: max tries should be > 1", ErrInvalidSettings)
-	}
-	if settings.BackoffFactor < 1 {
-		return fmt.Errorf("%w: backoff factor should be >= 1", ErrInvalidSettings)
-	}
-	if settings.TimeBetweenRetries < 0 {
-		return fmt.Errorf("%w: time between retries should be >= 0", ErrInvalidSettings)
+	if err := settings.validate(); err != nil {
+		return err
 	}
 	var (
 		shouldRetry bool
 		iterErr     error
 	)
 	for i := 0; i < settings.MaxTries; i++ {
+		if settings.Budget != nil {
+			if i == 0 {
+				settings.Budget.RecordAttempt()
+			} else if !settings.Budget.TryRetry() {
+				return fmt.Errorf("%w: %w", ErrBudgetExhausted, iterErr)
+			}
+		}
 		// Delays and context checks
 		if i > 0 && settings.TimeBetweenRetries > 0 {
 			if settings.Context != nil {
@@ -88,8 +113,22 @@ func WithSettings(settings Settings, iteration Iteration) error {
 			return settings.Context.Err()
 		}
 
+		if settings.Breaker != nil && !settings.Breaker.Allow() {
+			if iterErr != nil {
+				return fmt.Errorf("%w: %w", ErrBreakerOpen, iterErr)
+			}
+			return ErrBreakerOpen
+		}
+
 		// Try the loop
 		shouldRetry, iterErr = iteration()
+		if settings.Breaker != nil {
+			if iterErr != nil && shouldRetry {
+				settings.Breaker.RecordFailure()
+			} else {
+				settings.Breaker.RecordSuccess()
+			}
+		}
 		if iterErr != nil {
 			if shouldRetry {
 				continue
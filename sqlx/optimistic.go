@@ -0,0 +1,223 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/saylorsolutions/x/patterns/retry"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrVersionConflict = errors.New("version conflict, the row was modified or deleted by another transaction")
+	ErrInvalidModel    = errors.New("invalid model")
+)
+
+// Execer is any type that can execute a statement, like [sql.DB], [sql.Tx], or [sql.Conn].
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Placeholder produces the bind parameter placeholder for the nth (1-based) argument in a statement.
+type Placeholder func(n int) string
+
+// PlaceholderQuestion is used by drivers like MySQL and SQLite.
+func PlaceholderQuestion(int) string {
+	return "?"
+}
+
+// PlaceholderDollar is used by drivers like PostgreSQL.
+func PlaceholderDollar(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+type versionedModel struct {
+	columns    []string
+	values     []any
+	keyCols    []string
+	keyVals    []any
+	versionCol string
+	version    reflect.Value
+}
+
+// parseVersionedModel reads the db struct tags of the model, as described on [UpdateStatement].
+func parseVersionedModel(model any) (*versionedModel, error) {
+	val := reflect.ValueOf(model)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil, fmt.Errorf("%w: nil model", ErrInvalidModel)
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: expected a struct, got %T", ErrInvalidModel, model)
+	}
+	m := new(versionedModel)
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		name, flag, _ := strings.Cut(tag, ",")
+		if len(name) == 0 {
+			name = field.Name
+		}
+		fieldVal := val.Field(i)
+		switch flag {
+		case "key":
+			m.keyCols = append(m.keyCols, name)
+			m.keyVals = append(m.keyVals, fieldVal.Interface())
+		case "version":
+			if len(m.versionCol) > 0 {
+				return nil, fmt.Errorf("%w: multiple version columns", ErrInvalidModel)
+			}
+			switch fieldVal.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			default:
+				return nil, fmt.Errorf("%w: version field '%s' must be an integer", ErrInvalidModel, field.Name)
+			}
+			m.versionCol = name
+			m.version = fieldVal
+		case "":
+			m.columns = append(m.columns, name)
+			m.values = append(m.values, fieldVal.Interface())
+		default:
+			return nil, fmt.Errorf("%w: unknown db tag option '%s' on field '%s'", ErrInvalidModel, flag, field.Name)
+		}
+	}
+	if len(m.keyCols) == 0 {
+		return nil, fmt.Errorf("%w: no key columns", ErrInvalidModel)
+	}
+	if len(m.versionCol) == 0 {
+		return nil, fmt.Errorf("%w: no version column", ErrInvalidModel)
+	}
+	return m, nil
+}
+
+func (m *versionedModel) currentVersion() int64 {
+	if m.version.CanInt() {
+		return m.version.Int()
+	}
+	return int64(m.version.Uint())
+}
+
+// UpdateStatement generates an UPDATE statement for a model with a version column, along with its arguments.
+// All non-key columns are updated, the version is incremented, and the WHERE clause matches the key columns and current version.
+//
+//	UPDATE users SET name = ?, version = ? WHERE id = ? AND version = ?
+//
+// Columns are read from the db struct tags of the model.
+// Key columns are tagged with "key", the version column is tagged with "version", and fields tagged with "-" are skipped.
+//
+//	type User struct {
+//		ID      int    `db:"id,key"`
+//		Name    string `db:"name"`
+//		Version int    `db:"version,version"`
+//		Cache   string `db:"-"`
+//	}
+//
+// Exported fields without a db tag use the field name as the column.
+func UpdateStatement(table string, model any, placeholder Placeholder) (string, []any, error) {
+	m, err := parseVersionedModel(model)
+	if err != nil {
+		return "", nil, err
+	}
+	query, args := m.updateStatement(table, placeholder)
+	return query, args, nil
+}
+
+func (m *versionedModel) updateStatement(table string, placeholder Placeholder) (string, []any) {
+	var (
+		sb   strings.Builder
+		args = make([]any, 0, len(m.values)+len(m.keyVals)+2)
+	)
+	param := func() string {
+		return placeholder(len(args))
+	}
+	sb.WriteString("UPDATE ")
+	sb.WriteString(table)
+	sb.WriteString(" SET ")
+	for i, col := range m.columns {
+		args = append(args, m.values[i])
+		sb.WriteString(col)
+		sb.WriteString(" = ")
+		sb.WriteString(param())
+		sb.WriteString(", ")
+	}
+	version := m.currentVersion()
+	args = append(args, version+1)
+	sb.WriteString(m.versionCol)
+	sb.WriteString(" = ")
+	sb.WriteString(param())
+	sb.WriteString(" WHERE ")
+	for i, col := range m.keyCols {
+		args = append(args, m.keyVals[i])
+		sb.WriteString(col)
+		sb.WriteString(" = ")
+		sb.WriteString(param())
+		sb.WriteString(" AND ")
+	}
+	args = append(args, version)
+	sb.WriteString(m.versionCol)
+	sb.WriteString(" = ")
+	sb.WriteString(param())
+	return sb.String(), args
+}
+
+// UpdateVersioned executes the statement from [UpdateStatement] for the model.
+// If no rows were affected, then the row was changed or deleted since it was read, and [ErrVersionConflict] is returned.
+// On success, the model's version field is incremented to match the database, so the model must be a pointer.
+func UpdateVersioned(ctx context.Context, db Execer, table string, model any, placeholder Placeholder) error {
+	if reflect.ValueOf(model).Kind() != reflect.Pointer {
+		return fmt.Errorf("%w: model must be a pointer to update its version", ErrInvalidModel)
+	}
+	m, err := parseVersionedModel(model)
+	if err != nil {
+		return err
+	}
+	query, args := m.updateStatement(table, placeholder)
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrVersionConflict
+	}
+	if m.version.CanInt() {
+		m.version.SetInt(m.version.Int() + 1)
+	} else {
+		m.version.SetUint(m.version.Uint() + 1)
+	}
+	return nil
+}
+
+// RetryOnConflict calls attempt until it succeeds, returns an error other than [ErrVersionConflict], or the settings are exhausted.
+// The attempt should re-read the row, re-apply its changes, and call [UpdateVersioned], ideally in a single transaction.
+//
+//	err := sqlx.RetryOnConflict(settings, func() error {
+//		user, err := loadUser(ctx, db, id)
+//		if err != nil {
+//			return err
+//		}
+//		user.Name = newName
+//		return sqlx.UpdateVersioned(ctx, db, "users", user, sqlx.PlaceholderDollar)
+//	})
+func RetryOnConflict(settings retry.Settings, attempt func() error) error {
+	return retry.WithSettings(settings, func() (bool, error) {
+		err := attempt()
+		return errors.Is(err, ErrVersionConflict), err
+	})
+}
@@ -0,0 +1,125 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/saylorsolutions/x/patterns/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type testUser struct {
+	ID      int    `db:"id,key"`
+	Name    string `db:"name"`
+	Email   string
+	Version uint   `db:"version,version"`
+	Cache   string `db:"-"`
+	private string
+}
+
+type testResult struct {
+	sql.Result
+	affected int64
+}
+
+func (r testResult) RowsAffected() (int64, error) {
+	return r.affected, nil
+}
+
+type testExecer struct {
+	query    string
+	args     []any
+	affected int64
+	calls    int
+}
+
+func (e *testExecer) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	e.calls++
+	e.query = query
+	e.args = args
+	return testResult{affected: e.affected}, nil
+}
+
+func TestUpdateStatement(t *testing.T) {
+	user := testUser{ID: 5, Name: "bob", Email: "bob@example.com", Version: 2}
+	query, args, err := UpdateStatement("users", user, PlaceholderQuestion)
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = ?, Email = ?, version = ? WHERE id = ? AND version = ?", query)
+	assert.Equal(t, []any{"bob", "bob@example.com", int64(3), 5, int64(2)}, args)
+
+	query, _, err = UpdateStatement("users", &user, PlaceholderDollar)
+	require.NoError(t, err)
+	assert.Equal(t, "UPDATE users SET name = $1, Email = $2, version = $3 WHERE id = $4 AND version = $5", query)
+}
+
+func TestUpdateStatement_Invalid(t *testing.T) {
+	tests := map[string]any{
+		"Not a struct": 5,
+		"Nil pointer":  (*testUser)(nil),
+		"No key": struct {
+			Version int `db:"version,version"`
+		}{},
+		"No version": struct {
+			ID int `db:"id,key"`
+		}{},
+		"String version": struct {
+			ID      int    `db:"id,key"`
+			Version string `db:"version,version"`
+		}{},
+		"Unknown option": struct {
+			ID      int `db:"id,primary"`
+			Version int `db:"version,version"`
+		}{},
+	}
+	for name, model := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := UpdateStatement("table", model, PlaceholderQuestion)
+			assert.ErrorIs(t, err, ErrInvalidModel)
+		})
+	}
+}
+
+func TestUpdateVersioned(t *testing.T) {
+	user := &testUser{ID: 5, Name: "bob", Version: 2}
+	db := &testExecer{affected: 1}
+	require.NoError(t, UpdateVersioned(context.Background(), db, "users", user, PlaceholderQuestion))
+	assert.Equal(t, uint(3), user.Version)
+
+	db.affected = 0
+	err := UpdateVersioned(context.Background(), db, "users", user, PlaceholderQuestion)
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Equal(t, uint(3), user.Version, "Version should not change on conflict")
+
+	assert.ErrorIs(t, UpdateVersioned(context.Background(), db, "users", *user, PlaceholderQuestion), ErrInvalidModel)
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	settings := retry.Settings{BackoffFactor: 1, MaxTries: 3}
+	var attempts int
+	err := RetryOnConflict(settings, func() error {
+		attempts++
+		if attempts < 2 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	attempts = 0
+	other := errors.New("other")
+	err = RetryOnConflict(settings, func() error {
+		attempts++
+		return other
+	})
+	assert.ErrorIs(t, err, other)
+	assert.Equal(t, 1, attempts, "Other errors should not be retried")
+
+	err = RetryOnConflict(settings, func() error {
+		return ErrVersionConflict
+	})
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.ErrorIs(t, err, retry.ErrMaxRetries)
+}
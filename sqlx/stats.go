@@ -0,0 +1,209 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatsSource is any type that reports connection pool statistics, like [sql.DB].
+type StatsSource interface {
+	Stats() sql.DBStats
+}
+
+// StatsDelta is the change in the cumulative counters of [sql.DBStats] since the previous sample.
+type StatsDelta struct {
+	WaitCount         int64
+	WaitDuration      time.Duration
+	MaxIdleClosed     int64
+	MaxIdleTimeClosed int64
+	MaxLifetimeClosed int64
+}
+
+// StatsSample is a point in time sample of connection pool statistics.
+type StatsSample struct {
+	Time  time.Time
+	Stats sql.DBStats
+	Delta StatsDelta // Delta is relative to the previous sample, or to zero for the first sample.
+}
+
+// StatsSink receives each [StatsSample] taken by a [StatsPublisher].
+type StatsSink interface {
+	Publish(sample StatsSample) error
+}
+
+// StatsSinkFunc is a function that implements [StatsSink].
+type StatsSinkFunc func(sample StatsSample) error
+
+func (f StatsSinkFunc) Publish(sample StatsSample) error {
+	return f(sample)
+}
+
+// StatsPublisher samples a [StatsSource] on an interval and sends each sample to its sinks.
+type StatsPublisher struct {
+	src      StatsSource
+	interval time.Duration
+	sinks    []StatsSink
+	now      func() time.Time
+
+	mux  sync.Mutex
+	prev sql.DBStats
+}
+
+// NewStatsPublisher creates a [StatsPublisher] that samples the source every interval.
+func NewStatsPublisher(src StatsSource, interval time.Duration, sinks ...StatsSink) (*StatsPublisher, error) {
+	if src == nil {
+		return nil, errors.New("nil stats source")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid interval '%s', must be > 0", interval)
+	}
+	if len(sinks) == 0 {
+		return nil, errors.New("no stats sinks given")
+	}
+	for _, sink := range sinks {
+		if sink == nil {
+			return nil, errors.New("nil stats sink")
+		}
+	}
+	return &StatsPublisher{
+		src:      src,
+		interval: interval,
+		sinks:    sinks,
+		now:      time.Now,
+	}, nil
+}
+
+// Sample takes a [StatsSample] immediately and publishes it to all sinks.
+// Errors from sinks are joined, and don't prevent publishing to other sinks.
+func (p *StatsPublisher) Sample() (StatsSample, error) {
+	p.mux.Lock()
+	stats := p.src.Stats()
+	sample := StatsSample{
+		Time:  p.now(),
+		Stats: stats,
+		Delta: StatsDelta{
+			WaitCount:         stats.WaitCount - p.prev.WaitCount,
+			WaitDuration:      stats.WaitDuration - p.prev.WaitDuration,
+			MaxIdleClosed:     stats.MaxIdleClosed - p.prev.MaxIdleClosed,
+			MaxIdleTimeClosed: stats.MaxIdleTimeClosed - p.prev.MaxIdleTimeClosed,
+			MaxLifetimeClosed: stats.MaxLifetimeClosed - p.prev.MaxLifetimeClosed,
+		},
+	}
+	p.prev = stats
+	p.mux.Unlock()
+
+	var errs []error
+	for _, sink := range p.sinks {
+		if err := sink.Publish(sample); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return sample, errors.Join(errs...)
+}
+
+// Run takes a sample every interval until the context is cancelled.
+// If onError is not nil, then it's called with any error from publishing a sample.
+func (p *StatsPublisher) Run(ctx context.Context, onError func(err error)) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.Sample(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// ExpvarSink creates a [StatsSink] that publishes the latest sample as an [expvar.Map] with the given name.
+// Like [expvar.Publish], this panics if the name is already in use.
+func ExpvarSink(name string) StatsSink {
+	m := expvar.NewMap(name)
+	return StatsSinkFunc(func(sample StatsSample) error {
+		for metric, val := range statsMetrics(sample.Stats) {
+			v := new(expvar.Float)
+			v.Set(val.value)
+			m.Set(metric, v)
+		}
+		return nil
+	})
+}
+
+type statsMetric struct {
+	value   float64
+	counter bool
+	help    string
+}
+
+func statsMetrics(stats sql.DBStats) map[string]statsMetric {
+	return map[string]statsMetric{
+		"max_open_connections":        {float64(stats.MaxOpenConnections), false, "Maximum number of open connections to the database."},
+		"open_connections":            {float64(stats.OpenConnections), false, "The number of established connections both in use and idle."},
+		"in_use_connections":          {float64(stats.InUse), false, "The number of connections currently in use."},
+		"idle_connections":            {float64(stats.Idle), false, "The number of idle connections."},
+		"wait_count_total":            {float64(stats.WaitCount), true, "The total number of connections waited for."},
+		"wait_duration_seconds_total": {stats.WaitDuration.Seconds(), true, "The total time blocked waiting for a new connection."},
+		"max_idle_closed_total":       {float64(stats.MaxIdleClosed), true, "The total number of connections closed due to SetMaxIdleConns."},
+		"max_idle_time_closed_total":  {float64(stats.MaxIdleTimeClosed), true, "The total number of connections closed due to SetConnMaxIdleTime."},
+		"max_lifetime_closed_total":   {float64(stats.MaxLifetimeClosed), true, "The total number of connections closed due to SetConnMaxLifetime."},
+	}
+}
+
+// PrometheusTextfileSink creates a [StatsSink] that writes the latest sample to a file in the Prometheus text exposition format.
+// This is intended for the node_exporter textfile collector, and the file is replaced atomically so a partial file is never collected.
+//
+// Metric names are prefixed with "sqlx_db_", and labeled with the given database name.
+func PrometheusTextfileSink(path, dbName string) StatsSink {
+	return StatsSinkFunc(func(sample StatsSample) error {
+		metrics := statsMetrics(sample.Stats)
+		names := make([]string, 0, len(metrics))
+		for name := range metrics {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		label := fmt.Sprintf(`{db=%s}`, strconv.Quote(dbName))
+		var sb strings.Builder
+		for _, name := range names {
+			metric := metrics[name]
+			fullName := "sqlx_db_" + name
+			metricType := "gauge"
+			if metric.counter {
+				metricType = "counter"
+			}
+			_, _ = fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s %s\n%s%s %s\n",
+				fullName, metric.help, fullName, metricType, fullName, label, strconv.FormatFloat(metric.value, 'g', -1, 64))
+		}
+		tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = os.Remove(tmp.Name())
+		}()
+		if _, err := tmp.WriteString(sb.String()); err != nil {
+			_ = tmp.Close()
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			return err
+		}
+		// Temp files are only readable by the owner, but the collector may run as another user.
+		if err := os.Chmod(tmp.Name(), 0644); err != nil {
+			return err
+		}
+		return os.Rename(tmp.Name(), path)
+	})
+}
@@ -0,0 +1,108 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"expvar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeStatsSource struct {
+	stats sql.DBStats
+}
+
+func (f *fakeStatsSource) Stats() sql.DBStats {
+	return f.stats
+}
+
+func TestStatsPublisher_Sample(t *testing.T) {
+	src := &fakeStatsSource{stats: sql.DBStats{OpenConnections: 2, WaitCount: 3, MaxLifetimeClosed: 1}}
+	var samples []StatsSample
+	pub, err := NewStatsPublisher(src, time.Second, StatsSinkFunc(func(sample StatsSample) error {
+		samples = append(samples, sample)
+		return nil
+	}))
+	require.NoError(t, err)
+
+	sample, err := pub.Sample()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), sample.Delta.WaitCount)
+	assert.Equal(t, int64(1), sample.Delta.MaxLifetimeClosed)
+
+	src.stats.WaitCount = 10
+	src.stats.WaitDuration = time.Second
+	sample, err = pub.Sample()
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), sample.Delta.WaitCount)
+	assert.Equal(t, time.Second, sample.Delta.WaitDuration)
+	assert.Equal(t, int64(0), sample.Delta.MaxLifetimeClosed)
+	assert.Len(t, samples, 2)
+}
+
+func TestStatsPublisher_Run(t *testing.T) {
+	src := &fakeStatsSource{}
+	published := make(chan struct{}, 10)
+	pub, err := NewStatsPublisher(src, 5*time.Millisecond,
+		StatsSinkFunc(func(sample StatsSample) error {
+			published <- struct{}{}
+			return nil
+		}),
+		StatsSinkFunc(func(sample StatsSample) error {
+			return assert.AnError
+		}),
+	)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pub.Run(ctx, func(err error) {
+			errs <- err
+		})
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Sample was not published")
+	}
+	cancel()
+	<-done
+	assert.ErrorIs(t, <-errs, assert.AnError, "Other sinks should still be published to")
+
+	_, err = NewStatsPublisher(nil, time.Second, StatsSinkFunc(nil))
+	assert.Error(t, err)
+	_, err = NewStatsPublisher(src, 0, StatsSinkFunc(nil))
+	assert.Error(t, err)
+	_, err = NewStatsPublisher(src, time.Second)
+	assert.Error(t, err)
+}
+
+func TestExpvarSink(t *testing.T) {
+	sink := ExpvarSink("sqlx_test_stats")
+	require.NoError(t, sink.Publish(StatsSample{Stats: sql.DBStats{InUse: 4}}))
+	m := expvar.Get("sqlx_test_stats").(*expvar.Map)
+	assert.Equal(t, "4", m.Get("in_use_connections").String())
+}
+
+func TestPrometheusTextfileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.prom")
+	sink := PrometheusTextfileSink(path, "main")
+	require.NoError(t, sink.Publish(StatsSample{Stats: sql.DBStats{Idle: 2, WaitDuration: 1500 * time.Millisecond}}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# TYPE sqlx_db_idle_connections gauge\nsqlx_db_idle_connections{db=\"main\"} 2\n")
+	assert.Contains(t, string(data), "# TYPE sqlx_db_wait_duration_seconds_total counter\nsqlx_db_wait_duration_seconds_total{db=\"main\"} 1.5\n")
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "Temp file should be cleaned up")
+
+	err = PrometheusTextfileSink(filepath.Join(t.TempDir(), "missing", "db.prom"), "main").Publish(StatsSample{})
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
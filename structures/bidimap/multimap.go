@@ -2,6 +2,7 @@ package bidimap
 
 import (
 	"github.com/saylorsolutions/x/structures/set"
+	"iter"
 	"sync"
 )
 
@@ -117,3 +118,70 @@ func (m *MultiMap[K, V]) HasKey(key K) bool {
 	_, ok := m.GetValuesOk(key)
 	return ok
 }
+
+// Keys returns an iterator over every key with at least one associated value, in no particular order.
+// The iterator reads from a snapshot taken when iteration starts, so changes made to the [MultiMap] during iteration are not observed.
+func (m *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.init()
+		m.mux.Lock()
+		keys := make([]K, 0, len(m.ktov))
+		for key, values := range m.ktov {
+			if len(values) > 0 {
+				keys = append(keys, key)
+			}
+		}
+		m.mux.Unlock()
+		for _, key := range keys {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over every value with at least one associated key, in no particular order.
+// The iterator reads from a snapshot taken when iteration starts, so changes made to the [MultiMap] during iteration are not observed.
+func (m *MultiMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.init()
+		m.mux.Lock()
+		values := make([]V, 0, len(m.vtok))
+		for value, keys := range m.vtok {
+			if len(keys) > 0 {
+				values = append(values, value)
+			}
+		}
+		m.mux.Unlock()
+		for _, value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Pairs returns an iterator over every association between a key and a value, in no particular order.
+// The iterator reads from a snapshot taken when iteration starts, so changes made to the [MultiMap] during iteration are not observed.
+func (m *MultiMap[K, V]) Pairs() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		type pair struct {
+			key K
+			val V
+		}
+		m.init()
+		m.mux.Lock()
+		var pairs []pair
+		for key, values := range m.ktov {
+			for val := range values {
+				pairs = append(pairs, pair{key: key, val: val})
+			}
+		}
+		m.mux.Unlock()
+		for _, p := range pairs {
+			if !yield(p.key, p.val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package bidimap
+
+import (
+	"encoding/json"
+	"github.com/saylorsolutions/x/structures/set"
+)
+
+type multiMapEntry[K comparable, V comparable] struct {
+	Key    K          `json:"key"`
+	Values set.Set[V] `json:"values"`
+}
+
+// MarshalJSON encodes the [MultiMap] as a JSON array of objects with a "key" and its "values".
+// An array is used rather than an object, because keys are not limited to strings.
+// Keys and values are sorted with [set.Sort] for determinism.
+func (m *MultiMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.init()
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	keys := set.FromKeys(m.ktov).SortedSlice()
+	entries := make([]multiMapEntry[K, V], 0, len(keys))
+	for _, key := range keys {
+		if len(m.ktov[key]) == 0 {
+			continue
+		}
+		entries = append(entries, multiMapEntry[K, V]{Key: key, Values: m.ktov[key]})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON decodes the format produced by [MultiMap.MarshalJSON], replacing any existing associations.
+func (m *MultiMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []multiMapEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	m.init()
+	m.mux.Lock()
+	m.ktov = map[K]set.Set[V]{}
+	m.vtok = map[V]set.Set[K]{}
+	m.mux.Unlock()
+	for _, entry := range entries {
+		for val := range entry.Values {
+			m.AddValues(entry.Key, val)
+		}
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package bidimap
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"slices"
 	"testing"
 )
 
@@ -44,3 +47,42 @@ func TestMultiMap_AddKeys(t *testing.T) {
 		assert.Equal(t, "1", values[0])
 	}
 }
+
+func TestMultiMap_JSON(t *testing.T) {
+	m := NewMulti[int, string]()
+	m.AddValues(2, "b", "a")
+	m.AddValues(1, "a")
+	data, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"key":1,"values":["a"]},{"key":2,"values":["a","b"]}]`, string(data))
+
+	decoded := new(MultiMap[int, string])
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.ElementsMatch(t, []int{1, 2}, decoded.GetKeys("a"))
+	assert.ElementsMatch(t, []string{"a", "b"}, decoded.GetValues(2))
+	assert.False(t, decoded.HasKey(3))
+}
+
+func TestMultiMap_Iterators(t *testing.T) {
+	m := new(MultiMap[int, string])
+	assert.Empty(t, slices.Collect(m.Keys()))
+	m.AddValues(1, "a", "b")
+	m.AddValues(2, "b")
+
+	assert.ElementsMatch(t, []int{1, 2}, slices.Collect(m.Keys()))
+	assert.ElementsMatch(t, []string{"a", "b"}, slices.Collect(m.Values()))
+	var pairs []string
+	for key, val := range m.Pairs() {
+		pairs = append(pairs, fmt.Sprintf("%d:%s", key, val))
+	}
+	assert.ElementsMatch(t, []string{"1:a", "1:b", "2:b"}, pairs)
+
+	var keys []int
+	for key := range m.Keys() {
+		// Changes during iteration are not observed, and don't deadlock.
+		m.AddValues(3, "c")
+		keys = append(keys, key)
+	}
+	assert.Len(t, keys, 2)
+	assert.True(t, m.HasKey(3))
+}
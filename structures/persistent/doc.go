@@ -0,0 +1,4 @@
+// Package persistent provides immutable data structures that share structure between versions.
+// Every modification returns a new version, and previous versions are unaffected, which gives cheap snapshot semantics.
+// Since versions are never modified, they're safe to share between goroutines without locking.
+package persistent
@@ -0,0 +1,122 @@
+package persistent
+
+import (
+	"fmt"
+	"iter"
+)
+
+const (
+	listBits  = 5
+	listWidth = 1 << listBits
+	listMask  = listWidth - 1
+)
+
+type listNode[T any] struct {
+	children [listWidth]*listNode[T]
+	values   [listWidth]T
+}
+
+func (n *listNode[T]) clone() *listNode[T] {
+	if n == nil {
+		return new(listNode[T])
+	}
+	c := *n
+	return &c
+}
+
+// List is an immutable, indexed sequence of values.
+// Modifying operations return a new version of the List that shares most of its structure with the original, so old versions remain valid and cheap to keep.
+// This makes it suitable for taking snapshots of state without copying it.
+//
+// The zero value is an empty List ready to use.
+// Lookups and modifications are O(log32 n), which is effectively constant for practical sizes.
+type List[T any] struct {
+	root  *listNode[T]
+	size  int
+	shift uint
+}
+
+// ListOf creates a [List] with the given values.
+func ListOf[T any](vals ...T) List[T] {
+	var l List[T]
+	return l.Append(vals...)
+}
+
+// Len returns the number of values in the [List].
+func (l List[T]) Len() int {
+	return l.size
+}
+
+// Get returns the value at the index, and panics if the index is out of range like a slice would.
+func (l List[T]) Get(i int) T {
+	l.checkIndex(i)
+	n := l.root
+	for shift := l.shift; shift > 0; shift -= listBits {
+		n = n.children[(i>>shift)&listMask]
+	}
+	return n.values[i&listMask]
+}
+
+// Set returns a new version of the [List] with the value at the index replaced.
+// Panics if the index is out of range.
+func (l List[T]) Set(i int, val T) List[T] {
+	l.checkIndex(i)
+	l.root = l.root.set(l.shift, i, val)
+	return l
+}
+
+// Append returns a new version of the [List] with the values added to the end.
+func (l List[T]) Append(vals ...T) List[T] {
+	for _, val := range vals {
+		if l.root != nil && l.size == 1<<(l.shift+listBits) {
+			// Root is full, grow the tree by a level.
+			root := new(listNode[T])
+			root.children[0] = l.root
+			l.root = root
+			l.shift += listBits
+		}
+		l.root = l.root.set(l.shift, l.size, val)
+		l.size++
+	}
+	return l
+}
+
+func (n *listNode[T]) set(shift uint, i int, val T) *listNode[T] {
+	n = n.clone()
+	if shift == 0 {
+		n.values[i&listMask] = val
+		return n
+	}
+	idx := (i >> shift) & listMask
+	n.children[idx] = n.children[idx].set(shift-listBits, i, val)
+	return n
+}
+
+func (l List[T]) checkIndex(i int) {
+	if i < 0 || i >= l.size {
+		panic(fmt.Sprintf("index %d out of range for list with length %d", i, l.size))
+	}
+}
+
+// All iterates over the index and value of each element in order.
+func (l List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < l.size; i++ {
+			if !yield(i, l.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Slice copies the values of the [List] to a new slice.
+func (l List[T]) Slice() []T {
+	if l.size == 0 {
+		return nil
+	}
+	vals := make([]T, 0, l.size)
+	for _, val := range l.All() {
+		vals = append(vals, val)
+	}
+	return vals
+}
@@ -0,0 +1,62 @@
+package persistent
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	var empty List[int]
+	assert.Equal(t, 0, empty.Len())
+	assert.Nil(t, empty.Slice())
+
+	const size = 40_000
+	var (
+		l        List[int]
+		versions []List[int]
+	)
+	for i := 0; i < size; i++ {
+		l = l.Append(i)
+		if i%10_000 == 0 {
+			versions = append(versions, l)
+		}
+	}
+	assert.Equal(t, size, l.Len())
+	for i := 0; i < size; i++ {
+		if !assert.Equal(t, i, l.Get(i)) {
+			break
+		}
+	}
+	for i, v := range versions {
+		assert.Equal(t, i*10_000+1, v.Len(), "Old versions should be unchanged")
+		assert.Equal(t, i*10_000, v.Get(v.Len()-1))
+	}
+
+	updated := l.Set(1234, -1)
+	assert.Equal(t, -1, updated.Get(1234))
+	assert.Equal(t, 1234, l.Get(1234), "Set should not modify the original")
+
+	assert.Panics(t, func() {
+		l.Get(size)
+	})
+	assert.Panics(t, func() {
+		l.Set(-1, 0)
+	})
+}
+
+func TestListOf(t *testing.T) {
+	l := ListOf("a", "b", "c")
+	assert.Equal(t, []string{"a", "b", "c"}, l.Slice())
+	branch := l.Append("d")
+	assert.Equal(t, []string{"a", "b", "c"}, l.Slice())
+	assert.Equal(t, []string{"a", "b", "c", "d"}, branch.Slice())
+
+	var visited []int
+	for i := range l.All() {
+		visited = append(visited, i)
+		if i == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1}, visited)
+}
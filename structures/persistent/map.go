@@ -0,0 +1,199 @@
+package persistent
+
+import (
+	"cmp"
+	"iter"
+)
+
+type mapNode[K cmp.Ordered, V any] struct {
+	key         K
+	val         V
+	left, right *mapNode[K, V]
+	height      int
+}
+
+func (n *mapNode[K, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// with returns a copy of the node with new children, and a recalculated height.
+// Nodes are never modified after they're created, since they may be shared between versions.
+func (n *mapNode[K, V]) with(left, right *mapNode[K, V]) *mapNode[K, V] {
+	return &mapNode[K, V]{
+		key:    n.key,
+		val:    n.val,
+		left:   left,
+		right:  right,
+		height: 1 + max(left.getHeight(), right.getHeight()),
+	}
+}
+
+// Map is an immutable map with ordered keys.
+// Modifying operations return a new version of the Map that shares most of its structure with the original, so old versions remain valid and cheap to keep.
+// This makes it suitable for taking snapshots of state without copying it.
+//
+// The zero value is an empty Map ready to use.
+// Lookups and modifications are O(log n), and iteration is in key order.
+type Map[K cmp.Ordered, V any] struct {
+	root *mapNode[K, V]
+	size int
+}
+
+// Len returns the number of entries in the [Map].
+func (m Map[K, V]) Len() int {
+	return m.size
+}
+
+// Get returns the value for the key, and whether it was found.
+func (m Map[K, V]) Get(key K) (V, bool) {
+	n := m.root
+	for n != nil {
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Has returns true if the key is in the [Map].
+func (m Map[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set returns a new version of the [Map] with the key set to the value.
+func (m Map[K, V]) Set(key K, val V) Map[K, V] {
+	var added bool
+	m.root = m.root.insert(key, val, &added)
+	if added {
+		m.size++
+	}
+	return m
+}
+
+// Delete returns a new version of the [Map] without the key.
+// If the key isn't present, then the same version is returned.
+func (m Map[K, V]) Delete(key K) Map[K, V] {
+	var removed bool
+	root := m.root.remove(key, &removed)
+	if !removed {
+		return m
+	}
+	m.root = root
+	m.size--
+	return m
+}
+
+// All iterates over the entries of the [Map] in key order.
+func (m Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.walk(yield)
+	}
+}
+
+// Keys returns the keys of the [Map] in order.
+func (m Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	for key := range m.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (n *mapNode[K, V]) walk(yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.walk(yield) && yield(n.key, n.val) && n.right.walk(yield)
+}
+
+func (n *mapNode[K, V]) insert(key K, val V, added *bool) *mapNode[K, V] {
+	if n == nil {
+		*added = true
+		return &mapNode[K, V]{key: key, val: val, height: 1}
+	}
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		return n.with(n.left.insert(key, val, added), n.right).balance()
+	case c > 0:
+		return n.with(n.left, n.right.insert(key, val, added)).balance()
+	default:
+		updated := n.with(n.left, n.right)
+		updated.val = val
+		return updated
+	}
+}
+
+func (n *mapNode[K, V]) remove(key K, removed *bool) *mapNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		left := n.left.remove(key, removed)
+		if !*removed {
+			return n
+		}
+		return n.with(left, n.right).balance()
+	case c > 0:
+		right := n.right.remove(key, removed)
+		if !*removed {
+			return n
+		}
+		return n.with(n.left, right).balance()
+	default:
+		*removed = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		// Replace with the smallest node in the right subtree.
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		var ignored bool
+		right := n.right.remove(successor.key, &ignored)
+		return successor.with(n.left, right).balance()
+	}
+}
+
+func (n *mapNode[K, V]) balance() *mapNode[K, V] {
+	switch diff := n.left.getHeight() - n.right.getHeight(); {
+	case diff > 1:
+		left := n.left
+		if left.left.getHeight() < left.right.getHeight() {
+			left = left.rotateLeft()
+		}
+		return n.with(left, n.right).rotateRight()
+	case diff < -1:
+		right := n.right
+		if right.right.getHeight() < right.left.getHeight() {
+			right = right.rotateRight()
+		}
+		return n.with(n.left, right).rotateLeft()
+	default:
+		return n
+	}
+}
+
+func (n *mapNode[K, V]) rotateRight() *mapNode[K, V] {
+	l := n.left
+	return l.with(l.left, n.with(l.right, n.right))
+}
+
+func (n *mapNode[K, V]) rotateLeft() *mapNode[K, V] {
+	r := n.right
+	return r.with(n.with(n.left, r.left), r.right)
+}
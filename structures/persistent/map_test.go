@@ -0,0 +1,68 @@
+package persistent
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func testCheckBalanced[K interface{ ~int | ~string }, V any](t *testing.T, n *mapNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	left, right := testCheckBalanced(t, n.left), testCheckBalanced(t, n.right)
+	assert.LessOrEqual(t, max(left-right, right-left), 1, "Tree should be balanced")
+	assert.Equal(t, 1+max(left, right), n.height)
+	return n.height
+}
+
+func TestMap(t *testing.T) {
+	var (
+		m        Map[int, int]
+		expected = map[int]int{}
+		rng      = rand.New(rand.NewSource(1))
+	)
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(1000)
+		if rng.Intn(3) == 0 {
+			m = m.Delete(key)
+			delete(expected, key)
+			continue
+		}
+		m = m.Set(key, i)
+		expected[key] = i
+	}
+	testCheckBalanced(t, m.root)
+	assert.Equal(t, len(expected), m.Len())
+	for key, val := range expected {
+		got, ok := m.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, val, got)
+	}
+	keys := m.Keys()
+	assert.True(t, slices.IsSorted(keys), "Keys should be in order")
+	assert.Len(t, keys, len(expected))
+}
+
+func TestMap_Versions(t *testing.T) {
+	v1 := Map[string, int]{}.Set("a", 1).Set("b", 2)
+	v2 := v1.Set("a", 10).Set("c", 3)
+	v3 := v2.Delete("b")
+
+	assert.Equal(t, []string{"a", "b"}, v1.Keys())
+	val, _ := v1.Get("a")
+	assert.Equal(t, 1, val)
+
+	assert.Equal(t, []string{"a", "b", "c"}, v2.Keys())
+	val, _ = v2.Get("a")
+	assert.Equal(t, 10, val)
+
+	assert.Equal(t, []string{"a", "c"}, v3.Keys())
+	assert.False(t, v3.Has("b"))
+	assert.True(t, v2.Has("b"))
+
+	same := v3.Delete("missing")
+	assert.Equal(t, v3, same)
+	assert.Equal(t, 2, same.Len())
+}
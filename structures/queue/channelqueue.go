@@ -3,6 +3,7 @@ package queue
 import (
 	"context"
 	"fmt"
+	"github.com/saylorsolutions/x/contextx"
 	"sync"
 )
 
@@ -68,7 +69,7 @@ func NewChannelQueue[T any](ctx context.Context, opts ...ChannelQueueOption) (*C
 	var (
 		cancel context.CancelFunc
 	)
-	ctx, cancel = context.WithCancel(ctx)
+	ctx, cancel = contextx.WithCancel(ctx)
 	cq := &ChannelQueue[T]{
 		ctx:     ctx,
 		stop:    cancel,
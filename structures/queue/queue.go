@@ -104,3 +104,62 @@ func (q *Queue[T]) iterator() iter.Seq[T] {
 		}
 	}
 }
+
+// Peek returns the item at the head of the Queue without removing it.
+// False will be returned if the Queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	val, _, ok := q.PeekRanked()
+	return val, ok
+}
+
+// PeekRanked is the same as [Queue.Peek], but also returns the item's priority.
+func (q *Queue[T]) PeekRanked() (T, uint, bool) {
+	q.mux.RLock()
+	defer q.mux.RUnlock()
+	if len(q.values) == 0 {
+		var mt T
+		return mt, 0, false
+	}
+	return q.values[0].val, q.values[0].priority, true
+}
+
+// Snapshot returns a copy of the items in the Queue, in the order they would be popped.
+// The Queue is not modified.
+func (q *Queue[T]) Snapshot() []T {
+	q.mux.RLock()
+	defer q.mux.RUnlock()
+	vals := make([]T, len(q.values))
+	for i, el := range q.values {
+		vals[i] = el.val
+	}
+	return vals
+}
+
+// All returns an iterator over the items in the Queue, in the order they would be popped, without removing them.
+// The iterator reads from a snapshot taken when iteration starts, so changes made to the Queue during iteration are not observed.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, val := range q.Snapshot() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// AllRanked is the same as [Queue.All], but also yields each item's priority.
+func (q *Queue[T]) AllRanked() iter.Seq2[T, uint] {
+	return func(yield func(T, uint) bool) {
+		q.mux.RLock()
+		elements := make([]queueElement[T], len(q.values))
+		for i, el := range q.values {
+			elements[i] = *el
+		}
+		q.mux.RUnlock()
+		for _, el := range elements {
+			if !yield(el.val, el.priority) {
+				return
+			}
+		}
+	}
+}
@@ -39,3 +39,33 @@ func TestNewQueue(t *testing.T) {
 	assert.Equal(t, 3, val)
 	assert.Equal(t, 0, q.Len())
 }
+
+func TestQueue_Inspect(t *testing.T) {
+	q := NewQueue[string]()
+	_, ok := q.Peek()
+	assert.False(t, ok)
+	assert.Empty(t, q.Snapshot())
+
+	q.Push("c")
+	q.PushRanked("a", 2)
+	q.PushRanked("b", 1)
+
+	val, priority, ok := q.PeekRanked()
+	assert.True(t, ok)
+	assert.Equal(t, "a", val)
+	assert.Equal(t, uint(2), priority)
+	assert.Equal(t, []string{"a", "b", "c"}, q.Snapshot())
+
+	var vals []string
+	for val := range q.All() {
+		vals = append(vals, val)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, vals)
+
+	var priorities []uint
+	for _, priority := range q.AllRanked() {
+		priorities = append(priorities, priority)
+	}
+	assert.Equal(t, []uint{2, 1, 0}, priorities)
+	assert.Equal(t, 3, q.Len(), "Inspection should not modify the queue")
+}
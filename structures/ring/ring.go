@@ -0,0 +1,132 @@
+package ring
+
+import (
+	"errors"
+	"iter"
+	"sync"
+)
+
+var (
+	ErrFull = errors.New("ring buffer is full")
+)
+
+// Mode determines what happens when a value is pushed to a full [Buffer].
+type Mode int
+
+const (
+	ModeOverwrite Mode = iota // ModeOverwrite replaces the oldest value when the Buffer is full. This is the default.
+	ModeReject                // ModeReject returns ErrFull when the Buffer is full.
+)
+
+// Buffer is a concurrency-safe, fixed-capacity ring buffer.
+// It's useful for keeping the last N items of something, like debug events or log lines, without unbounded memory growth.
+type Buffer[T any] struct {
+	mux     sync.RWMutex
+	values  []T
+	start   int
+	size    int
+	mode    Mode
+	dropped uint64
+}
+
+// New creates a [Buffer] with the given capacity, using [ModeOverwrite] unless another [Mode] is given.
+// This will panic if capacity is less than 1.
+func New[T any](capacity int, mode ...Mode) *Buffer[T] {
+	if capacity < 1 {
+		panic("ring buffer capacity must be >= 1")
+	}
+	b := &Buffer[T]{
+		values: make([]T, capacity),
+	}
+	if len(mode) > 0 {
+		b.mode = mode[0]
+	}
+	return b
+}
+
+// Len returns the number of values in the [Buffer].
+func (b *Buffer[T]) Len() int {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	return b.size
+}
+
+// Cap returns the capacity of the [Buffer].
+func (b *Buffer[T]) Cap() int {
+	return len(b.values)
+}
+
+// Dropped returns the number of values that were overwritten or rejected because the [Buffer] was full.
+func (b *Buffer[T]) Dropped() uint64 {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	return b.dropped
+}
+
+// Push adds a value to the [Buffer].
+// If the [Buffer] is full, then the oldest value is overwritten in [ModeOverwrite], and [ErrFull] is returned in [ModeReject].
+func (b *Buffer[T]) Push(val T) error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.size == len(b.values) {
+		b.dropped++
+		if b.mode == ModeReject {
+			return ErrFull
+		}
+		b.values[b.start] = val
+		b.start = (b.start + 1) % len(b.values)
+		return nil
+	}
+	b.values[(b.start+b.size)%len(b.values)] = val
+	b.size++
+	return nil
+}
+
+// Pop removes and returns the oldest value in the [Buffer].
+// False will be returned if the [Buffer] is empty.
+func (b *Buffer[T]) Pop() (T, bool) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	var mt T
+	if b.size == 0 {
+		return mt, false
+	}
+	val := b.values[b.start]
+	b.values[b.start] = mt
+	b.start = (b.start + 1) % len(b.values)
+	b.size--
+	return val, true
+}
+
+// Snapshot returns a copy of the values in the [Buffer], from oldest to newest.
+// The [Buffer] is not modified.
+func (b *Buffer[T]) Snapshot() []T {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	vals := make([]T, b.size)
+	for i := range vals {
+		vals[i] = b.values[(b.start+i)%len(b.values)]
+	}
+	return vals
+}
+
+// All returns an iterator over the values in the [Buffer], from oldest to newest, without removing them.
+// The iterator reads from a snapshot taken when iteration starts, so changes made to the [Buffer] during iteration are not observed.
+func (b *Buffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, val := range b.Snapshot() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all values from the [Buffer].
+func (b *Buffer[T]) Clear() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	clear(b.values)
+	b.start = 0
+	b.size = 0
+}
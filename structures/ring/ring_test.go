@@ -0,0 +1,50 @@
+package ring
+
+import (
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"testing"
+)
+
+func TestBuffer_Overwrite(t *testing.T) {
+	b := New[int](3)
+	assert.Equal(t, 3, b.Cap())
+	assert.Equal(t, 0, b.Len())
+	assert.Empty(t, b.Snapshot())
+
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, b.Push(i))
+	}
+	assert.Equal(t, 3, b.Len())
+	assert.Equal(t, uint64(2), b.Dropped())
+	assert.Equal(t, []int{3, 4, 5}, b.Snapshot())
+	assert.Equal(t, []int{3, 4, 5}, slices.Collect(b.All()))
+
+	val, ok := b.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+	assert.NoError(t, b.Push(6))
+	assert.Equal(t, []int{4, 5, 6}, b.Snapshot())
+
+	b.Clear()
+	assert.Equal(t, 0, b.Len())
+	_, ok = b.Pop()
+	assert.False(t, ok)
+}
+
+func TestBuffer_Reject(t *testing.T) {
+	b := New[string](2, ModeReject)
+	assert.NoError(t, b.Push("a"))
+	assert.NoError(t, b.Push("b"))
+	assert.ErrorIs(t, b.Push("c"), ErrFull)
+	assert.Equal(t, uint64(1), b.Dropped())
+	assert.Equal(t, []string{"a", "b"}, b.Snapshot())
+
+	for val := range b.All() {
+		assert.Equal(t, "a", val)
+		break
+	}
+	assert.Panics(t, func() {
+		New[int](0)
+	})
+}
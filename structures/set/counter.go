@@ -0,0 +1,131 @@
+package set
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Counter is a multiset (or bag) that tracks how many times each value has been added.
+// Like [Set], it's a map type and is not safe for concurrent use.
+type Counter[T comparable] map[T]int
+
+// CounterEntry is a value and its count, as returned from [Counter.TopN].
+type CounterEntry[T comparable] struct {
+	Value T
+	Count int
+}
+
+// NewCounter creates a [Counter] with each given value counted once per occurrence.
+func NewCounter[T comparable](vals ...T) Counter[T] {
+	c := Counter[T]{}
+	for _, v := range vals {
+		c[v]++
+	}
+	return c
+}
+
+// Add increments the count of each given value by one.
+func (c Counter[T]) Add(val T, others ...T) Counter[T] {
+	if c == nil {
+		c = Counter[T]{}
+	}
+	c[val]++
+	for _, v := range others {
+		c[v]++
+	}
+	return c
+}
+
+// AddN increments the count of the value by n.
+// If the resulting count is <= 0, then the value is removed.
+func (c Counter[T]) AddN(val T, n int) Counter[T] {
+	if c == nil {
+		c = Counter[T]{}
+	}
+	c[val] += n
+	if c[val] <= 0 {
+		delete(c, val)
+	}
+	return c
+}
+
+// Remove decrements the count of each given value by one, removing values that reach zero.
+func (c Counter[T]) Remove(val T, others ...T) Counter[T] {
+	c = c.AddN(val, -1)
+	for _, v := range others {
+		c.AddN(v, -1)
+	}
+	return c
+}
+
+// Count returns the number of times the value has been counted.
+func (c Counter[T]) Count(val T) int {
+	return c[val]
+}
+
+// Total returns the sum of all counts.
+func (c Counter[T]) Total() int {
+	var total int
+	for _, count := range c {
+		total += count
+	}
+	return total
+}
+
+// TopN returns up to n entries with the highest counts, in descending order of count.
+// Ties are broken using the same ordering as [Sort], so the result is deterministic.
+// If n is <= 0, then all entries are returned.
+func (c Counter[T]) TopN(n int) []CounterEntry[T] {
+	keys := make([]T, 0, len(c))
+	for v := range c {
+		keys = append(keys, v)
+	}
+	Sort(keys)
+	slices.SortStableFunc(keys, func(a, b T) int {
+		return cmp.Compare(c[b], c[a])
+	})
+	if n > 0 && n < len(keys) {
+		keys = keys[:n]
+	}
+	entries := make([]CounterEntry[T], len(keys))
+	for i, v := range keys {
+		entries[i] = CounterEntry[T]{Value: v, Count: c[v]}
+	}
+	return entries
+}
+
+// Merge adds the counts from other into this [Counter].
+func (c Counter[T]) Merge(other Counter[T]) Counter[T] {
+	if c == nil {
+		c = Counter[T]{}
+	}
+	for v, count := range other {
+		c.AddN(v, count)
+	}
+	return c
+}
+
+// All returns an iterator over each value and its count, in no particular order.
+func (c Counter[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for v, count := range c {
+			if !yield(v, count) {
+				return
+			}
+		}
+	}
+}
+
+// Set returns a [Set] of the distinct values in the [Counter].
+func (c Counter[T]) Set() Set[T] {
+	return FromKeys(c)
+}
+
+func (c Counter[T]) Copy() Counter[T] {
+	cp := make(Counter[T], len(c))
+	for v, count := range c {
+		cp[v] = count
+	}
+	return cp
+}
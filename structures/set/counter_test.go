@@ -0,0 +1,38 @@
+package set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	c := NewCounter("a", "b", "a", "c", "a", "b")
+	assert.Equal(t, 3, c.Count("a"))
+	assert.Equal(t, 2, c.Count("b"))
+	assert.Equal(t, 0, c.Count("z"))
+	assert.Equal(t, 6, c.Total())
+
+	c.Remove("c")
+	assert.Equal(t, 0, c.Count("c"))
+	assert.False(t, c.Set().Has("c"), "Values with a zero count should be removed")
+
+	c.Add("d", "d", "d")
+	assert.Equal(t, []CounterEntry[string]{
+		{Value: "a", Count: 3},
+		{Value: "d", Count: 3},
+	}, c.TopN(2))
+	assert.Len(t, c.TopN(0), 3)
+
+	var other Counter[string]
+	other = other.Add("b").AddN("e", 5)
+	merged := c.Copy().Merge(other)
+	assert.Equal(t, 3, merged.Count("b"))
+	assert.Equal(t, 5, merged.Count("e"))
+	assert.Equal(t, 2, c.Count("b"), "Copy should not be affected by Merge")
+
+	var total int
+	for _, count := range merged.All() {
+		total += count
+	}
+	assert.Equal(t, merged.Total(), total)
+}
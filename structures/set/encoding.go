@@ -0,0 +1,230 @@
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Sort sorts the values in place, so sets can be output in a deterministic order.
+// Values with an ordered underlying type (integers, floats, and strings) are sorted naturally, and other types are sorted by their JSON encoding.
+// Each pair of values is compared by its dynamic type, so sets of an interface type like any may mix kinds.
+// Mixed kinds are grouped in the order signed integers, unsigned integers, floats, strings, then all other types.
+func Sort[T comparable](vals []T) {
+	if len(vals) < 2 {
+		return
+	}
+	var encoded map[T][]byte
+	encode := func(val T) []byte {
+		if encoded == nil {
+			encoded = map[T][]byte{}
+		}
+		data, ok := encoded[val]
+		if !ok {
+			// Values that can't be encoded sort first, and will fail later if they're being marshaled.
+			data, _ = json.Marshal(val)
+			encoded[val] = data
+		}
+		return data
+	}
+	slices.SortFunc(vals, func(a, b T) int {
+		va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+		ga, gb := kindGroup(va.Kind()), kindGroup(vb.Kind())
+		if ga != gb {
+			return cmp.Compare(ga, gb)
+		}
+		switch ga {
+		case groupInt:
+			return cmp.Compare(va.Int(), vb.Int())
+		case groupUint:
+			return cmp.Compare(va.Uint(), vb.Uint())
+		case groupFloat:
+			return cmp.Compare(va.Float(), vb.Float())
+		case groupString:
+			return cmp.Compare(va.String(), vb.String())
+		default:
+			return bytes.Compare(encode(a), encode(b))
+		}
+	})
+}
+
+const (
+	groupInt = iota
+	groupUint
+	groupFloat
+	groupString
+	groupOther
+)
+
+// kindGroup groups kinds that can be compared with each other naturally.
+func kindGroup(kind reflect.Kind) int {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return groupInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return groupUint
+	case reflect.Float32, reflect.Float64:
+		return groupFloat
+	case reflect.String:
+		return groupString
+	default:
+		return groupOther
+	}
+}
+
+// SortedSlice returns the values of the [Set] in a deterministic order, see [Sort].
+func (s Set[T]) SortedSlice() []T {
+	vals := s.Slice()
+	Sort(vals)
+	return vals
+}
+
+// MarshalJSON encodes the [Set] as a JSON array, sorted with [Sort].
+// A nil [Set] is encoded as null.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	vals := s.SortedSlice()
+	if vals == nil {
+		vals = []T{}
+	}
+	return json.Marshal(vals)
+}
+
+// UnmarshalJSON decodes a JSON array into the [Set], replacing any existing values.
+// Duplicate values in the array are ignored.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var vals []T
+	if err := json.Unmarshal(data, &vals); err != nil {
+		return err
+	}
+	if vals == nil {
+		*s = nil
+		return nil
+	}
+	*s = New(vals...)
+	return nil
+}
+
+// MarshalText encodes the [Set] as a comma separated list of values, sorted with [Sort].
+// Values that contain a comma or quote are quoted as in CSV.
+// This makes a [Set] usable in text formats like environment variables and flags.
+//
+// Only values that are strings, numbers, booleans, or implement [encoding.TextMarshaler] are supported.
+func (s Set[T]) MarshalText() ([]byte, error) {
+	vals := s.SortedSlice()
+	record := make([]string, len(vals))
+	for i, val := range vals {
+		text, err := marshalTextValue(val)
+		if err != nil {
+			return nil, err
+		}
+		record[i] = text
+	}
+	if len(record) == 0 {
+		return []byte{}, nil
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(record); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\r\n"), nil
+}
+
+// UnmarshalText decodes a comma separated list of values, as produced by [Set.MarshalText], replacing any existing values.
+// Whitespace around unquoted values is trimmed.
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	newSet := Set[T]{}
+	if len(bytes.TrimSpace(text)) == 0 {
+		*s = newSet
+		return nil
+	}
+	r := csv.NewReader(bytes.NewReader(text))
+	r.TrimLeadingSpace = true
+	record, err := r.Read()
+	if err != nil {
+		return err
+	}
+	for _, field := range record {
+		var val T
+		if err := unmarshalTextValue(strings.TrimSpace(field), &val); err != nil {
+			return err
+		}
+		newSet.Add(val)
+	}
+	*s = newSet
+	return nil
+}
+
+func marshalTextValue(val any) (string, error) {
+	if tm, ok := val.(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	default:
+		return "", fmt.Errorf("set value type %T cannot be marshaled as text", val)
+	}
+}
+
+func unmarshalTextValue(text string, target any) error {
+	if tu, ok := target.(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(text))
+	}
+	rv := reflect.ValueOf(target).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(text, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	default:
+		return fmt.Errorf("set value type %s cannot be unmarshaled from text", rv.Type())
+	}
+	return nil
+}
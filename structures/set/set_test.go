@@ -1,6 +1,7 @@
 package set
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"sort"
 	"testing"
@@ -32,3 +33,74 @@ func TestSet_Slice_NilSet(t *testing.T) {
 	assert.Nil(t, set.Copy().Slice())
 	assert.Empty(t, set.Copy().Slice())
 }
+
+func TestSet_JSON(t *testing.T) {
+	data, err := json.Marshal(New(10, 2, 33, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,10,33]`, string(data))
+
+	data, err = json.Marshal(struct {
+		Tags  Set[string] `json:"tags"`
+		Empty Set[string] `json:"empty"`
+		Nil   Set[string] `json:"nil"`
+	}{Tags: New("b", "a"), Empty: New[string]()})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":["a","b"],"empty":[],"nil":null}`, string(data))
+
+	var s Set[int]
+	assert.NoError(t, json.Unmarshal([]byte(`[3, 1, 3]`), &s))
+	assert.Equal(t, New(1, 3), s)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &s))
+	assert.Nil(t, s)
+	assert.Error(t, json.Unmarshal([]byte(`["a"]`), &s))
+}
+
+func TestSet_Text(t *testing.T) {
+	text, err := New("b", "a,c", "d").MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, `"a,c",b,d`, string(text))
+
+	var s Set[string]
+	assert.NoError(t, s.UnmarshalText([]byte(`b, "a,c",  d`)))
+	assert.Equal(t, New("a,c", "b", "d"), s)
+
+	var nums Set[float64]
+	assert.NoError(t, nums.UnmarshalText([]byte("1.5,2")))
+	assert.Equal(t, New(1.5, 2.0), nums)
+	text, err = nums.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5,2", string(text))
+	assert.Error(t, nums.UnmarshalText([]byte("x")))
+
+	assert.NoError(t, s.UnmarshalText(nil))
+	assert.Empty(t, s)
+
+	_, err = New(struct{ A int }{1}).MarshalText()
+	assert.Error(t, err)
+}
+
+func TestSort(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	pts := []point{{2, 1}, {1, 2}}
+	Sort(pts)
+	assert.Equal(t, []point{{1, 2}, {2, 1}}, pts)
+	uints := []uint8{3, 1, 2}
+	Sort(uints)
+	assert.Equal(t, []uint8{1, 2, 3}, uints)
+}
+
+func TestSort_MixedKinds(t *testing.T) {
+	for range 20 {
+		data, err := json.Marshal(New[any](10, "b", 2, "a", 1.5, true))
+		assert.NoError(t, err)
+		assert.Equal(t, `[2,10,1.5,"a","b",true]`, string(data))
+	}
+	c := Counter[any]{}
+	c.Add("a")
+	c.Add(1)
+	c.Add(2)
+	top := c.TopN(0)
+	assert.Equal(t, []any{1, 2, "a"}, []any{top[0].Value, top[1].Value, top[2].Value})
+}
@@ -0,0 +1,125 @@
+package syncx
+
+import "sync/atomic"
+
+// Atomic is a value of any comparable type that may be loaded and stored atomically.
+// The zero value is ready to use, and holds the zero value of T.
+//
+// An Atomic must not be copied after first use.
+type Atomic[T comparable] struct {
+	ptr atomic.Pointer[T]
+}
+
+// NewAtomic creates an [Atomic] holding the initial value.
+func NewAtomic[T comparable](initial T) *Atomic[T] {
+	a := new(Atomic[T])
+	a.Store(initial)
+	return a
+}
+
+// Load returns the current value.
+func (a *Atomic[T]) Load() T {
+	p := a.ptr.Load()
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// Store sets the current value.
+func (a *Atomic[T]) Store(val T) {
+	a.ptr.Store(&val)
+}
+
+// Swap sets the current value and returns the previous value.
+func (a *Atomic[T]) Swap(val T) T {
+	p := a.ptr.Swap(&val)
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// CompareAndSwap sets the value to next only if the current value is equal to old, and returns true if the swap happened.
+func (a *Atomic[T]) CompareAndSwap(old, next T) bool {
+	for {
+		p := a.ptr.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		if cur != old {
+			return false
+		}
+		if a.ptr.CompareAndSwap(p, &next) {
+			return true
+		}
+	}
+}
+
+// Update atomically replaces the current value with the result of fn, and returns the new value.
+// The fn may be called more than once if there is contention with other writers, so it should not have side effects.
+func (a *Atomic[T]) Update(fn func(cur T) T) T {
+	for {
+		p := a.ptr.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		next := fn(cur)
+		if a.ptr.CompareAndSwap(p, &next) {
+			return next
+		}
+	}
+}
+
+// AtomicError holds an error that may be loaded and stored atomically.
+// This is useful for recording the first failure among several goroutines without a mutex.
+// The zero value is ready to use, and holds a nil error.
+//
+// An AtomicError must not be copied after first use.
+type AtomicError struct {
+	ptr atomic.Pointer[error]
+}
+
+// Load returns the current error, which may be nil.
+func (a *AtomicError) Load() error {
+	p := a.ptr.Load()
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// Store sets the current error.
+func (a *AtomicError) Store(err error) {
+	a.ptr.Store(&err)
+}
+
+// Swap sets the current error and returns the previous error.
+func (a *AtomicError) Swap(err error) error {
+	p := a.ptr.Swap(&err)
+	if p == nil {
+		return nil
+	}
+	return *p
+}
+
+// StoreFirst sets the error only if no non-nil error has been stored yet, and returns true if it was set.
+// A nil error is never stored.
+func (a *AtomicError) StoreFirst(err error) bool {
+	if err == nil {
+		return false
+	}
+	for {
+		p := a.ptr.Load()
+		if p != nil && *p != nil {
+			return false
+		}
+		if a.ptr.CompareAndSwap(p, &err) {
+			return true
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package syncx
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestAtomic(t *testing.T) {
+	var a Atomic[string]
+	assert.Equal(t, "", a.Load())
+	assert.True(t, a.CompareAndSwap("", "a"))
+	assert.False(t, a.CompareAndSwap("", "b"))
+	assert.Equal(t, "a", a.Swap("c"))
+	assert.Equal(t, "c", a.Load())
+
+	ptr := NewAtomic[*int](nil)
+	val := 5
+	assert.True(t, ptr.CompareAndSwap(nil, &val))
+	assert.Same(t, &val, ptr.Load())
+}
+
+func TestAtomic_Update(t *testing.T) {
+	var (
+		a  Atomic[int]
+		wg sync.WaitGroup
+	)
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				a.Update(func(cur int) int {
+					return cur + 1
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 5000, a.Load())
+}
+
+func TestAtomicError(t *testing.T) {
+	var (
+		a      AtomicError
+		first  = errors.New("first")
+		second = errors.New("second")
+	)
+	assert.NoError(t, a.Load())
+	assert.False(t, a.StoreFirst(nil))
+	assert.True(t, a.StoreFirst(first))
+	assert.False(t, a.StoreFirst(second))
+	assert.Equal(t, first, a.Load())
+	assert.Equal(t, first, a.Swap(nil))
+	assert.True(t, a.StoreFirst(second))
+	assert.Equal(t, second, a.Load())
+}
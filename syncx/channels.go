@@ -1,5 +1,7 @@
 package syncx
 
+import "context"
+
 // Merge will create a goroutine to merge two or more channels into one unbuffered channel.
 // Any T dispatched to any source channel will be output to the merged channel.
 //
@@ -33,3 +35,177 @@ func Merge[T any](a, b <-chan T, others ...<-chan T) <-chan T {
 	}
 	return newCh
 }
+
+// sendOrDone sends the value to the channel, returning false if the context is done first.
+func sendOrDone[T any](ctx context.Context, ch chan<- T, val T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case ch <- val:
+		return true
+	}
+}
+
+// OrDone will forward values from the input channel to the returned channel until either the input is closed or the context is done.
+// The returned channel is always closed when forwarding stops, so ranging over it will not block forever on cancellation.
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case val, more := <-in:
+				if !more {
+					return
+				}
+				if !sendOrDone(ctx, out, val) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// FanOut will distribute values from the input channel to n unbuffered output channels in round-robin order.
+// A value is not read from the input until the previous value has been received from its output channel, so a slow consumer will hold up the others.
+// All output channels are closed when the input is closed or the context is done.
+//
+// Values may be put back in order with [FanIn], as long as each output is consumed one-for-one.
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		panic("fan out to fewer than 1 channel")
+	}
+	outs := make([]chan T, n)
+	ret := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		ret[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for next := 0; ; next = (next + 1) % n {
+			select {
+			case <-ctx.Done():
+				return
+			case val, more := <-in:
+				if !more {
+					return
+				}
+				if !sendOrDone(ctx, outs[next], val) {
+					return
+				}
+			}
+		}
+	}()
+	return ret
+}
+
+// FanIn will read from the input channels in round-robin order, forwarding each value to the returned channel.
+// Unlike [Merge], this preserves the order of values distributed by [FanOut].
+// A closed input is skipped from then on, and the returned channel is closed once all inputs are closed or the context is done.
+func FanIn[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		open := make([]<-chan T, 0, len(ins))
+		for _, in := range ins {
+			if in != nil {
+				open = append(open, in)
+			}
+		}
+		for next := 0; len(open) > 0; {
+			select {
+			case <-ctx.Done():
+				return
+			case val, more := <-open[next]:
+				if !more {
+					open = append(open[:next], open[next+1:]...)
+					if len(open) > 0 {
+						next %= len(open)
+					}
+					continue
+				}
+				if !sendOrDone(ctx, out, val) {
+					return
+				}
+				next = (next + 1) % len(open)
+			}
+		}
+	}()
+	return out
+}
+
+// Tee will send each value from the input channel to both returned channels.
+// The next value is not read from the input until both channels have received the current one.
+// Both channels are closed when the input is closed or the context is done.
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	out1, out2 := make(chan T), make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for {
+			var (
+				val  T
+				more bool
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case val, more = <-in:
+				if !more {
+					return
+				}
+			}
+			// Use local copies so each channel can be disabled once it has received the value.
+			a, b := out1, out2
+			for a != nil || b != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case a <- val:
+					a = nil
+				case b <- val:
+					b = nil
+				}
+			}
+		}
+	}()
+	return out1, out2
+}
+
+// Bridge will flatten a channel of channels into a single channel, reading each inner channel to completion before moving on to the next.
+// The returned channel is closed when the outer channel is closed or the context is done.
+func Bridge[T any](ctx context.Context, chans <-chan <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			var in <-chan T
+			select {
+			case <-ctx.Done():
+				return
+			case ch, more := <-chans:
+				if !more {
+					return
+				}
+				in = ch
+			}
+			if in == nil {
+				continue
+			}
+			for val := range OrDone(ctx, in) {
+				if !sendOrDone(ctx, out, val) {
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
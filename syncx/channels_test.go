@@ -0,0 +1,116 @@
+package syncx
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testProduce(vals ...int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, val := range vals {
+			ch <- val
+		}
+	}()
+	return ch
+}
+
+func testCollect[T any](t *testing.T, ch <-chan T) []T {
+	var vals []T
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case val, more := <-ch:
+			if !more {
+				return vals
+			}
+			vals = append(vals, val)
+		case <-timeout:
+			t.Fatal("Timed out waiting for channel to close")
+			return nil
+		}
+	}
+}
+
+func TestOrDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Equal(t, []int{1, 2, 3}, testCollect(t, OrDone(ctx, testProduce(1, 2, 3))))
+
+	blocked := make(chan int)
+	out := OrDone(ctx, blocked)
+	cancel()
+	assert.Empty(t, testCollect(t, out), "Output should be closed on cancellation")
+}
+
+func TestFanOut_FanIn(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	outs := FanOut(ctx, testProduce(1, 2, 3, 4, 5, 6, 7), 3)
+	assert.Len(t, outs, 3)
+	doubled := make([]<-chan int, len(outs))
+	for i, out := range outs {
+		ch := make(chan int)
+		doubled[i] = ch
+		go func() {
+			defer close(ch)
+			for val := range out {
+				ch <- val * 2
+			}
+		}()
+	}
+	assert.Equal(t, []int{2, 4, 6, 8, 10, 12, 14}, testCollect(t, FanIn(ctx, doubled...)), "Order should be preserved")
+	assert.Panics(t, func() {
+		FanOut(ctx, testProduce(), 0)
+	})
+}
+
+func TestFanOut_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	outs := FanOut(ctx, make(chan int), 2)
+	cancel()
+	for _, out := range outs {
+		assert.Empty(t, testCollect(t, out))
+	}
+}
+
+func TestFanIn_Closed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	vals := testCollect(t, FanIn(ctx, testProduce(1, 3), nil, testProduce(2, 4, 5, 6)))
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, vals)
+	assert.Empty(t, testCollect(t, FanIn[int](ctx)))
+}
+
+func TestTee(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a, b := Tee(ctx, testProduce(1, 2, 3))
+	var bVals []int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for val := range b {
+			bVals = append(bVals, val)
+		}
+	}()
+	assert.Equal(t, []int{1, 2, 3}, testCollect(t, a))
+	<-done
+	assert.Equal(t, []int{1, 2, 3}, bVals)
+}
+
+func TestBridge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	chans := make(chan (<-chan int))
+	go func() {
+		defer close(chans)
+		chans <- testProduce(1, 2)
+		chans <- nil
+		chans <- testProduce(3)
+	}()
+	assert.Equal(t, []int{1, 2, 3}, testCollect(t, Bridge(ctx, chans)))
+}
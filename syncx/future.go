@@ -185,3 +185,29 @@ func DiscardFutureErr[T any](f FutureErr[T]) {
 		_, _ = f.AwaitErr()
 	}()
 }
+
+// AwaitAllSettled waits for all the given futures to resolve, or for the timeout to elapse.
+// The results are returned in the same order as the futures, and a future that didn't resolve in time will have a zero Result and an Err of [context.DeadlineExceeded].
+// This allows reporting partial success, rather than treating a batch as all-or-nothing.
+//
+// If the timeout is <= 0, then this will wait indefinitely.
+// A new goroutine is created to await each future.
+func AwaitAllSettled[T any](timeout time.Duration, futures ...FutureErr[T]) []ErrChannelResult[T] {
+	results := make([]ErrChannelResult[T], len(futures))
+	var wg sync.WaitGroup
+	wg.Add(len(futures))
+	for i, f := range futures {
+		go func() {
+			defer wg.Done()
+			var result ErrChannelResult[T]
+			if timeout > 0 {
+				result.Result, result.Err = f.AwaitErr(timeout)
+			} else {
+				result.Result, result.Err = f.AwaitErr()
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	return results
+}
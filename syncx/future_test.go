@@ -53,3 +53,26 @@ func TestFuture_Await_Blocking(t *testing.T) {
 	assert.Equal(t, 5, val)
 	assert.NoError(t, err)
 }
+
+func TestAwaitAllSettled(t *testing.T) {
+	fast, slow, failed := NewFutureErr[int](), NewFutureErr[int](), NewFutureErr[int]()
+	fast.ResolveErr(1, nil)
+	failed.ResolveErr(0, assert.AnError)
+	start := time.Now()
+	results := AwaitAllSettled(50*time.Millisecond, fast, slow, failed)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Len(t, results, 3)
+	assert.Equal(t, 1, results[0].Result)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, context.DeadlineExceeded)
+	assert.ErrorIs(t, results[2].Err, assert.AnError)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		slow.ResolveErr(2, nil)
+	}()
+	results = AwaitAllSettled(0, slow)
+	assert.Equal(t, 2, results[0].Result)
+	assert.NoError(t, results[0].Err)
+	assert.Empty(t, AwaitAllSettled[int](time.Second))
+}
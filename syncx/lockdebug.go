@@ -0,0 +1,264 @@
+package syncx
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EnvLockDebug is the environment variable that enables lock debugging when the program starts.
+// It may be set to a threshold duration like "500ms", or to "1" or "true" to use [DefaultLockThreshold].
+// Warnings are logged with [slog.Default].
+const EnvLockDebug = "SYNCX_LOCK_DEBUG"
+
+// DefaultLockThreshold is how long a lock may be held before a warning is reported, if no threshold is given.
+const DefaultLockThreshold = time.Second
+
+// LockWarningKind identifies the problem reported by a [LockWarning].
+type LockWarningKind int
+
+const (
+	LockHeldTooLong    LockWarningKind = iota // LockHeldTooLong is reported when a lock is held longer than the threshold.
+	LockOrderInversion                        // LockOrderInversion is reported when two locks are acquired in the opposite order of an earlier acquisition, which can deadlock.
+	LockRecursive                             // LockRecursive is reported when a goroutine acquires a lock it already holds, which can deadlock.
+)
+
+func (k LockWarningKind) String() string {
+	switch k {
+	case LockHeldTooLong:
+		return "lock held too long"
+	case LockOrderInversion:
+		return "lock order inversion"
+	case LockRecursive:
+		return "recursive lock"
+	default:
+		return "unknown"
+	}
+}
+
+// LockWarning describes a potential deadlock detected while lock debugging is enabled.
+type LockWarning struct {
+	Kind       LockWarningKind
+	Lock       string        // Lock identifies the lock being acquired or held.
+	Other      string        // Other is the lock that was acquired in the opposite order, for LockOrderInversion.
+	Held       time.Duration // Held is how long the lock had been held, for LockHeldTooLong.
+	Stack      string        // Stack is the stack trace where the lock was acquired.
+	OtherStack string        // OtherStack is the stack trace where the opposite order was first seen, for LockOrderInversion.
+}
+
+func (w LockWarning) String() string {
+	var buf strings.Builder
+	_, _ = fmt.Fprintf(&buf, "%s: %s", w.Kind, w.Lock)
+	switch w.Kind {
+	case LockHeldTooLong:
+		_, _ = fmt.Fprintf(&buf, " held for more than %s", w.Held)
+	case LockOrderInversion:
+		_, _ = fmt.Fprintf(&buf, " acquired while holding %s, but was previously held while acquiring it", w.Other)
+	}
+	buf.WriteString("\nacquired at:\n" + w.Stack)
+	if len(w.OtherStack) > 0 {
+		buf.WriteString("\nopposite order at:\n" + w.OtherStack)
+	}
+	return buf.String()
+}
+
+// EnableLockDebug makes [LockFunc], [RLockFunc], and their variants record where locks are acquired, and call the handler with a [LockWarning] when a lock is held longer than the threshold, or is acquired in a way that can deadlock.
+// If the handler is nil, then warnings are logged with [slog.Default].
+//
+// Order inversions are detected between pairs of locks acquired through these helpers by the same goroutine, so the inversion is reported even if the deadlock doesn't happen.
+// This adds significant overhead to every lock, so it's only intended for debugging, like finding a handler that blocks the [github.com/saylorsolutions/x/patterns/eventbus.EventBus].
+// It can also be enabled with the [EnvLockDebug] environment variable.
+func EnableLockDebug(threshold time.Duration, handler func(LockWarning)) {
+	if threshold <= 0 {
+		threshold = DefaultLockThreshold
+	}
+	if handler == nil {
+		handler = func(w LockWarning) {
+			slog.Default().Warn(w.String())
+		}
+	}
+	lockDebug.Store(&lockDebugger{
+		threshold: threshold,
+		handler:   handler,
+		held:      map[uint64][]any{},
+		order:     map[lockPair]string{},
+	})
+}
+
+// DisableLockDebug stops recording lock acquisitions.
+// Locks acquired while debugging was enabled are still tracked until released.
+func DisableLockDebug() {
+	lockDebug.Store(nil)
+}
+
+var lockDebug atomic.Pointer[lockDebugger]
+
+func init() {
+	val := strings.TrimSpace(os.Getenv(EnvLockDebug))
+	switch strings.ToLower(val) {
+	case "", "0", "false":
+		return
+	case "1", "true":
+		EnableLockDebug(DefaultLockThreshold, nil)
+	default:
+		threshold, err := time.ParseDuration(val)
+		if err != nil {
+			slog.Default().Warn("Invalid lock debug threshold, using the default", slog.String("value", val), slog.Duration("threshold", DefaultLockThreshold))
+		}
+		EnableLockDebug(threshold, nil)
+	}
+}
+
+// lockPair is an edge in the lock order graph, recording that second was acquired while holding first.
+type lockPair struct {
+	first, second any
+}
+
+type lockDebugger struct {
+	threshold time.Duration
+	handler   func(LockWarning)
+
+	mux   sync.Mutex
+	held  map[uint64][]any    // held maps a goroutine ID to the locks it holds, in acquisition order.
+	order map[lockPair]string // order maps an observed acquisition order to the stack where it was first seen.
+}
+
+// track acquires the lock, recording it for the current goroutine, and returns a function that releases it.
+func (d *lockDebugger) track(mux any, acquire, release func()) func() {
+	var (
+		name  = fmt.Sprintf("%T(%p)", mux, mux)
+		stack = callerStack()
+		gid   = goroutineID()
+		key   = mux
+	)
+	if !reflect.TypeOf(mux).Comparable() {
+		// Can't be used as a map key, so only the hold time is checked.
+		key = nil
+	}
+	if key != nil {
+		for _, w := range d.checkOrder(gid, key, name, stack) {
+			d.handler(w)
+		}
+	}
+	acquire()
+	start := time.Now()
+	if key != nil {
+		d.mux.Lock()
+		d.held[gid] = append(d.held[gid], key)
+		d.mux.Unlock()
+	}
+	timer := time.AfterFunc(d.threshold, func() {
+		d.handler(LockWarning{
+			Kind:  LockHeldTooLong,
+			Lock:  name,
+			Held:  time.Since(start),
+			Stack: stack,
+		})
+	})
+	return func() {
+		timer.Stop()
+		if key != nil {
+			d.mux.Lock()
+			d.release(gid, key)
+			d.mux.Unlock()
+		}
+		release()
+	}
+}
+
+// checkOrder records the order that the lock is acquired relative to locks already held by the goroutine, and returns warnings for any potential deadlocks.
+func (d *lockDebugger) checkOrder(gid uint64, key any, name, stack string) []LockWarning {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	var warnings []LockWarning
+	for _, held := range d.held[gid] {
+		if held == key {
+			warnings = append(warnings, LockWarning{
+				Kind:  LockRecursive,
+				Lock:  name,
+				Stack: stack,
+			})
+			continue
+		}
+		if otherStack, ok := d.order[lockPair{first: key, second: held}]; ok {
+			warnings = append(warnings, LockWarning{
+				Kind:       LockOrderInversion,
+				Lock:       name,
+				Other:      fmt.Sprintf("%T(%p)", held, held),
+				Stack:      stack,
+				OtherStack: otherStack,
+			})
+		}
+		pair := lockPair{first: held, second: key}
+		if _, ok := d.order[pair]; !ok {
+			d.order[pair] = stack
+		}
+	}
+	return warnings
+}
+
+// release removes the most recent acquisition of the lock by the goroutine.
+// This must be called with the lock held.
+func (d *lockDebugger) release(gid uint64, key any) {
+	held := d.held[gid]
+	for i := len(held) - 1; i >= 0; i-- {
+		if held[i] == key {
+			held = append(held[:i], held[i+1:]...)
+			break
+		}
+	}
+	if len(held) == 0 {
+		delete(d.held, gid)
+		return
+	}
+	d.held[gid] = held
+}
+
+// lockFrames are the function name prefixes of stack frames within the lock helpers, which are omitted from warnings.
+var lockFrames = []string{
+	"github.com/saylorsolutions/x/syncx.LockFunc",
+	"github.com/saylorsolutions/x/syncx.RLockFunc",
+	"github.com/saylorsolutions/x/syncx.(*lockDebugger)",
+	"github.com/saylorsolutions/x/syncx.callerStack",
+}
+
+// callerStack returns the stack of the goroutine, skipping frames within the lock helpers.
+func callerStack() string {
+	buf := make([]byte, 8<<10)
+	buf = buf[:runtime.Stack(buf, false)]
+	var (
+		out   []string
+		lines = strings.Split(strings.TrimSpace(string(buf)), "\n")
+	)
+	// The first line is the goroutine header, and each frame is a function line followed by a file line.
+	for i := 1; i+1 < len(lines); i += 2 {
+		if slices.ContainsFunc(lockFrames, func(prefix string) bool {
+			return strings.HasPrefix(lines[i], prefix)
+		}) {
+			continue
+		}
+		out = append(out, lines[i], lines[i+1])
+	}
+	return strings.Join(out, "\n")
+}
+
+// goroutineID parses the ID of the current goroutine from its stack header, like "goroutine 18 [running]:".
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
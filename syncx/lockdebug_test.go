@@ -0,0 +1,86 @@
+package syncx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func enableTestLockDebug(t *testing.T, threshold time.Duration) func() []LockWarning {
+	var (
+		mux      sync.Mutex
+		warnings []LockWarning
+	)
+	EnableLockDebug(threshold, func(w LockWarning) {
+		mux.Lock()
+		defer mux.Unlock()
+		warnings = append(warnings, w)
+	})
+	t.Cleanup(DisableLockDebug)
+	return func() []LockWarning {
+		mux.Lock()
+		defer mux.Unlock()
+		return append([]LockWarning(nil), warnings...)
+	}
+}
+
+func TestLockDebug_HeldTooLong(t *testing.T) {
+	warnings := enableTestLockDebug(t, 10*time.Millisecond)
+	var mux sync.Mutex
+	LockFunc(&mux, func() {
+		time.Sleep(50 * time.Millisecond)
+	})
+	result := warnings()
+	require.Len(t, result, 1)
+	assert.Equal(t, LockHeldTooLong, result[0].Kind)
+	assert.Contains(t, result[0].Stack, "TestLockDebug_HeldTooLong", "The stack should show where the lock was acquired")
+	assert.NotContains(t, result[0].Stack, "syncx.LockFunc", "Lock helper frames should be omitted")
+
+	LockFunc(&mux, func() {})
+	assert.Len(t, warnings(), 1, "Locks released within the threshold should not be reported")
+}
+
+func TestLockDebug_OrderInversion(t *testing.T) {
+	warnings := enableTestLockDebug(t, time.Minute)
+	var a, b sync.Mutex
+	LockFunc(&a, func() {
+		LockFunc(&b, func() {})
+	})
+	assert.Empty(t, warnings())
+	LockFunc(&b, func() {
+		LockFunc(&a, func() {})
+	})
+	result := warnings()
+	require.Len(t, result, 1)
+	assert.Equal(t, LockOrderInversion, result[0].Kind)
+	assert.NotEmpty(t, result[0].OtherStack)
+	assert.Contains(t, result[0].String(), "lock order inversion")
+}
+
+func TestLockDebug_Recursive(t *testing.T) {
+	warnings := enableTestLockDebug(t, time.Minute)
+	var mux sync.RWMutex
+	RLockFunc(&mux, func() {
+		_ = RLockFuncT(&mux, func() int {
+			return 0
+		})
+	})
+	result := warnings()
+	require.Len(t, result, 1)
+	assert.Equal(t, LockRecursive, result[0].Kind)
+}
+
+func TestLockDebug_Disabled(t *testing.T) {
+	warnings := enableTestLockDebug(t, time.Minute)
+	DisableLockDebug()
+	var a, b sync.Mutex
+	LockFunc(&a, func() {
+		LockFunc(&b, func() {})
+	})
+	LockFunc(&b, func() {
+		LockFunc(&a, func() {})
+	})
+	assert.Empty(t, warnings())
+}
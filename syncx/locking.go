@@ -2,19 +2,34 @@ package syncx
 
 import "sync"
 
+// LockFunc calls fn with the lock held.
+// Lock debugging can be enabled for this and the other lock helpers with [EnableLockDebug].
 func LockFunc(mux sync.Locker, fn func()) {
+	if d := lockDebug.Load(); d != nil {
+		defer d.track(mux, mux.Lock, mux.Unlock)()
+		fn()
+		return
+	}
 	mux.Lock()
 	defer mux.Unlock()
 	fn()
 }
 
 func LockFuncT[T any](mux sync.Locker, fn func() T) T {
+	if d := lockDebug.Load(); d != nil {
+		defer d.track(mux, mux.Lock, mux.Unlock)()
+		return fn()
+	}
 	mux.Lock()
 	defer mux.Unlock()
 	return fn()
 }
 
 func LockFuncTErr[T any](mux sync.Locker, fn func() (T, error)) (T, error) {
+	if d := lockDebug.Load(); d != nil {
+		defer d.track(mux, mux.Lock, mux.Unlock)()
+		return fn()
+	}
 	mux.Lock()
 	defer mux.Unlock()
 	return fn()
@@ -26,18 +41,31 @@ type RLocker interface {
 }
 
 func RLockFunc(mux RLocker, fn func()) {
+	if d := lockDebug.Load(); d != nil {
+		defer d.track(mux, mux.RLock, mux.RUnlock)()
+		fn()
+		return
+	}
 	mux.RLock()
 	defer mux.RUnlock()
 	fn()
 }
 
 func RLockFuncT[T any](mux RLocker, fn func() T) T {
+	if d := lockDebug.Load(); d != nil {
+		defer d.track(mux, mux.RLock, mux.RUnlock)()
+		return fn()
+	}
 	mux.RLock()
 	defer mux.RUnlock()
 	return fn()
 }
 
 func RLockFuncTErr[T any](mux RLocker, fn func() (T, error)) (T, error) {
+	if d := lockDebug.Load(); d != nil {
+		defer d.track(mux, mux.RLock, mux.RUnlock)()
+		return fn()
+	}
 	mux.RLock()
 	defer mux.RUnlock()
 	return fn()
@@ -0,0 +1,113 @@
+package syncx
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type generation[T any] struct {
+	val T
+	gen uint64
+}
+
+// Swappable holds a value that may be replaced atomically, along with a generation number that increases with each replacement.
+// This is useful for hot-swapping configuration, since readers can cheaply check if the value has changed since they last looked without taking a lock.
+// The zero value is ready to use, and holds the zero value of T at generation 0.
+//
+// A Swappable must not be copied after first use.
+type Swappable[T any] struct {
+	ptr  atomic.Pointer[generation[T]]
+	mux  sync.Mutex
+	subs map[chan uint64]struct{}
+}
+
+// NewSwappable creates a [Swappable] holding the initial value at generation 0.
+func NewSwappable[T any](initial T) *Swappable[T] {
+	s := new(Swappable[T])
+	s.ptr.Store(&generation[T]{val: initial})
+	return s
+}
+
+// Load returns the current value and its generation.
+func (s *Swappable[T]) Load() (T, uint64) {
+	p := s.ptr.Load()
+	if p == nil {
+		var zero T
+		return zero, 0
+	}
+	return p.val, p.gen
+}
+
+// Generation returns the current generation.
+func (s *Swappable[T]) Generation() uint64 {
+	p := s.ptr.Load()
+	if p == nil {
+		return 0
+	}
+	return p.gen
+}
+
+// Changed returns true if the generation has changed since the given generation.
+func (s *Swappable[T]) Changed(since uint64) bool {
+	return s.Generation() != since
+}
+
+// Swap replaces the current value, notifies subscribers, and returns the new generation.
+func (s *Swappable[T]) Swap(val T) uint64 {
+	var next *generation[T]
+	for {
+		p := s.ptr.Load()
+		next = &generation[T]{val: val}
+		if p != nil {
+			next.gen = p.gen + 1
+		} else {
+			next.gen = 1
+		}
+		if s.ptr.CompareAndSwap(p, next) {
+			break
+		}
+	}
+	s.notify()
+	return next.gen
+}
+
+// notify sends the current generation to subscribers.
+// The generation is read with the lock held, so concurrent swaps can't deliver an older generation after a newer one.
+func (s *Swappable[T]) notify() {
+	LockFunc(&s.mux, func() {
+		gen := s.Generation()
+		for ch := range s.subs {
+			// Replace a pending notification, so subscribers only see the latest generation.
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- gen:
+			default:
+			}
+		}
+	})
+}
+
+// Subscribe returns a channel that receives the new generation after each [Swappable.Swap].
+// Notifications never block the writer. If the subscriber hasn't received a pending notification, then it's replaced with the latest generation.
+// The returned cancel function stops notifications and closes the channel, and is safe to call multiple times.
+func (s *Swappable[T]) Subscribe() (<-chan uint64, func()) {
+	ch := make(chan uint64, 1)
+	LockFunc(&s.mux, func() {
+		if s.subs == nil {
+			s.subs = map[chan uint64]struct{}{}
+		}
+		s.subs[ch] = struct{}{}
+	})
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			LockFunc(&s.mux, func() {
+				delete(s.subs, ch)
+				close(ch)
+			})
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package syncx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestSwappable(t *testing.T) {
+	var zero Swappable[string]
+	val, gen := zero.Load()
+	assert.Equal(t, "", val)
+	assert.Equal(t, uint64(0), gen)
+	assert.Equal(t, uint64(1), zero.Swap("a"))
+
+	s := NewSwappable("initial")
+	val, gen = s.Load()
+	assert.Equal(t, "initial", val)
+	assert.False(t, s.Changed(gen))
+
+	ch, cancel := s.Subscribe()
+	assert.Equal(t, uint64(1), s.Swap("first"))
+	assert.Equal(t, uint64(2), s.Swap("second"))
+	assert.True(t, s.Changed(gen))
+	assert.Equal(t, uint64(2), <-ch, "Pending notifications should be replaced by the latest generation")
+
+	val, gen = s.Load()
+	assert.Equal(t, "second", val)
+	assert.Equal(t, uint64(2), gen)
+
+	cancel()
+	cancel()
+	_, more := <-ch
+	assert.False(t, more)
+	s.Swap("third")
+}
+
+func TestSwappable_Concurrent(t *testing.T) {
+	var (
+		s  Swappable[int]
+		wg sync.WaitGroup
+	)
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Swap(i)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(50), s.Generation())
+}
+
+func TestSwappable_SubscribeLatest(t *testing.T) {
+	s := NewSwappable(0)
+	ch, cancel := s.Subscribe()
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Swap(i)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, s.Generation(), <-ch, "The pending notification should be the latest generation")
+}
@@ -0,0 +1,5 @@
+// Package testx provides general-purpose helpers for tests.
+//
+// Most helpers accept a [testing.TB] and register any necessary cleanup with it, so they can be used in tests and benchmarks alike.
+// [Setenv] and [Unsetenv] require a [*testing.T], because they use [testing.T.Setenv], which isn't available on [testing.TB].
+package testx
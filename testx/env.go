@@ -0,0 +1,37 @@
+package testx
+
+import (
+	"os"
+	"testing"
+)
+
+// Setenv sets all the given environment variables for the duration of the test.
+// A nil value unsets the variable instead.
+// Original values are restored when the test completes.
+//
+// Like [testing.T.Setenv], this can't be used in parallel tests.
+func Setenv(t *testing.T, vars map[string]*string) {
+	t.Helper()
+	for key, val := range vars {
+		if val == nil {
+			Unsetenv(t, key)
+			continue
+		}
+		t.Setenv(key, *val)
+	}
+}
+
+// Unsetenv unsets an environment variable for the duration of the test, restoring it when the test completes.
+func Unsetenv(t *testing.T, key string) {
+	t.Helper()
+	// Setenv registers the cleanup to restore the original value and guards against parallel use.
+	t.Setenv(key, "")
+	if err := os.Unsetenv(key); err != nil {
+		t.Fatalf("Failed to unset environment variable '%s': %v", key, err)
+	}
+}
+
+// Ptr returns a pointer to the given value, which is convenient for constructing values for [Setenv].
+func Ptr[T any](val T) *T {
+	return &val
+}
@@ -0,0 +1,38 @@
+package testx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TempFile creates a file with the given name and content in a temporary directory that is removed when the test completes.
+// The name may include subdirectories, which will be created.
+// The full path to the file is returned.
+func TempFile(t testing.TB, name string, content []byte) string {
+	t.Helper()
+	return writeFile(t, t.TempDir(), name, content)
+}
+
+// TempFiles creates a temporary directory populated with the given files, keyed by their slash-separated relative path.
+// The directory is removed when the test completes, and its path is returned.
+func TempFiles(t testing.TB, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		writeFile(t, dir, name, []byte(content))
+	}
+	return dir
+}
+
+func writeFile(t testing.TB, dir, name string, content []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatalf("Failed to create directory for '%s': %v", name, err)
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatalf("Failed to write file '%s': %v", name, err)
+	}
+	return path
+}
@@ -0,0 +1,39 @@
+package testx
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "Update golden files used by testx.Golden instead of comparing against them")
+
+// GoldenDir is the directory, relative to the package under test, where golden files are stored.
+var GoldenDir = "testdata"
+
+// Golden compares actual to the content of the golden file testdata/<name>.golden, and fails the test if they differ.
+//
+// Golden files are created or updated instead of compared when the test is run with the -update-golden flag.
+//
+//	go test ./... -update-golden
+func Golden(t testing.TB, name string, actual []byte) {
+	t.Helper()
+	path := filepath.Join(GoldenDir, filepath.FromSlash(name)+".golden")
+	if *updateGolden {
+		writeFile(t, GoldenDir, filepath.FromSlash(name)+".golden", actual)
+		return
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("Golden file '%s' does not exist, run with -update-golden to create it", path)
+		}
+		t.Fatalf("Failed to read golden file '%s': %v", path, err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("Output does not match golden file '%s', run with -update-golden to update it\nexpected:\n%s\nactual:\n%s", path, expected, actual)
+	}
+}
@@ -0,0 +1,21 @@
+package testx
+
+import (
+	"net"
+	"testing"
+)
+
+// FreePort returns a TCP port on the loopback interface that was available at the time of the call.
+// There's an inherent race between this call and the port being used, but it's usually good enough for tests.
+// Prefer listening on port 0 directly where the code under test allows it.
+func FreePort(t testing.TB) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	defer func() {
+		_ = l.Close()
+	}()
+	return l.Addr().(*net.TCPAddr).Port
+}
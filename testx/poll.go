@@ -0,0 +1,46 @@
+package testx
+
+import (
+	"testing"
+	"time"
+)
+
+// DefaultPollInterval is how often [Eventually] checks its condition.
+var DefaultPollInterval = 10 * time.Millisecond
+
+// Eventually polls cond until it returns true, failing the test if that doesn't happen within the timeout.
+// The condition is always checked at least once.
+func Eventually(t testing.TB, cond func() bool, timeout time.Duration, msgAndArgs ...any) {
+	t.Helper()
+	if !poll(cond, timeout) {
+		t.Fatalf("Condition was not met within %s%s", timeout, formatMessage(msgAndArgs))
+	}
+}
+
+// Never polls cond until the timeout, failing the test if it ever returns true.
+func Never(t testing.TB, cond func() bool, timeout time.Duration, msgAndArgs ...any) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		if cond() {
+			t.Fatalf("Condition was unexpectedly met%s", formatMessage(msgAndArgs))
+		}
+		if time.Now().After(deadline) {
+			return
+		}
+		time.Sleep(DefaultPollInterval)
+	}
+}
+
+func poll(cond func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if cond() {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(DefaultPollInterval)
+	}
+}
@@ -0,0 +1,99 @@
+package testx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTempFiles(t *testing.T) {
+	path := TempFile(t, "nested/file.txt", []byte("content"))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	dir := TempFiles(t, map[string]string{
+		"a.txt":     "a",
+		"sub/b.txt": "b",
+	})
+	data, err = os.ReadFile(filepath.Join(dir, "sub", "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(data))
+}
+
+func TestGolden(t *testing.T) {
+	orig := GoldenDir
+	GoldenDir = t.TempDir()
+	defer func() {
+		GoldenDir = orig
+		*updateGolden = false
+	}()
+
+	*updateGolden = true
+	Golden(t, "output", []byte("expected output"))
+	_, err := os.Stat(filepath.Join(GoldenDir, "output.golden"))
+	require.NoError(t, err)
+
+	*updateGolden = false
+	Golden(t, "output", []byte("expected output"))
+}
+
+func TestEventually(t *testing.T) {
+	var count atomic.Int32
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(5 * time.Millisecond)
+			count.Add(1)
+		}
+	}()
+	Eventually(t, func() bool {
+		return count.Load() == 3
+	}, time.Second)
+	assert.True(t, poll(func() bool { return true }, 0), "Condition should be checked at least once")
+	assert.False(t, poll(func() bool { return false }, 20*time.Millisecond))
+
+	Never(t, func() bool {
+		return count.Load() > 3
+	}, 30*time.Millisecond)
+}
+
+func TestFreePort(t *testing.T) {
+	port := FreePort(t)
+	assert.Greater(t, port, 0)
+	l, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+	require.NoError(t, err)
+	_ = l.Close()
+}
+
+func TestSetenv(t *testing.T) {
+	require.NoError(t, os.Setenv("TESTX_REMOVED", "original"))
+	defer func() {
+		_ = os.Unsetenv("TESTX_REMOVED")
+	}()
+
+	t.Run("Scoped", func(t *testing.T) {
+		Setenv(t, map[string]*string{
+			"TESTX_ADDED":   Ptr("added"),
+			"TESTX_REMOVED": nil,
+		})
+		assert.Equal(t, "added", os.Getenv("TESTX_ADDED"))
+		_, ok := os.LookupEnv("TESTX_REMOVED")
+		assert.False(t, ok)
+	})
+
+	_, ok := os.LookupEnv("TESTX_ADDED")
+	assert.False(t, ok)
+	assert.Equal(t, "original", os.Getenv("TESTX_REMOVED"))
+}
+
+func TestFormatMessage(t *testing.T) {
+	assert.Equal(t, "", formatMessage(nil))
+	assert.Equal(t, ": value 5", formatMessage([]any{"value %d", 5}))
+	assert.Equal(t, ": 5", formatMessage([]any{5}))
+}
@@ -0,0 +1,15 @@
+package testx
+
+import (
+	"fmt"
+)
+
+func formatMessage(msgAndArgs []any) string {
+	if len(msgAndArgs) == 0 {
+		return ""
+	}
+	if format, ok := msgAndArgs[0].(string); ok {
+		return ": " + fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+	return ": " + fmt.Sprint(msgAndArgs...)
+}
@@ -0,0 +1,110 @@
+package tmplx
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// FuncMap returns a set of functions that are useful in both [text/template] and [html/template].
+// The returned map is a new instance, so it may be modified freely.
+//
+// Functions that operate on a string take it as the last argument, so they work naturally in pipelines.
+// For example, {{ .Name | trimPrefix "Mr. " | upper }}.
+//
+//   - lower, upper, trim: Change case or trim whitespace.
+//   - trimPrefix, trimSuffix: Remove the given prefix/suffix.
+//   - replace: Replace all instances of old with new: replace "old" "new" .Str
+//   - contains, hasPrefix, hasSuffix: Test for a substring, prefix, or suffix.
+//   - split, join: Split a string or join a slice with a separator.
+//   - repeat: Repeat a string N times.
+//   - default: Use a fallback value if the given value is empty: {{ .Title | default "Untitled" }}
+//   - env, envOr: Look up an environment variable, optionally with a fallback.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"trim":  strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string {
+			return strings.TrimPrefix(s, prefix)
+		},
+		"trimSuffix": func(suffix, s string) string {
+			return strings.TrimSuffix(s, suffix)
+		},
+		"replace": func(old, new, s string) string {
+			return strings.ReplaceAll(s, old, new)
+		},
+		"contains": func(substr, s string) bool {
+			return strings.Contains(s, substr)
+		},
+		"hasPrefix": func(prefix, s string) bool {
+			return strings.HasPrefix(s, prefix)
+		},
+		"hasSuffix": func(suffix, s string) bool {
+			return strings.HasSuffix(s, suffix)
+		},
+		"split": func(sep, s string) []string {
+			return strings.Split(s, sep)
+		},
+		"join": join,
+		"repeat": func(count int, s string) string {
+			return strings.Repeat(s, count)
+		},
+		"default": defaultValue,
+		"env":     os.Getenv,
+		"envOr": func(key, fallback string) string {
+			if val, ok := os.LookupEnv(key); ok {
+				return val
+			}
+			return fallback
+		},
+	}
+}
+
+func join(sep string, elems any) (string, error) {
+	if strs, ok := elems.([]string); ok {
+		return strings.Join(strs, sep), nil
+	}
+	val := reflect.ValueOf(elems)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return "", fmt.Errorf("join: expected a slice, got %T", elems)
+	}
+	strs := make([]string, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		strs[i] = fmt.Sprint(val.Index(i).Interface())
+	}
+	return strings.Join(strs, sep), nil
+}
+
+func defaultValue(fallback, val any) any {
+	if val == nil {
+		return fallback
+	}
+	rv := reflect.ValueOf(val)
+	if rv.IsZero() {
+		return fallback
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		if rv.Len() == 0 {
+			return fallback
+		}
+	default:
+	}
+	return val
+}
+
+// Execute is a shorthand for parsing and executing a [text/template] with [FuncMap] available.
+func Execute(text string, data any) (string, error) {
+	tmpl, err := template.New("").Funcs(FuncMap()).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
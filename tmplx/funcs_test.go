@@ -0,0 +1,42 @@
+package tmplx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	t.Setenv("TMPLX_TEST_VAR", "from env")
+	tests := map[string]struct {
+		text     string
+		data     any
+		expected string
+	}{
+		"Pipeline":   {`{{ . | trimPrefix "Mr. " | upper }}`, "Mr. Smith", "SMITH"},
+		"Replace":    {`{{ replace "a" "o" . }}`, "banana", "bonono"},
+		"Contains":   {`{{ if contains "an" . }}yes{{ end }}`, "banana", "yes"},
+		"Split":      {`{{ range split "," . }}[{{ . }}]{{ end }}`, "a,b", "[a][b]"},
+		"Join":       {`{{ join "-" . }}`, []int{1, 2, 3}, "1-2-3"},
+		"Repeat":     {`{{ repeat 3 . }}`, "ab", "ababab"},
+		"Default":    {`{{ . | default "Untitled" }}`, "", "Untitled"},
+		"No default": {`{{ . | default "Untitled" }}`, "Title", "Title"},
+		"Env":        {`{{ env "TMPLX_TEST_VAR" }}`, nil, "from env"},
+		"EnvOr":      {`{{ envOr "TMPLX_TEST_UNSET_VAR" "fallback" }}`, nil, "fallback"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := Execute(tc.text, tc.data)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestExecute_Errors(t *testing.T) {
+	_, err := Execute(`{{ .Missing`, nil)
+	assert.Error(t, err)
+
+	_, err = Execute(`{{ join "," . }}`, 5)
+	assert.Error(t, err)
+}
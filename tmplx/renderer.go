@@ -0,0 +1,303 @@
+package tmplx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrPageNotFound = errors.New("page template not found")
+)
+
+type rendererConf struct {
+	layoutDir  string
+	partialDir string
+	pageDir    string
+	extensions []string
+	funcs      template.FuncMap
+	reload     bool
+	logger     *slog.Logger
+}
+
+type RendererOption func(conf *rendererConf) error
+
+// OptLayoutDir sets the directory in the [fs.FS] where layout templates are found.
+// Defaults to "layouts".
+func OptLayoutDir(dir string) RendererOption {
+	return func(conf *rendererConf) error {
+		conf.layoutDir = path.Clean(dir)
+		return nil
+	}
+}
+
+// OptPartialDir sets the directory in the [fs.FS] where partial templates are found.
+// Defaults to "partials".
+func OptPartialDir(dir string) RendererOption {
+	return func(conf *rendererConf) error {
+		conf.partialDir = path.Clean(dir)
+		return nil
+	}
+}
+
+// OptPageDir sets the directory in the [fs.FS] where page templates are found.
+// Defaults to "pages".
+func OptPageDir(dir string) RendererOption {
+	return func(conf *rendererConf) error {
+		conf.pageDir = path.Clean(dir)
+		return nil
+	}
+}
+
+// OptExtensions sets the file extensions that will be treated as templates.
+// Defaults to ".html" and ".tmpl".
+func OptExtensions(exts ...string) RendererOption {
+	return func(conf *rendererConf) error {
+		if len(exts) == 0 {
+			return errors.New("no template extensions specified")
+		}
+		conf.extensions = exts
+		return nil
+	}
+}
+
+// OptFuncs adds functions to the function map available to templates, in addition to [FuncMap].
+func OptFuncs(funcs template.FuncMap) RendererOption {
+	return func(conf *rendererConf) error {
+		for name, fn := range funcs {
+			conf.funcs[name] = fn
+		}
+		return nil
+	}
+}
+
+// OptReload makes the [Renderer] re-parse templates on every render.
+// This is intended for development, where the [fs.FS] is something like [os.DirFS] and templates are being edited.
+func OptReload(reload bool) RendererOption {
+	return func(conf *rendererConf) error {
+		conf.reload = reload
+		return nil
+	}
+}
+
+// OptLogger sets the [slog.Logger] used to log errors from [Renderer.Handler].
+// Defaults to [slog.Default].
+func OptLogger(logger *slog.Logger) RendererOption {
+	return func(conf *rendererConf) error {
+		if logger == nil {
+			return errors.New("nil logger")
+		}
+		conf.logger = logger
+		return nil
+	}
+}
+
+// Renderer renders a tree of [html/template] pages from an [fs.FS], like an [embed.FS].
+//
+// Every page is parsed together with all layouts and partials, so a page may reference them by name.
+// Layouts and partials are named by their path relative to their directory, without the extension.
+// Pages are named the same way relative to the page directory.
+//
+// A typical page defines the blocks used by a layout and then invokes it:
+//
+//	{{ define "content" }}<p>Hello, {{ .Name }}</p>{{ end }}
+//	{{ template "base" . }}
+type Renderer struct {
+	fsys  fs.FS
+	conf  rendererConf
+	pages map[string]*template.Template
+}
+
+// NewRenderer creates a [Renderer] and parses all templates, unless [OptReload] is enabled.
+func NewRenderer(fsys fs.FS, opts ...RendererOption) (*Renderer, error) {
+	if fsys == nil {
+		return nil, errors.New("nil file system")
+	}
+	conf := rendererConf{
+		layoutDir:  "layouts",
+		partialDir: "partials",
+		pageDir:    "pages",
+		extensions: []string{".html", ".tmpl"},
+		funcs:      template.FuncMap(FuncMap()),
+		logger:     slog.Default(),
+	}
+	for _, opt := range opts {
+		if err := opt(&conf); err != nil {
+			return nil, err
+		}
+	}
+	r := &Renderer{
+		fsys: fsys,
+		conf: conf,
+	}
+	if !conf.reload {
+		pages, err := r.parse()
+		if err != nil {
+			return nil, err
+		}
+		r.pages = pages
+	}
+	return r, nil
+}
+
+func (r *Renderer) isTemplate(name string) bool {
+	for _, ext := range r.conf.extensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// readDir reads all template files in the directory recursively, keyed by their template name.
+// A missing directory is not an error, since layouts and partials are optional.
+func (r *Renderer) readDir(dir string) (map[string]string, error) {
+	files := map[string]string{}
+	err := fs.WalkDir(r.fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && p == dir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || !r.isTemplate(p) {
+			return nil
+		}
+		data, err := fs.ReadFile(r.fsys, p)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(p, dir), "/")
+		name = strings.TrimSuffix(name, path.Ext(name))
+		files[name] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (r *Renderer) parse() (map[string]*template.Template, error) {
+	layouts, err := r.readDir(r.conf.layoutDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layouts: %w", err)
+	}
+	partials, err := r.readDir(r.conf.partialDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partials: %w", err)
+	}
+	pageFiles, err := r.readDir(r.conf.pageDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pages: %w", err)
+	}
+	pages := make(map[string]*template.Template, len(pageFiles))
+	for name, content := range pageFiles {
+		tmpl := template.New(name).Funcs(r.conf.funcs)
+		for _, shared := range []map[string]string{layouts, partials} {
+			for sharedName, sharedContent := range shared {
+				if _, err := tmpl.New(sharedName).Parse(sharedContent); err != nil {
+					return nil, fmt.Errorf("failed to parse template '%s' for page '%s': %w", sharedName, name, err)
+				}
+			}
+		}
+		if _, err := tmpl.Parse(content); err != nil {
+			return nil, fmt.Errorf("failed to parse page '%s': %w", name, err)
+		}
+		pages[name] = tmpl
+	}
+	return pages, nil
+}
+
+func (r *Renderer) page(name string) (*template.Template, error) {
+	pages := r.pages
+	if r.conf.reload {
+		var err error
+		pages, err = r.parse()
+		if err != nil {
+			return nil, err
+		}
+	}
+	tmpl, ok := pages[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPageNotFound, name)
+	}
+	return tmpl, nil
+}
+
+// Pages returns the names of all parsed pages.
+func (r *Renderer) Pages() ([]string, error) {
+	pages := r.pages
+	if r.conf.reload {
+		var err error
+		pages, err = r.parse()
+		if err != nil {
+			return nil, err
+		}
+	}
+	names := make([]string, 0, len(pages))
+	for name := range pages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Render executes the named page with the given data.
+func (r *Renderer) Render(w io.Writer, page string, data any) error {
+	tmpl, err := r.page(page)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
+
+// RenderLayout executes the named layout within the named page's template set.
+// This is useful when a page only defines blocks, and the layout to use is decided by the caller.
+func (r *Renderer) RenderLayout(w io.Writer, layout, page string, data any) error {
+	tmpl, err := r.page(page)
+	if err != nil {
+		return err
+	}
+	return tmpl.ExecuteTemplate(w, layout, data)
+}
+
+// Handler returns a [http.Handler] that renders the named page.
+// The data function is called for each request to get the data passed to the template, and may be nil.
+// The page is rendered to a buffer first, so a template error results in a 500 status rather than a partial page.
+// Errors from the data function or template are logged with the logger set by [OptLogger], and aren't sent to the client.
+func (r *Renderer) Handler(page string, data func(req *http.Request) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var (
+			pageData any
+			err      error
+		)
+		if data != nil {
+			pageData, err = data(req)
+			if err != nil {
+				r.serverError(w, req, page, err)
+				return
+			}
+		}
+		var buf bytes.Buffer
+		if err := r.Render(&buf, page, pageData); err != nil {
+			r.serverError(w, req, page, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+func (r *Renderer) serverError(w http.ResponseWriter, req *http.Request, page string, err error) {
+	r.conf.logger.Error("Failed to render page", slog.String("page", page), slog.String("path", req.URL.Path), slog.Any("error", err))
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
@@ -0,0 +1,121 @@
+package tmplx
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"layouts/base.html":      {Data: []byte(`<html><title>{{ block "title" . }}Default{{ end }}</title><body>{{ block "content" . }}{{ end }}</body></html>`)},
+		"partials/greeting.tmpl": {Data: []byte(`<p>Hello, {{ .Name | upper }}</p>`)},
+		"pages/index.html":       {Data: []byte(`{{ define "title" }}Home{{ end }}{{ define "content" }}{{ template "greeting" . }}{{ end }}{{ template "base" . }}`)},
+		"pages/admin/users.html": {Data: []byte(`{{ define "content" }}{{ .Name }}{{ end }}{{ template "base" . }}`)},
+		"pages/notes.txt":        {Data: []byte(`Not a template`)},
+	}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	r, err := NewRenderer(testFS())
+	require.NoError(t, err)
+
+	pages, err := r.Pages()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin/users", "index"}, pages)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Render(&buf, "index", map[string]string{"Name": "<b>world</b>"}))
+	assert.Equal(t, `<html><title>Home</title><body><p>Hello, &lt;B&gt;WORLD&lt;/B&gt;</p></body></html>`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, r.Render(&buf, "admin/users", map[string]string{"Name": "Bob"}))
+	assert.Equal(t, `<html><title>Default</title><body>Bob</body></html>`, buf.String())
+
+	assert.ErrorIs(t, r.Render(&buf, "missing", nil), ErrPageNotFound)
+}
+
+func TestRenderer_RenderLayout(t *testing.T) {
+	fsys := testFS()
+	fsys["pages/blocks.html"] = &fstest.MapFile{Data: []byte(`{{ define "content" }}Blocks only{{ end }}`)}
+	r, err := NewRenderer(fsys)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.RenderLayout(&buf, "base", "blocks", nil))
+	assert.Equal(t, `<html><title>Default</title><body>Blocks only</body></html>`, buf.String())
+}
+
+func TestRenderer_Reload(t *testing.T) {
+	fsys := testFS()
+	r, err := NewRenderer(fsys, OptReload(true))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Render(&buf, "admin/users", map[string]string{"Name": "Bob"}))
+	assert.Contains(t, buf.String(), "Bob")
+
+	fsys["pages/admin/users.html"] = &fstest.MapFile{Data: []byte(`Updated {{ .Name }}`)}
+	buf.Reset()
+	require.NoError(t, r.Render(&buf, "admin/users", map[string]string{"Name": "Bob"}))
+	assert.Equal(t, "Updated Bob", buf.String())
+}
+
+func TestRenderer_Options(t *testing.T) {
+	fsys := fstest.MapFS{
+		"views/page.gohtml": {Data: []byte(`{{ shout .Name }}`)},
+	}
+	r, err := NewRenderer(fsys,
+		OptPageDir("views"),
+		OptExtensions(".gohtml"),
+		OptFuncs(map[string]any{
+			"shout": func(s string) string { return s + "!" },
+		}),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Render(&buf, "page", map[string]string{"Name": "hey"}))
+	assert.Equal(t, "hey!", buf.String())
+}
+
+func TestRenderer_ParseError(t *testing.T) {
+	fsys := testFS()
+	fsys["pages/broken.html"] = &fstest.MapFile{Data: []byte(`{{ .Name `)}
+	_, err := NewRenderer(fsys)
+	assert.Error(t, err)
+}
+
+func TestRenderer_Handler(t *testing.T) {
+	var logs bytes.Buffer
+	r, err := NewRenderer(testFS(), OptLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	require.NoError(t, err)
+
+	h := r.Handler("index", func(req *http.Request) (any, error) {
+		return map[string]string{"Name": req.URL.Query().Get("name")}, nil
+	})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?name=test", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "Hello, TEST")
+
+	h = r.Handler("index", func(req *http.Request) (any, error) {
+		return nil, errors.New("no data")
+	})
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "no data", "Error details should not be sent to the client")
+	assert.Contains(t, logs.String(), "no data", "Error details should be logged")
+
+	rec = httptest.NewRecorder()
+	r.Handler("missing", nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}